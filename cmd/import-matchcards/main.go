@@ -87,6 +87,7 @@ func main() {
 		playerRepo,
 		divisionRepo,
 		seasonRepo,
+		repository.NewUnitOfWork(db),
 		appCfg.HomeClubID,
 	)
 
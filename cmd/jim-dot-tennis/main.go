@@ -9,21 +9,30 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
+	"syscall"
 	"time"
 
 	"jim-dot-tennis/internal/admin"
 	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/config"
 	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/health"
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/observability"
 	"jim-dot-tennis/internal/players"
+	"jim-dot-tennis/internal/ratelimit"
 	"jim-dot-tennis/internal/repository"
+	"jim-dot-tennis/internal/templates"
 	"jim-dot-tennis/internal/webpush"
 )
 
 func main() {
+	// Structured logs; existing log.Printf calls are routed through slog too
+	observability.SetupLogging()
+
 	// Get project root directory
 	projectRoot, err := getProjectRoot()
 	if err != nil {
@@ -36,7 +45,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to set up database: %v", err)
 	}
-	defer db.Close()
 
 	// Execute migrations
 	migrationsPath := filepath.Join(projectRoot, "migrations")
@@ -73,6 +81,11 @@ func main() {
 	} else if removed > 0 {
 		log.Printf("Cleaned up %d stale push subscriptions", removed)
 	}
+	if removed, err := pushService.CleanupOldDeliveries(180 * 24 * time.Hour); err != nil {
+		log.Printf("Warning: Failed to clean up old push delivery records: %v", err)
+	} else if removed > 0 {
+		log.Printf("Cleaned up %d old push delivery records", removed)
+	}
 
 	// Set up auth service
 	authConfig := auth.DefaultConfig()
@@ -80,14 +93,35 @@ func main() {
 	if os.Getenv("APP_ENV") != "production" {
 		authConfig.CookieSecure = false
 	}
+	authConfig.RequireAdminMFA = os.Getenv("REQUIRE_ADMIN_2FA") == "true"
 	authService := auth.NewService(db, authConfig)
 
 	// Set up repositories for fantasy token auth
 	playerRepo := repository.NewPlayerRepository(db)
 	fantasyMatchRepo := repository.NewFantasyMixedDoublesRepository(db)
+	playerTokenRepo := repository.NewPlayerTokenRepository(db)
 
 	// Set up auth middleware
-	authMiddleware := auth.NewMiddleware(authService, playerRepo, fantasyMatchRepo)
+	authMiddleware := auth.NewMiddleware(authService, playerRepo, fantasyMatchRepo, playerTokenRepo)
+
+	// CSRF protection for every form post, HTMX request and fetch call. The
+	// exempt routes authenticate with a secret in the request itself and are
+	// called by the service worker, which can't read the token cookie.
+	csrf := auth.NewCSRF(authService, auth.NewLinkSigner(db),
+		"/push-action/",         // signed notification action links
+		"/api/push/resubscribe", // service worker replacing a rotated subscription, proven by its old endpoint
+	)
+
+	// Rate limits for the login forms, player links and push API
+	trustedProxies, err := ratelimit.TrustedProxiesFromEnv()
+	if err != nil {
+		log.Fatalf("Rate limit configuration error: %v", err)
+	}
+	rateLimitGroups, err := ratelimit.ApplyEnv(ratelimit.DefaultGroups(ratelimit.NewClientIP(trustedProxies)))
+	if err != nil {
+		log.Fatalf("Rate limit configuration error: %v", err)
+	}
+	rateLimits := ratelimit.NewMiddleware(ratelimit.NewLimiter(ratelimit.DefaultBackoff), rateLimitGroups...)
 
 	// Set up auth handlers
 	templateDir := filepath.Join(projectRoot, "templates")
@@ -99,22 +133,24 @@ func main() {
 		courthiveAPIURL = "http://courthive-server:8383"
 	}
 	adminHandler := admin.New(db, templateDir, courthiveAPIURL, appConfig.HomeClubID, appConfig.BHPLTAClubCode, pushService)
+	adminHandler.SetRateLimiter(rateLimits.Limiter())
 
 	// Set up players handlers
-	playersHandler := players.New(db, templateDir, appConfig.HomeClubID)
+	playersHandler := players.New(db, templateDir, appConfig.HomeClubID, pushService)
 
-	// Set up template functions
-	templateFuncs := template.FuncMap{
-		"currentYear": func() int {
-			return time.Now().Year()
-		},
-	}
-
-	// Load templates with functions
-	templates, err := loadTemplatesWithFuncs(templateDir, templateFuncs)
+	// Parse every template now so a broken one stops startup rather than
+	// failing the first request that uses it
+	loaded, err := templates.LoadAll(templateDir)
 	if err != nil {
 		log.Fatalf("Failed to load templates: %v", err)
 	}
+	log.Printf("Loaded %d templates from %s", loaded, templateDir)
+	if templates.ReloadEnabled() {
+		if err := templates.Watch(context.Background(), templateDir); err != nil {
+			log.Fatalf("Failed to watch templates: %v", err)
+		}
+		log.Printf("Reloading templates when they change (DEV_TEMPLATES_RELOAD)")
+	}
 
 	// Set up routes
 	mux := http.NewServeMux()
@@ -124,6 +160,7 @@ func main() {
 
 	// Auth routes
 	authHandler.RegisterRoutes(mux)
+	authHandler.RegisterProtectedRoutes(mux, authMiddleware)
 
 	// Admin routes (protected)
 	adminHandler.RegisterRoutes(mux, authMiddleware)
@@ -137,6 +174,23 @@ func main() {
 	// Public player-facing routes (standings, etc.)
 	playersHandler.RegisterPublicRoutes(mux)
 
+	// Liveness and readiness probes
+	checker := health.NewChecker()
+	checker.AddCheck("database", db.PingContext)
+	checker.AddCheck("migrations", func(ctx context.Context) error {
+		return db.CheckMigrations(ctx, migrationsPath)
+	})
+	checker.AddCheck("templates", func(ctx context.Context) error {
+		_, err := pageTemplates.Get(templateDir, "index.html")
+		return err
+	})
+	checker.RegisterRoutes(mux)
+
+	// Prometheus metrics, for scrapers on the private network or holding
+	// METRICS_TOKEN
+	observability.RegisterActiveSessions(authService.CountActiveSessions)
+	mux.Handle("/metrics", observability.MetricsHandler())
+
 	// Dynamic manifest — allows per-player start_url for PWA install
 	mux.HandleFunc("/api/manifest", func(w http.ResponseWriter, r *http.Request) {
 		startURL := r.URL.Query().Get("start")
@@ -164,9 +218,7 @@ func main() {
 
 	// About page (public, no auth required)
 	mux.HandleFunc("/about", func(w http.ResponseWriter, r *http.Request) {
-		if err := templates.ExecuteTemplate(w, "about.html", nil); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		renderPage(w, templateDir, "about.html", nil)
 	})
 
 	// Public routes
@@ -186,20 +238,15 @@ func main() {
 		data := map[string]interface{}{
 			"Tournaments": visibleTournaments,
 		}
-		if err := templates.ExecuteTemplate(w, "index.html", data); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		renderPage(w, templateDir, "index.html", data)
 	})
 
 	// Serve static files with special handling for service worker
 	staticDir := filepath.Join(projectRoot, "static")
 	fs := http.FileServer(http.Dir(staticDir))
 	mux.Handle("/static/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("Static file request: %s", r.URL.Path)
-
 		// Add Service-Worker-Allowed header for service worker file
 		if r.URL.Path == "/static/service-worker.js" {
-			log.Printf("Service worker request detected, setting Service-Worker-Allowed header")
 			w.Header().Set("Service-Worker-Allowed", "/")
 		}
 
@@ -210,13 +257,44 @@ func main() {
 	port := getPort()
 	server := &http.Server{
 		Addr:         ":" + port,
-		Handler:      config.HomeClubMiddleware(appConfig, mux),
+		Handler:      observability.Middleware(mux, rateLimits.Protect(csrf.Protect(config.HomeClubMiddleware(appConfig, mux)))),
 		ReadTimeout:  30 * time.Second,  // Generous for mobile
 		WriteTimeout: 30 * time.Second,  // Generous for mobile
 		IdleTimeout:  120 * time.Second, // Keep connections alive
 	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
 	log.Printf("Server started at http://localhost:%s", port)
-	log.Fatal(server.ListenAndServe())
+
+	// On SIGTERM (a deploy) or Ctrl-C, stop accepting connections and let
+	// in-flight requests and background push sends finish before closing
+	// the database
+	stopping, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-serverErr:
+		db.Close()
+		log.Fatalf("Server failed: %v", err)
+	case <-stopping.Done():
+	}
+	stop()
+
+	timeout := getShutdownTimeout()
+	log.Printf("Shutting down, waiting up to %s for in-flight work", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Warning: requests still running at shutdown deadline: %v", err)
+	}
+	if err := pushService.Drain(ctx); err != nil {
+		log.Printf("Warning: push sends still running at shutdown deadline: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	log.Printf("Shutdown complete")
 }
 
 // getProjectRoot returns the project root directory
@@ -236,21 +314,28 @@ func getProjectRoot() (string, error) {
 	return cwd, nil
 }
 
-// loadTemplatesWithFuncs loads templates with function map
-func loadTemplatesWithFuncs(templateDir string, funcMap template.FuncMap) (*template.Template, error) {
-	log.Printf("Loading templates from: %s", templateDir)
+// pageTemplates caches the public pages served from main
+var pageTemplates = templates.NewSet("pages", parsePage, "index.html", "about.html")
 
-	// Create a new template with the function map
-	tmpl := template.New("").Funcs(funcMap)
+// parsePage parses one of the public pages
+func parsePage(templateDir, name string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"currentYear": func() int {
+			return time.Now().Year()
+		},
+	}
+	return template.New(name).Funcs(funcMap).ParseFiles(filepath.Join(templateDir, name))
+}
 
-	// Parse all HTML files in the template directory
-	pattern := filepath.Join(templateDir, "*.html")
-	templates, err := tmpl.ParseGlob(pattern)
+// renderPage renders one of the public pages
+func renderPage(w http.ResponseWriter, templateDir, name string, data interface{}) {
+	tmpl, err := pageTemplates.Get(templateDir, name)
+	if err == nil {
+		err = tmpl.ExecuteTemplate(w, name, data)
+	}
 	if err != nil {
-		return nil, err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
-
-	return templates, nil
 }
 
 // setupDatabase initializes the database connection
@@ -286,6 +371,18 @@ func getPort() string {
 	return port
 }
 
+// defaultShutdownTimeout leaves a margin inside the 30s stop_grace_period
+// set for the app in docker-compose
+const defaultShutdownTimeout = 25 * time.Second
+
+// getShutdownTimeout reads SHUTDOWN_TIMEOUT (e.g. "25s"), falling back to the default
+func getShutdownTimeout() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SHUTDOWN_TIMEOUT")); err == nil && d > 0 {
+		return d
+	}
+	return defaultShutdownTimeout
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,21 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package main
+
+import (
+	"testing"
+
+	"jim-dot-tennis/internal/templates"
+)
+
+// Every template the app serves, partials included, parses with the
+// functions its package provides
+func TestAllTemplatesParse(t *testing.T) {
+	loaded, err := templates.LoadAll("../../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded == 0 {
+		t.Fatal("no templates loaded")
+	}
+}
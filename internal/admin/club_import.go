@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"jim-dot-tennis/internal/observability"
 	"jim-dot-tennis/internal/services"
 )
 
@@ -80,7 +81,7 @@ func (h *ClubImportHandler) processImport(w http.ResponseWriter, r *http.Request
 	dryRun := r.FormValue("dry_run") == "on"
 
 	scraper := services.NewClubScraper(h.service.db, h.service.clubRepository, dryRun, true)
-	ctx := context.Background()
+	ctx := context.WithoutCancel(r.Context())
 
 	var summary *services.ClubScraperSummary
 	var err error
@@ -91,6 +92,11 @@ func (h *ClubImportHandler) processImport(w http.ResponseWriter, r *http.Request
 		summary, err = scraper.ScrapeAll(ctx)
 	}
 
+	itemErrors := 0
+	if summary != nil {
+		itemErrors = len(summary.Errors)
+	}
+	observability.RecordImportRun("club_data", time.Since(startTime), err, itemErrors)
 	processingTime := time.Since(startTime).Milliseconds()
 
 	if err != nil {
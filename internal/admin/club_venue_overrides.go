@@ -0,0 +1,145 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// venueOverrideDateFormat is the date input format on the club page
+const venueOverrideDateFormat = "2006-01-02"
+
+// GetClubVenueOverrides lists a club's date-range venue overrides, newest
+// first, with the replacement venue loaded for display
+func (s *Service) GetClubVenueOverrides(ctx context.Context, clubID uint) ([]models.VenueOverride, error) {
+	overrides, err := s.venueOverrideRepository.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range overrides {
+		if venue, err := s.clubRepository.FindByID(ctx, overrides[i].VenueClubID); err == nil {
+			overrides[i].VenueClub = venue
+		}
+	}
+	return overrides, nil
+}
+
+// handleVenueOverride handles the club page's override forms:
+// POST /admin/league/clubs/{id}/venue-overrides saves one (an override_id
+// field edits an existing one) and
+// POST /admin/league/clubs/{id}/venue-overrides/{overrideID}/delete removes it.
+// Both go through the service so affected fixtures notify their players.
+func (h *ClubsHandler) handleVenueOverride(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/league/clubs/"), "/"), "/")
+	if len(pathParts) < 2 || pathParts[1] != "venue-overrides" {
+		http.Error(w, "Invalid venue override URL", http.StatusBadRequest)
+		return
+	}
+	clubID, err := strconv.ParseUint(pathParts[0], 10, 32)
+	if err != nil {
+		logAndError(w, "Invalid club ID", err, http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		logAndError(w, "Failed to parse form data", err, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case len(pathParts) == 4 && pathParts[3] == "delete":
+		overrideID, err := strconv.ParseUint(pathParts[2], 10, 32)
+		if err != nil {
+			logAndError(w, "Invalid venue override ID", err, http.StatusBadRequest)
+			return
+		}
+		if !h.overrideBelongsToClub(r.Context(), uint(overrideID), uint(clubID)) {
+			http.Error(w, "Venue override not found", http.StatusNotFound)
+			return
+		}
+		if err := h.service.DeleteVenueOverride(r.Context(), uint(overrideID)); err != nil {
+			logAndError(w, "Failed to delete venue override", err, http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/admin/league/clubs/%d?success=override_deleted", clubID), http.StatusSeeOther)
+	case len(pathParts) == 2:
+		override, problem := venueOverrideFromForm(r, uint(clubID))
+		if problem != "" {
+			http.Redirect(w, r, fmt.Sprintf("/admin/league/clubs/%d?error=%s", clubID, url.QueryEscape(problem)), http.StatusSeeOther)
+			return
+		}
+		if override.ID != 0 && !h.overrideBelongsToClub(r.Context(), override.ID, uint(clubID)) {
+			http.Error(w, "Venue override not found", http.StatusNotFound)
+			return
+		}
+		if err := h.service.SaveVenueOverride(r.Context(), override); err != nil {
+			logAndError(w, "Failed to save venue override", err, http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/admin/league/clubs/%d?success=override_saved", clubID), http.StatusSeeOther)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// overrideBelongsToClub guards edits and deletes against another club's override
+func (h *ClubsHandler) overrideBelongsToClub(ctx context.Context, overrideID, clubID uint) bool {
+	existing, err := h.service.venueOverrideRepository.FindByID(ctx, overrideID)
+	if err != nil {
+		log.Printf("Failed to load venue override %d: %v", overrideID, err)
+		return false
+	}
+	return existing.ClubID == clubID
+}
+
+// venueOverrideFromForm reads the override form, returning a message for the
+// page when the input doesn't make sense
+func venueOverrideFromForm(r *http.Request, clubID uint) (*models.VenueOverride, string) {
+	override := &models.VenueOverride{
+		ClubID: clubID,
+		Reason: strings.TrimSpace(r.FormValue("reason")),
+	}
+	if id := r.FormValue("override_id"); id != "" {
+		parsed, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, "Invalid venue override"
+		}
+		override.ID = uint(parsed)
+	}
+
+	venueClubID, err := strconv.ParseUint(r.FormValue("venue_club_id"), 10, 32)
+	if err != nil || venueClubID == 0 {
+		return nil, "Choose the venue the club is playing at instead"
+	}
+	if uint(venueClubID) == clubID {
+		return nil, "The replacement venue must be a different club"
+	}
+	override.VenueClubID = uint(venueClubID)
+
+	start, err := time.Parse(venueOverrideDateFormat, r.FormValue("start_date"))
+	if err != nil {
+		return nil, "Enter a valid start date"
+	}
+	end, err := time.Parse(venueOverrideDateFormat, r.FormValue("end_date"))
+	if err != nil {
+		return nil, "Enter a valid end date"
+	}
+	if end.Before(start) {
+		return nil, "The end date must be on or after the start date"
+	}
+	override.StartDate, override.EndDate = start, end
+	return override, ""
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/webpush"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Saving or removing a date-range override from the club page moves the
+// club's home fixtures, so it must log the venue change and push it to the
+// selected players, once each way.
+func TestVenueOverrideFormsNotifyAffectedFixtures(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "venue_overrides.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	matchNight := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 14).Add(19 * time.Hour)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`, matchNight.AddDate(0, -1, 0), matchNight.AddDate(0, 3, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`, matchNight, matchNight.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', ''), (3, 'Preston', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'St Ann''s A', 1, 1, 1), (3, 'Preston A', 3, 1, 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (1, 1, 3, 1, 1, 1, ?, '', 'Scheduled', '')`, matchNight)
+	for i, tour := range []string{"WTA", "ATP", "WTA", "ATP"} {
+		exec(`INSERT INTO tennis_players (id, first_name, last_name, common_name, nationality, gender, current_rank,
+			highest_rank, year_pro, wikipedia_url, hand, birth_date, birth_place, tour)
+			VALUES (?, 'Pro', 'Player', 'Pro', 'GBR', '', ?, ?, 2010, '', 'Right', '1990-01-01', '', ?)`, i+1, i+1, i+1, tour)
+	}
+	exec(`INSERT INTO fantasy_mixed_doubles (id, team_a_woman_id, team_a_man_id, team_b_woman_id, team_b_man_id, auth_token) VALUES (1, 1, 2, 3, 4, 'ann-token')`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id, fantasy_match_id) VALUES ('ann', 'Ann', 'Able', 1, 1)`)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id) VALUES (1, 'ann', 1, 1, 1)`)
+
+	var mu sync.Mutex
+	var pushes int
+	pushServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		pushes++
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushServer.Close()
+
+	push := webpush.New(db)
+	if _, _, err := push.GenerateVAPIDKeys(); err != nil {
+		t.Fatalf("vapid keys: %v", err)
+	}
+	p256dh, authSecret := testBrowserKeys(t)
+	token := "ann-token"
+	if err := push.SaveSubscription(&webpush.Subscription{Endpoint: pushServer.URL + "/ann", P256dh: p256dh, Auth: authSecret, PlayerToken: &token}); err != nil {
+		t.Fatalf("save subscription: %v", err)
+	}
+
+	service := NewService(db, "", 1, "", push)
+	h := NewClubsHandler(service, filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"))
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "admin"}))
+		rec := httptest.NewRecorder()
+		h.HandleClubs(rec, req)
+		return rec
+	}
+	pushCount := func() int {
+		t.Helper()
+		if err := push.Drain(ctx); err != nil {
+			t.Fatalf("drain: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return pushes
+	}
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+
+	// Courts resurfaced: the fixture moves to Hove
+	rec := post("/admin/league/clubs/1/venue-overrides", url.Values{
+		"venue_club_id": {"2"}, "start_date": {day(matchNight.AddDate(0, 0, -3))}, "end_date": {day(matchNight.AddDate(0, 0, 3))}, "reason": {"Resurfacing"},
+	})
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Location"), "success=override_saved") {
+		t.Fatalf("save override = %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	changes, err := service.GetFixtureChanges(ctx, 1)
+	if err != nil {
+		t.Fatalf("load changes: %v", err)
+	}
+	if len(changes) != 1 || changes[0].ChangeType != models.FixtureChangeVenue || changes[0].OldValue != "St Ann's" || changes[0].NewValue != "Hove" {
+		t.Fatalf("changes after saving = %+v", changes)
+	}
+	if n := pushCount(); n != 1 {
+		t.Errorf("pushes after saving = %d; want 1", n)
+	}
+
+	overrides, err := service.GetClubVenueOverrides(ctx, 1)
+	if err != nil || len(overrides) != 1 || overrides[0].VenueClub == nil || overrides[0].VenueClub.Name != "Hove" {
+		t.Fatalf("overrides = %+v, %v", overrides, err)
+	}
+	overridePath := "/venue-overrides/" + strconv.FormatUint(uint64(overrides[0].ID), 10) + "/delete"
+
+	req := httptest.NewRequest("GET", "/admin/league/clubs/1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "admin"}))
+	page := httptest.NewRecorder()
+	h.HandleClubs(page, req)
+	if body := page.Body.String(); !strings.Contains(body, `data-testid="venue-overrides"`) || !strings.Contains(body, "Resurfacing") {
+		t.Errorf("club page doesn't list the override")
+	}
+
+	// Another club can't remove it, and bad input never reaches the service
+	if rec := post("/admin/league/clubs/3"+overridePath, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("delete from another club = %d; want 404", rec.Code)
+	}
+	if rec := post("/admin/league/clubs/1/venue-overrides", url.Values{"venue_club_id": {"1"}, "start_date": {day(matchNight)}, "end_date": {day(matchNight)}}); !strings.Contains(rec.Header().Get("Location"), "error=") {
+		t.Errorf("override to the club's own courts = %s; want an error", rec.Header().Get("Location"))
+	}
+
+	// Back home: the fixture moves back and players hear about it again
+	rec = post("/admin/league/clubs/1"+overridePath, nil)
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Location"), "success=override_deleted") {
+		t.Fatalf("delete override = %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	if changes, _ := service.GetFixtureChanges(ctx, 1); len(changes) != 2 || changes[1].NewValue != "St Ann's" {
+		t.Errorf("changes after removing = %+v", changes)
+	}
+	if n := pushCount(); n != 2 {
+		t.Errorf("pushes after removing = %d; want 2", n)
+	}
+}
+
+// testBrowserKeys returns a p256dh/auth pair like a browser hands over on
+// subscribe, so the real payload encryption runs
+func testBrowserKeys(t *testing.T) (p256dh, authSecret string) {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate auth: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(secret)
+}
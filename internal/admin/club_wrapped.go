@@ -8,8 +8,11 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"jim-dot-tennis/internal/config"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/sharecard"
 )
 
 // ClubWrappedHandler handles club-wide season wrapped requests
@@ -154,6 +157,8 @@ type ClubTimelineEvent struct {
 // Main club wrapped data structure - for all players collectively
 type ClubWrappedData struct {
 	ClubName               string
+	SeasonID               uint
+	SeasonName             string
 	SeasonYear             int
 	OverallStats           ClubOverallStats
 	FixtureBreakdown       ClubFixtureBreakdown
@@ -173,6 +178,9 @@ type ClubWrappedData struct {
 	SeasonHighlights       ClubSeasonHighlights
 	// Optional per-player section when accessed via player availability
 	Personal *PersonalWrappedData
+	// FrozenAt is when a finished season's edition was snapshotted; nil
+	// while the season is still being played
+	FrozenAt *time.Time `json:"-"`
 }
 
 // PersonalWrappedData: per-player season summary
@@ -224,20 +232,35 @@ func (h *ClubWrappedHandler) HandleWrapped(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Admins can throw away a finished season's snapshot to pick up
+	// corrected results
+	if r.Method == http.MethodPost && r.FormValue("action") == "refreeze" {
+		h.handleRefreeze(w, r)
+		return
+	}
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	season, seasons, err := h.wrappedSeason(r.Context(), r)
+	if err != nil {
+		logAndError(w, "Season not found", err, http.StatusNotFound)
+		return
+	}
+
 	// Generate wrapped data for all players (club-wide)
-	wrappedData, err := h.generateClubWrappedData(r.Context())
+	wrappedData, err := h.loadClubWrapped(r.Context(), season)
 	if err != nil {
 		logAndError(w, "Failed to generate wrapped data", err, http.StatusInternalServerError)
 		return
 	}
 
+	archive := h.wrappedArchive(r.Context(), "/admin/league/wrapped", seasons, wrappedData, "")
+	archive.CanRefreeze = wrappedData.FrozenAt != nil
+
 	// Render the wrapped pages
-	h.renderClubWrapped(w, user, wrappedData)
+	h.renderClubWrapped(w, user, wrappedData, archive, h.wrappedShare(wrappedData, ""))
 }
 
 // HandlePublicWrapped renders the club wrapped for non-admins using a simple password gate.
@@ -255,30 +278,43 @@ func (h *ClubWrappedHandler) HandlePublicWrapped(w http.ResponseWriter, r *http.
 	const cookieName = "wrapped_access"
 	cookie, err := r.Cookie(cookieName)
 	if err != nil || cookie.Value != "granted" {
+		// A shared link still unfurls, showing only its card
+		if token := r.URL.Query().Get("card"); token != "" && h.renderWrappedPreview(w, r, token) {
+			return
+		}
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
+	season, seasons, err := h.wrappedSeason(r.Context(), r)
+	if err != nil {
+		logAndError(w, "Season not found", err, http.StatusNotFound)
+		return
+	}
+
 	// Generate wrapped data (same as admin)
-	wrappedData, genErr := h.generateClubWrappedData(r.Context())
+	wrappedData, genErr := h.loadClubWrapped(r.Context(), season)
 	if genErr != nil {
 		logAndError(w, "Failed to generate wrapped data", genErr, http.StatusInternalServerError)
 		return
 	}
 
 	// If a player context cookie is present, enrich with personal stats
+	var playerID string
 	if playerCookie, perr := r.Cookie("wrapped_player_id"); perr == nil && playerCookie.Value != "" {
-		if personal := h.getPersonalWrappedData(r.Context(), playerCookie.Value); personal != nil {
+		playerID = playerCookie.Value
+		if personal := h.loadPersonalWrapped(r.Context(), season, playerID); personal != nil {
 			wrappedData.Personal = personal
 		}
 	}
 
 	// Render with a minimal user context label for template (no admin user)
-	h.renderClubWrapped(w, map[string]interface{}{"Username": "guest"}, wrappedData)
+	h.renderClubWrapped(w, map[string]interface{}{"Username": "guest"}, wrappedData,
+		h.wrappedArchive(r.Context(), "/club/wrapped", seasons, wrappedData, playerID), h.wrappedShare(wrappedData, playerID))
 }
 
-// getPersonalWrappedData builds a per-player season summary
-func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerID string) *PersonalWrappedData {
+// getPersonalWrappedData builds a player's summary of one season
+func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, seasonID uint, playerID string) *PersonalWrappedData {
 	pd := &PersonalWrappedData{PlayerID: playerID}
 
 	// Player display name
@@ -293,7 +329,8 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             SELECT m.*, mp.is_home
             FROM matchup_players mp
             INNER JOIN matchups m ON mp.matchup_id = m.id
-            WHERE mp.player_id = ? AND m.status = 'Finished'
+            INNER JOIN fixtures f ON m.fixture_id = f.id
+            WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         )
         SELECT 
             COUNT(DISTINCT fixture_id) as fixtures_played,
@@ -303,7 +340,7 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
                 + SUM(CASE WHEN home_score = away_score THEN 0.5 ELSE 0 END)
             ) * 100.0 / COUNT(*), 1) as win_pct
         FROM player_matchups
-    `, playerID).Scan(&pd.FixturesPlayed, &pd.MatchesPlayed, &pd.WinPercentage)
+    `, playerID, seasonID).Scan(&pd.FixturesPlayed, &pd.MatchesPlayed, &pd.WinPercentage)
 
 	// Home win percentage (weighted: win=1, draw=0.5)
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -311,7 +348,8 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
 			SELECT m.*
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
-			WHERE mp.player_id = ? AND m.status = 'Finished' AND mp.is_home = 1
+			INNER JOIN fixtures f ON m.fixture_id = f.id
+			WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ? AND mp.is_home = 1
 		)
 		SELECT CASE 
 			WHEN COUNT(*) > 0 THEN ROUND(((
@@ -319,7 +357,7 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
 				+ SUM(CASE WHEN home_score = away_score THEN 0.5 ELSE 0 END)
 			) * 100.0) / COUNT(*), 1) ELSE 0 END
 		FROM player_matchups
-	`, playerID).Scan(&pd.HomeWinPercentage)
+	`, playerID, seasonID).Scan(&pd.HomeWinPercentage)
 
 	// Away win percentage (weighted: win=1, draw=0.5)
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -327,7 +365,8 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
 			SELECT m.*
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
-			WHERE mp.player_id = ? AND m.status = 'Finished' AND mp.is_home = 0
+			INNER JOIN fixtures f ON m.fixture_id = f.id
+			WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ? AND mp.is_home = 0
 		)
 		SELECT CASE 
 			WHEN COUNT(*) > 0 THEN ROUND(((
@@ -335,7 +374,7 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
 				+ SUM(CASE WHEN home_score = away_score THEN 0.5 ELSE 0 END)
 			) * 100.0) / COUNT(*), 1) ELSE 0 END
 		FROM player_matchups
-	`, playerID).Scan(&pd.AwayWinPercentage)
+	`, playerID, seasonID).Scan(&pd.AwayWinPercentage)
 
 	// Unique partners
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -343,8 +382,9 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
         FROM matchup_players mp1
         JOIN matchup_players mp2 ON mp1.matchup_id = mp2.matchup_id AND mp1.is_home = mp2.is_home AND mp1.player_id <> mp2.player_id
         JOIN matchups m ON mp1.matchup_id = m.id
-        WHERE mp1.player_id = ? AND m.status = 'Finished'
-    `, playerID).Scan(&pd.UniquePartners)
+        JOIN fixtures f ON m.fixture_id = f.id
+        WHERE mp1.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
+    `, playerID, seasonID).Scan(&pd.UniquePartners)
 
 	// Three-set and tiebreak matches
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -353,8 +393,9 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             SUM(CASE WHEN (m.home_set3 >= 10 OR m.away_set3 >= 10) THEN 1 ELSE 0 END) AS tiebreaks
         FROM matchup_players mp
         JOIN matchups m ON mp.matchup_id = m.id
-        WHERE mp.player_id = ? AND m.status = 'Finished'
-    `, playerID).Scan(&pd.ThreeSetMatches, &pd.TiebreakMatches)
+        JOIN fixtures f ON m.fixture_id = f.id
+        WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
+    `, playerID, seasonID).Scan(&pd.ThreeSetMatches, &pd.TiebreakMatches)
 
 	// Division breakdown and most played division
 	rows, err := h.service.db.QueryContext(ctx, `
@@ -362,7 +403,8 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             SELECT m.fixture_id, mp.is_home, m.home_score, m.away_score
             FROM matchup_players mp
             JOIN matchups m ON mp.matchup_id = m.id
-            WHERE mp.player_id = ? AND m.status = 'Finished'
+            JOIN fixtures f ON m.fixture_id = f.id
+            WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         )
         SELECT d.name as division,
                COUNT(DISTINCT f.id) as fixtures,
@@ -375,7 +417,7 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
         JOIN divisions d ON d.id = f.division_id
         GROUP BY d.name
         ORDER BY fixtures DESC, d.name ASC
-    `, playerID)
+    `, playerID, seasonID)
 	if err == nil {
 		defer rows.Close()
 		var most string
@@ -404,8 +446,9 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             FROM matchup_players mp1
             JOIN matchup_players mp2 ON mp1.matchup_id = mp2.matchup_id AND mp1.is_home = mp2.is_home AND mp1.player_id <> mp2.player_id
             JOIN matchups m ON mp1.matchup_id = m.id
+            JOIN fixtures f ON m.fixture_id = f.id
             JOIN players p2 ON p2.id = mp2.player_id
-            WHERE mp1.player_id = ? AND m.status = 'Finished'
+            WHERE mp1.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
             GROUP BY mp2.player_id, partner_name
             HAVING COUNT(*) >= 2
         )
@@ -415,7 +458,7 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
         FROM my_pairs
         ORDER BY pct DESC, matches_together DESC, partner_name ASC
         LIMIT 1
-    `, playerID).Scan(&pd.BestPartnerName, &pd.BestPartnerMatchesTogether, &pd.BestPartnerWinPercentage)
+    `, playerID, seasonID).Scan(&pd.BestPartnerName, &pd.BestPartnerMatchesTogether, &pd.BestPartnerWinPercentage)
 
 	// Most frequent partner (by matches together)
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -426,26 +469,28 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             FROM matchup_players mp1
             JOIN matchup_players mp2 ON mp1.matchup_id = mp2.matchup_id AND mp1.is_home = mp2.is_home AND mp1.player_id <> mp2.player_id
             JOIN matchups m ON mp1.matchup_id = m.id
+            JOIN fixtures f ON m.fixture_id = f.id
             JOIN players p2 ON p2.id = mp2.player_id
-            WHERE mp1.player_id = ? AND m.status = 'Finished'
+            WHERE mp1.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
             GROUP BY mp2.player_id, partner_name
         )
         SELECT partner_name, matches_together
         FROM my_pairs
         ORDER BY matches_together DESC, partner_name ASC
         LIMIT 1
-    `, playerID).Scan(&pd.MostFrequentPartnerName, &pd.MostFrequentPartnerMatches)
+    `, playerID, seasonID).Scan(&pd.MostFrequentPartnerName, &pd.MostFrequentPartnerMatches)
 
 	// Most common matchup type
 	_ = h.service.db.QueryRowContext(ctx, `
         SELECT m.type as matchup_type, COUNT(*) as cnt
         FROM matchup_players mp
         JOIN matchups m ON mp.matchup_id = m.id
-        WHERE mp.player_id = ? AND m.status = 'Finished'
+        JOIN fixtures f ON m.fixture_id = f.id
+        WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         GROUP BY m.type
         ORDER BY cnt DESC, matchup_type ASC
         LIMIT 1
-    `, playerID).Scan(&pd.MostCommonMatchupType, &pd.MostCommonMatchupCount)
+    `, playerID, seasonID).Scan(&pd.MostCommonMatchupType, &pd.MostCommonMatchupCount)
 
 	// Deciding set performance
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -453,13 +498,14 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             SELECT m.home_set3, m.away_set3, mp.is_home, m.home_score, m.away_score
             FROM matchup_players mp
             JOIN matchups m ON mp.matchup_id = m.id
-            WHERE mp.player_id = ? AND m.status = 'Finished'
+            JOIN fixtures f ON m.fixture_id = f.id
+            WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         )
         SELECT 
             SUM(CASE WHEN (home_set3 IS NOT NULL OR away_set3 IS NOT NULL) THEN 1 ELSE 0 END) as deciding_matches,
             SUM(CASE WHEN (home_set3 IS NOT NULL OR away_set3 IS NOT NULL) AND ((is_home = 1 AND home_score > away_score) OR (is_home = 0 AND away_score > home_score)) THEN 1 ELSE 0 END) as deciding_wins
         FROM pm
-    `, playerID).Scan(&pd.DecidingSetMatches, &pd.DecidingSetWins)
+    `, playerID, seasonID).Scan(&pd.DecidingSetMatches, &pd.DecidingSetWins)
 	if pd.DecidingSetMatches > 0 {
 		pd.DecidingSetWinRate = float64(pd.DecidingSetWins) * 100.0 / float64(pd.DecidingSetMatches)
 	}
@@ -476,8 +522,9 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
         ) as bagels
         FROM matchup_players mp
         JOIN matchups m ON mp.matchup_id = m.id
-        WHERE mp.player_id = ? AND m.status = 'Finished'
-    `, playerID).Scan(&pd.BagelsDelivered)
+        JOIN fixtures f ON m.fixture_id = f.id
+        WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
+    `, playerID, seasonID).Scan(&pd.BagelsDelivered)
 
 	// Average games per set
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -491,11 +538,12 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
                 (CASE WHEN m.home_set3 IS NOT NULL AND m.away_set3 IS NOT NULL AND (m.home_set3 + m.away_set3) < 10 THEN 1 ELSE 0 END) AS sets
             FROM matchup_players mp
             JOIN matchups m ON mp.matchup_id = m.id
-            WHERE mp.player_id = ? AND m.status = 'Finished'
+            JOIN fixtures f ON m.fixture_id = f.id
+            WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         )
         SELECT ROUND(CASE WHEN SUM(sets) > 0 THEN CAST(SUM(games) AS FLOAT) / SUM(sets) ELSE 0 END, 2)
         FROM s
-    `, playerID).Scan(&pd.AverageGamesPerSet)
+    `, playerID, seasonID).Scan(&pd.AverageGamesPerSet)
 
 	// Comeback wins
 	_ = h.service.db.QueryRowContext(ctx, `
@@ -505,17 +553,18 @@ func (h *ClubWrappedHandler) getPersonalWrappedData(ctx context.Context, playerI
             THEN 1 ELSE 0 END)
         FROM matchup_players mp
         JOIN matchups m ON mp.matchup_id = m.id
-        WHERE mp.player_id = ? AND m.status = 'Finished' AND m.home_set1 IS NOT NULL AND m.away_set1 IS NOT NULL
-    `, playerID).Scan(&pd.ComebackWins)
+        JOIN fixtures f ON m.fixture_id = f.id
+        WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ? AND m.home_set1 IS NOT NULL AND m.away_set1 IS NOT NULL
+    `, playerID, seasonID).Scan(&pd.ComebackWins)
 
 	// Streaks
-	pd.LongestWinStreak, pd.LongestLosingStreak = h.computePlayerStreaks(ctx, playerID)
+	pd.LongestWinStreak, pd.LongestLosingStreak = h.computePlayerStreaks(ctx, seasonID, playerID)
 
 	return pd
 }
 
 // computePlayerStreaks computes longest win and losing streak for a player
-func (h *ClubWrappedHandler) computePlayerStreaks(ctx context.Context, playerID string) (int, int) {
+func (h *ClubWrappedHandler) computePlayerStreaks(ctx context.Context, seasonID uint, playerID string) (int, int) {
 	rows, err := h.service.db.QueryContext(ctx, `
         SELECT CASE 
             WHEN (mp.is_home = 1 AND m.home_score > m.away_score) OR (mp.is_home = 0 AND m.away_score > m.home_score) THEN 1
@@ -525,9 +574,9 @@ func (h *ClubWrappedHandler) computePlayerStreaks(ctx context.Context, playerID
         FROM matchup_players mp
         JOIN matchups m ON mp.matchup_id = m.id
         JOIN fixtures f ON f.id = m.fixture_id
-        WHERE mp.player_id = ? AND m.status = 'Finished'
+        WHERE mp.player_id = ? AND m.status = 'Finished' AND f.season_id = ?
         ORDER BY f.scheduled_date ASC, m.id ASC
-    `, playerID)
+    `, playerID, seasonID)
 	if err != nil {
 		return 0, 0
 	}
@@ -560,7 +609,7 @@ func (h *ClubWrappedHandler) computePlayerStreaks(ctx context.Context, playerID
 }
 
 // renderClubWrapped renders the club wrapped pages
-func (h *ClubWrappedHandler) renderClubWrapped(w http.ResponseWriter, user interface{}, wrappedData *ClubWrappedData) {
+func (h *ClubWrappedHandler) renderClubWrapped(w http.ResponseWriter, user interface{}, wrappedData *ClubWrappedData, archive WrappedArchive, share sharecard.Meta) {
 	// Load the club wrapped template
 	tmpl, err := parseTemplate(h.templateDir, "admin/wrapped_club.html")
 	if err != nil {
@@ -573,6 +622,8 @@ func (h *ClubWrappedHandler) renderClubWrapped(w http.ResponseWriter, user inter
 	templateData := map[string]interface{}{
 		"User":         user,
 		"WrappedData":  wrappedData,
+		"Archive":      archive,
+		"Share":        share,
 		"HomeClubName": wrappedData.ClubName,
 	}
 
@@ -582,7 +633,9 @@ func (h *ClubWrappedHandler) renderClubWrapped(w http.ResponseWriter, user inter
 }
 
 // generateClubWrappedData generates all wrapped statistics for the entire club
-func (h *ClubWrappedHandler) generateClubWrappedData(ctx context.Context) (*ClubWrappedData, error) {
+// for one season
+func (h *ClubWrappedHandler) generateClubWrappedData(ctx context.Context, season *models.Season) (*ClubWrappedData, error) {
+	seasonID := season.ID
 	clubName := "Tennis Club"
 	if club := config.GetHomeClub(ctx); club != nil {
 		clubName = club.Name
@@ -590,35 +643,37 @@ func (h *ClubWrappedHandler) generateClubWrappedData(ctx context.Context) (*Club
 
 	wrappedData := &ClubWrappedData{
 		ClubName:   clubName,
-		SeasonYear: 2025, // Could be dynamic
+		SeasonID:   season.ID,
+		SeasonName: season.Name,
+		SeasonYear: season.Year,
 	}
 
 	// Calculate all club statistics
-	if err := h.calculateClubOverallStats(ctx, &wrappedData.OverallStats); err != nil {
+	if err := h.calculateClubOverallStats(ctx, seasonID, &wrappedData.OverallStats); err != nil {
 		log.Printf("Error calculating club overall stats: %v", err)
 	}
 
-	if err := h.calculateClubFixtureBreakdown(ctx, &wrappedData.FixtureBreakdown); err != nil {
+	if err := h.calculateClubFixtureBreakdown(ctx, seasonID, &wrappedData.FixtureBreakdown); err != nil {
 		log.Printf("Error calculating club fixture breakdown: %v", err)
 	}
 
-	if err := h.calculateClubPlayingStylePlayers(ctx, &wrappedData.PlayingStylePlayers); err != nil {
+	if err := h.calculateClubPlayingStylePlayers(ctx, seasonID, &wrappedData.PlayingStylePlayers); err != nil {
 		log.Printf("Error calculating playing style players: %v", err)
 	}
 
-	wrappedData.ThreeSetWarriors = h.getClubThreeSetWarriors(ctx)
-	wrappedData.GameGrinders = h.getClubGameGrinders(ctx)
-	wrappedData.TopWinPercentage = h.getTopWinPercentagePlayers(ctx)          // New method call
-	wrappedData.TopPairings = h.getTopPairings(ctx)                           // New method call for top pairings
-	wrappedData.BestAwayVenues = h.getClubBestAwayVenues(ctx)                 // New method call
-	wrappedData.AvailabilityEngagement = h.getClubAvailabilityEngagement(ctx) // New method call for availability engagement
-	wrappedData.ComebackKings = h.getComebackKings(ctx)                       // New method call for comeback achievements
-	wrappedData.SocialButterflies = h.getSocialButterflies(ctx)               // New method call for social butterflies
-	wrappedData.TiebreakMasters = h.getTiebreakMasters(ctx)                   // New method call for tiebreak masters
-	wrappedData.DominatingWinners = h.getDominatingWinners(ctx)               // New method call for dominating wins
-	wrappedData.LuckyVenue = h.getClubLuckyVenue(ctx)
-
-	if err := h.calculateClubSeasonHighlights(ctx, &wrappedData.SeasonHighlights); err != nil {
+	wrappedData.ThreeSetWarriors = h.getClubThreeSetWarriors(ctx, seasonID)
+	wrappedData.GameGrinders = h.getClubGameGrinders(ctx, seasonID)
+	wrappedData.TopWinPercentage = h.getTopWinPercentagePlayers(ctx, seasonID)          // New method call
+	wrappedData.TopPairings = h.getTopPairings(ctx, seasonID)                           // New method call for top pairings
+	wrappedData.BestAwayVenues = h.getClubBestAwayVenues(ctx, seasonID)                 // New method call
+	wrappedData.AvailabilityEngagement = h.getClubAvailabilityEngagement(ctx, seasonID) // New method call for availability engagement
+	wrappedData.ComebackKings = h.getComebackKings(ctx, seasonID)                       // New method call for comeback achievements
+	wrappedData.SocialButterflies = h.getSocialButterflies(ctx, seasonID)               // New method call for social butterflies
+	wrappedData.TiebreakMasters = h.getTiebreakMasters(ctx, seasonID)                   // New method call for tiebreak masters
+	wrappedData.DominatingWinners = h.getDominatingWinners(ctx, seasonID)               // New method call for dominating wins
+	wrappedData.LuckyVenue = h.getClubLuckyVenue(ctx, seasonID)
+
+	if err := h.calculateClubSeasonHighlights(ctx, seasonID, &wrappedData.SeasonHighlights); err != nil {
 		log.Printf("Error calculating club season highlights: %v", err)
 	}
 
@@ -626,16 +681,16 @@ func (h *ClubWrappedHandler) generateClubWrappedData(ctx context.Context) (*Club
 }
 
 // Page 1: Calculate club overall statistics (all players combined)
-func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, stats *ClubOverallStats) error {
+func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, seasonID uint, stats *ClubOverallStats) error {
 	// Get total matchups across all teams
 	matchupQuery := `
 		SELECT COUNT(*)
 		FROM matchups m
 		INNER JOIN fixtures f ON m.fixture_id = f.id
-		WHERE m.status = 'Finished'
+		WHERE m.status = 'Finished' AND f.season_id = ?
 	`
 
-	err := h.service.db.QueryRowContext(ctx, matchupQuery).Scan(&stats.TotalMatchups)
+	err := h.service.db.QueryRowContext(ctx, matchupQuery, seasonID).Scan(&stats.TotalMatchups)
 	if err != nil {
 		return err
 	}
@@ -644,10 +699,10 @@ func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, stat
 	fixtureQuery := `
 		SELECT COUNT(*)
 		FROM fixtures f
-		WHERE f.status = 'Completed'
+		WHERE f.status = 'Completed' AND f.season_id = ?
 	`
 
-	err = h.service.db.QueryRowContext(ctx, fixtureQuery).Scan(&stats.TotalFixtures)
+	err = h.service.db.QueryRowContext(ctx, fixtureQuery, seasonID).Scan(&stats.TotalFixtures)
 	if err != nil {
 		return err
 	}
@@ -660,10 +715,11 @@ func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, stat
 		SELECT COUNT(DISTINCT mp.player_id)
 		FROM matchup_players mp
 		INNER JOIN matchups m ON mp.matchup_id = m.id
-		WHERE m.status = 'Finished'
+		INNER JOIN fixtures f ON m.fixture_id = f.id
+		WHERE m.status = 'Finished' AND f.season_id = ?
 	`
 
-	err = h.service.db.QueryRowContext(ctx, playersQuery).Scan(&stats.PlayersUsed)
+	err = h.service.db.QueryRowContext(ctx, playersQuery, seasonID).Scan(&stats.PlayersUsed)
 	if err != nil {
 		stats.PlayersUsed = 0
 	}
@@ -675,14 +731,15 @@ func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, stat
 			   COUNT(*) as matches_count
 		FROM matchup_players mp
 		INNER JOIN matchups m ON mp.matchup_id = m.id
+		INNER JOIN fixtures f ON m.fixture_id = f.id
 		INNER JOIN players p ON mp.player_id = p.id
-		WHERE m.status = 'Finished'
+		WHERE m.status = 'Finished' AND f.season_id = ?
 		GROUP BY p.id, name
 		ORDER BY matches_count DESC
 		LIMIT 1
 	`
 
-	err = h.service.db.QueryRowContext(ctx, activePlayerQuery).Scan(
+	err = h.service.db.QueryRowContext(ctx, activePlayerQuery, seasonID).Scan(
 		&stats.MostActivePlayer.ID, &stats.MostActivePlayer.Name, &stats.MostActivePlayer.MatchesCount)
 	if err != nil {
 		// No active player found
@@ -698,7 +755,7 @@ func (h *ClubWrappedHandler) calculateClubOverallStats(ctx context.Context, stat
 }
 
 // Page 2: Calculate club fixture breakdown (all fixtures)
-func (h *ClubWrappedHandler) calculateClubFixtureBreakdown(ctx context.Context, breakdown *ClubFixtureBreakdown) error {
+func (h *ClubWrappedHandler) calculateClubFixtureBreakdown(ctx context.Context, seasonID uint, breakdown *ClubFixtureBreakdown) error {
 	// Debug: First let's see what clubs exist
 	clubQuery := `SELECT id, name FROM clubs LIMIT 5`
 	clubRows, err := h.service.db.QueryContext(ctx, clubQuery)
@@ -736,12 +793,12 @@ func (h *ClubWrappedHandler) calculateClubFixtureBreakdown(ctx context.Context,
 		INNER JOIN matchups m ON f.id = m.fixture_id
 		INNER JOIN teams ht ON f.home_team_id = ht.id
 		INNER JOIN teams at ON f.away_team_id = at.id
-		WHERE f.status = 'Completed' AND m.status = 'Finished'
+		WHERE f.status = 'Completed' AND m.status = 'Finished' AND f.season_id = ?
 		  AND (ht.club_id = ? OR at.club_id = ?)
 		GROUP BY f.id, f.home_team_id, f.away_team_id, ht.club_id, at.club_id
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query, homeClubID, homeClubID)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID, homeClubID, homeClubID)
 	if err != nil {
 		log.Printf("=== DEBUG: Query error: %v ===", err)
 		return err
@@ -817,7 +874,7 @@ func (h *ClubWrappedHandler) calculateClubFixtureBreakdown(ctx context.Context,
 }
 
 // Page 3: Calculate playing style players for all club players
-func (h *ClubWrappedHandler) calculateClubPlayingStylePlayers(ctx context.Context, styles *ClubPlayingStyleStats) error {
+func (h *ClubWrappedHandler) calculateClubPlayingStylePlayers(ctx context.Context, seasonID uint, styles *ClubPlayingStyleStats) error {
 	// Get all players who played and their matchup types
 	query := `
 		SELECT 
@@ -827,13 +884,14 @@ func (h *ClubWrappedHandler) calculateClubPlayingStylePlayers(ctx context.Contex
 			GROUP_CONCAT(DISTINCT m.type) as matchup_types
 		FROM matchup_players mp
 		INNER JOIN matchups m ON mp.matchup_id = m.id
+		INNER JOIN fixtures f ON m.fixture_id = f.id
 		INNER JOIN players p ON mp.player_id = p.id
-		WHERE m.status = 'Finished'
+		WHERE m.status = 'Finished' AND f.season_id = ?
 		GROUP BY p.id, name
 		HAVING matches_count >= 3
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		return err
 	}
@@ -885,7 +943,7 @@ func (h *ClubWrappedHandler) calculateClubPlayingStylePlayers(ctx context.Contex
 }
 
 // Stub implementations for remaining methods (Pages 4, 5, 6, 7, 9, 10)
-func (h *ClubWrappedHandler) getClubThreeSetWarriors(ctx context.Context) []PlayerAchievement {
+func (h *ClubWrappedHandler) getClubThreeSetWarriors(ctx context.Context, seasonID uint) []PlayerAchievement {
 	// Page 4: Three-Set Warriors - players with highest proportion of 3-set matches
 	query := `
 		WITH player_set_stats AS (
@@ -907,8 +965,9 @@ func (h *ClubWrappedHandler) getClubThreeSetWarriors(ctx context.Context) []Play
 				) as three_set_percentage
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p ON mp.player_id = p.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 			GROUP BY p.id, name
 			HAVING total_matches >= 5
 		)
@@ -924,7 +983,7 @@ func (h *ClubWrappedHandler) getClubThreeSetWarriors(ctx context.Context) []Play
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting three set warriors: %v", err)
 		return []PlayerAchievement{}
@@ -969,7 +1028,7 @@ func (h *ClubWrappedHandler) getClubThreeSetWarriors(ctx context.Context) []Play
 	return achievements
 }
 
-func (h *ClubWrappedHandler) getClubGameGrinders(ctx context.Context) []PlayerAchievement {
+func (h *ClubWrappedHandler) getClubGameGrinders(ctx context.Context, seasonID uint) []PlayerAchievement {
 	// Page 5: Game Grinders - players with highest games per set
 	query := `
 		WITH player_game_stats AS (
@@ -995,8 +1054,9 @@ func (h *ClubWrappedHandler) getClubGameGrinders(ctx context.Context) []PlayerAc
 				) as total_games
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p ON mp.player_id = p.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 				AND (m.home_set1 IS NOT NULL OR m.home_set2 IS NOT NULL OR m.home_set3 IS NOT NULL)
 			GROUP BY p.id, name
 			HAVING total_matchups >= 5 AND total_sets >= 10
@@ -1013,7 +1073,7 @@ func (h *ClubWrappedHandler) getClubGameGrinders(ctx context.Context) []PlayerAc
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting game grinders: %v", err)
 		return []PlayerAchievement{}
@@ -1058,7 +1118,7 @@ func (h *ClubWrappedHandler) getClubGameGrinders(ctx context.Context) []PlayerAc
 	return achievements
 }
 
-func (h *ClubWrappedHandler) getClubBestAwayVenues(ctx context.Context) []ClubVenueStats {
+func (h *ClubWrappedHandler) getClubBestAwayVenues(ctx context.Context, seasonID uint) []ClubVenueStats {
 	homeClubID := config.GetHomeClubID(ctx)
 
 	// Find best away venues (excluding home fixtures and derbies)
@@ -1080,6 +1140,7 @@ func (h *ClubWrappedHandler) getClubBestAwayVenues(ctx context.Context) []ClubVe
 			INNER JOIN teams ht ON f.home_team_id = ht.id
 			WHERE f.status = 'Completed'
 				AND m.status = 'Finished'
+				AND f.season_id = ?
 				AND at.club_id = ?
 				AND ht.club_id != ?
 			GROUP BY f.venue_location, f.id
@@ -1098,7 +1159,7 @@ func (h *ClubWrappedHandler) getClubBestAwayVenues(ctx context.Context) []ClubVe
 		LIMIT 5
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query, homeClubID, homeClubID)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID, homeClubID, homeClubID)
 	if err != nil {
 		log.Printf("Error getting best away venues: %v", err)
 		return []ClubVenueStats{}
@@ -1142,21 +1203,23 @@ func (h *ClubWrappedHandler) getClubBestAwayVenues(ctx context.Context) []ClubVe
 	return venues
 }
 
-func (h *ClubWrappedHandler) getClubLuckyVenue(ctx context.Context) *ClubVenueStats {
+func (h *ClubWrappedHandler) getClubLuckyVenue(ctx context.Context, seasonID uint) *ClubVenueStats {
 	// Page 9: Lucky Venue - venue where club has best win percentage
 	return nil
 }
 
-func (h *ClubWrappedHandler) getClubAvailabilityEngagement(ctx context.Context) ClubAvailabilityEngagement {
+func (h *ClubWrappedHandler) getClubAvailabilityEngagement(ctx context.Context, seasonID uint) ClubAvailabilityEngagement {
 	// Page 9: Availability Engagement Stats
 	engagement := ClubAvailabilityEngagement{}
 
 	// Get players who set availability at least once (from availability exceptions table)
 	availabilityQuery := `
-		SELECT COUNT(DISTINCT player_id)
-		FROM player_availability_exceptions
+		SELECT COUNT(DISTINCT pae.player_id)
+		FROM player_availability_exceptions pae
+		JOIN seasons s ON s.id = ?
+		WHERE pae.start_date <= s.end_date AND pae.end_date >= s.start_date
 	`
-	err := h.service.db.QueryRowContext(ctx, availabilityQuery).Scan(&engagement.PlayersSetAvailability)
+	err := h.service.db.QueryRowContext(ctx, availabilityQuery, seasonID).Scan(&engagement.PlayersSetAvailability)
 	if err != nil {
 		log.Printf("Error getting players who set availability: %v", err)
 	}
@@ -1166,9 +1229,10 @@ func (h *ClubWrappedHandler) getClubAvailabilityEngagement(ctx context.Context)
 		SELECT COUNT(DISTINCT mp.player_id)
 		FROM matchup_players mp
 		INNER JOIN matchups m ON mp.matchup_id = m.id
-		WHERE m.status = 'Finished'
+		INNER JOIN fixtures f ON m.fixture_id = f.id
+		WHERE m.status = 'Finished' AND f.season_id = ?
 	`
-	err = h.service.db.QueryRowContext(ctx, playedQuery).Scan(&engagement.PlayersWhoPlayed)
+	err = h.service.db.QueryRowContext(ctx, playedQuery, seasonID).Scan(&engagement.PlayersWhoPlayed)
 	if err != nil {
 		log.Printf("Error getting players who played: %v", err)
 	}
@@ -1181,9 +1245,11 @@ func (h *ClubWrappedHandler) getClubAvailabilityEngagement(ctx context.Context)
 	// Get total availability updates (total records in exceptions table)
 	totalUpdatesQuery := `
 		SELECT COUNT(*)
-		FROM player_availability_exceptions
+		FROM player_availability_exceptions pae
+		JOIN seasons s ON s.id = ?
+		WHERE pae.start_date <= s.end_date AND pae.end_date >= s.start_date
 	`
-	err = h.service.db.QueryRowContext(ctx, totalUpdatesQuery).Scan(&engagement.TotalAvailabilityUpdates)
+	err = h.service.db.QueryRowContext(ctx, totalUpdatesQuery, seasonID).Scan(&engagement.TotalAvailabilityUpdates)
 	if err != nil {
 		log.Printf("Error getting total availability updates: %v", err)
 	}
@@ -1199,7 +1265,7 @@ func (h *ClubWrappedHandler) getClubAvailabilityEngagement(ctx context.Context)
 	return engagement
 }
 
-func (h *ClubWrappedHandler) getTopWinPercentagePlayers(ctx context.Context) []PlayerAchievement {
+func (h *ClubWrappedHandler) getTopWinPercentagePlayers(ctx context.Context, seasonID uint) []PlayerAchievement {
 	// Find players with highest win percentage (minimum 9 fixtures played)
 	query := `
 		WITH player_stats AS (
@@ -1223,7 +1289,7 @@ func (h *ClubWrappedHandler) getTopWinPercentagePlayers(ctx context.Context) []P
 			INNER JOIN matchups m ON mp.matchup_id = m.id
 			INNER JOIN players p ON mp.player_id = p.id
 			INNER JOIN fixtures f ON m.fixture_id = f.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 			GROUP BY p.id, name
 			HAVING fixtures_played >= 9
 		)
@@ -1238,7 +1304,7 @@ func (h *ClubWrappedHandler) getTopWinPercentagePlayers(ctx context.Context) []P
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting top win percentage players: %v", err)
 		return []PlayerAchievement{}
@@ -1288,7 +1354,7 @@ func (h *ClubWrappedHandler) getTopWinPercentagePlayers(ctx context.Context) []P
 	return achievements
 }
 
-func (h *ClubWrappedHandler) getTopPairings(ctx context.Context) []ClubPartnership {
+func (h *ClubWrappedHandler) getTopPairings(ctx context.Context, seasonID uint) []ClubPartnership {
 	// Find perfect partnerships with 100% win rate (minimum 2 matches together)
 	query := `
 		WITH pairing_stats AS (
@@ -1314,9 +1380,10 @@ func (h *ClubWrappedHandler) getTopPairings(ctx context.Context) []ClubPartnersh
 				AND mp1.is_home = mp2.is_home 
 				AND mp1.player_id < mp2.player_id  -- Avoid duplicate pairs and self-pairs
 			INNER JOIN matchups m ON mp1.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p1 ON mp1.player_id = p1.id
 			INNER JOIN players p2 ON mp2.player_id = p2.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 			GROUP BY p1.id, player1_name, p2.id, player2_name
 			HAVING matches_together >= 2
 		)
@@ -1333,7 +1400,7 @@ func (h *ClubWrappedHandler) getTopPairings(ctx context.Context) []ClubPartnersh
 		ORDER BY matches_together DESC, player1_name ASC
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting perfect partnerships: %v", err)
 		return []ClubPartnership{}
@@ -1378,7 +1445,7 @@ func (h *ClubWrappedHandler) getTopPairings(ctx context.Context) []ClubPartnersh
 	return partnerships
 }
 
-func (h *ClubWrappedHandler) getComebackKings(ctx context.Context) []ComebackAchievement {
+func (h *ClubWrappedHandler) getComebackKings(ctx context.Context, seasonID uint) []ComebackAchievement {
 	// Page 10: Comeback Kings/Queens - Players who won matches after losing the first set
 	query := `
         WITH pm AS (
@@ -1392,8 +1459,9 @@ func (h *ClubWrappedHandler) getComebackKings(ctx context.Context) []ComebackAch
                 m.home_score, m.away_score
             FROM matchup_players mp
             INNER JOIN matchups m ON mp.matchup_id = m.id
+            INNER JOIN fixtures f ON m.fixture_id = f.id
             INNER JOIN players p ON mp.player_id = p.id
-            WHERE m.status = 'Finished'
+            WHERE m.status = 'Finished' AND f.season_id = ?
                 AND m.home_set1 IS NOT NULL 
                 AND m.away_set1 IS NOT NULL
                 AND m.home_score IS NOT NULL
@@ -1432,7 +1500,7 @@ func (h *ClubWrappedHandler) getComebackKings(ctx context.Context) []ComebackAch
         LIMIT 10
     `
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting comeback kings: %v", err)
 		return []ComebackAchievement{}
@@ -1479,7 +1547,7 @@ func (h *ClubWrappedHandler) getComebackKings(ctx context.Context) []ComebackAch
 	return achievements
 }
 
-func (h *ClubWrappedHandler) getSocialButterflies(ctx context.Context) []SocialButterflyAchievement {
+func (h *ClubWrappedHandler) getSocialButterflies(ctx context.Context, seasonID uint) []SocialButterflyAchievement {
 	// Page 11: Social Butterflies - Players with most different partners
 	query := `
 		WITH player_partnerships AS (
@@ -1493,8 +1561,9 @@ func (h *ClubWrappedHandler) getSocialButterflies(ctx context.Context) []SocialB
 				AND mp1.is_home = mp2.is_home 
 				AND mp1.player_id != mp2.player_id  -- Don't count self
 			INNER JOIN matchups m ON mp1.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p1 ON mp1.player_id = p1.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 			GROUP BY mp1.player_id, player_name
 			HAVING total_partnerships >= 5  -- Minimum partnerships to qualify
 		)
@@ -1508,7 +1577,7 @@ func (h *ClubWrappedHandler) getSocialButterflies(ctx context.Context) []SocialB
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting social butterflies: %v", err)
 		return []SocialButterflyAchievement{}
@@ -1553,7 +1622,7 @@ func (h *ClubWrappedHandler) getSocialButterflies(ctx context.Context) []SocialB
 	return achievements
 }
 
-func (h *ClubWrappedHandler) getTiebreakMasters(ctx context.Context) []TiebreakMasterAchievement {
+func (h *ClubWrappedHandler) getTiebreakMasters(ctx context.Context, seasonID uint) []TiebreakMasterAchievement {
 	// Page 12: Championship Tiebreak Masters - Players in matches where final set had values >= 10
 	query := `
 		WITH tiebreak_stats AS (
@@ -1569,8 +1638,9 @@ func (h *ClubWrappedHandler) getTiebreakMasters(ctx context.Context) []TiebreakM
 				END) as tiebreak_wins
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p ON mp.player_id = p.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 				AND (
 					(m.home_set3 >= 10 OR m.away_set3 >= 10)  -- Championship tiebreak in set 3
 				)
@@ -1588,7 +1658,7 @@ func (h *ClubWrappedHandler) getTiebreakMasters(ctx context.Context) []TiebreakM
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting tiebreak masters: %v", err)
 		return []TiebreakMasterAchievement{}
@@ -1637,7 +1707,7 @@ func (h *ClubWrappedHandler) getTiebreakMasters(ctx context.Context) []TiebreakM
 
 // getDominatingWinners finds players who dominate their wins: among players with at least 5 wins,
 // compute the lowest average games per set considering only their won matches (championship tiebreaks excluded).
-func (h *ClubWrappedHandler) getDominatingWinners(ctx context.Context) []PlayerAchievement {
+func (h *ClubWrappedHandler) getDominatingWinners(ctx context.Context, seasonID uint) []PlayerAchievement {
 	query := `
 		WITH player_straight_wins AS (
 			SELECT 
@@ -1656,8 +1726,9 @@ func (h *ClubWrappedHandler) getDominatingWinners(ctx context.Context) []PlayerA
 				) AS total_games
 			FROM matchup_players mp
 			INNER JOIN matchups m ON mp.matchup_id = m.id
+			INNER JOIN fixtures f ON m.fixture_id = f.id
 			INNER JOIN players p ON mp.player_id = p.id
-			WHERE m.status = 'Finished'
+			WHERE m.status = 'Finished' AND f.season_id = ?
 				AND ((mp.is_home = 1 AND m.home_score > m.away_score) OR (mp.is_home = 0 AND m.away_score > m.home_score))
 				AND m.home_set3 IS NULL AND m.away_set3 IS NULL -- straight-set matches only
 			GROUP BY p.id, name
@@ -1675,7 +1746,7 @@ func (h *ClubWrappedHandler) getDominatingWinners(ctx context.Context) []PlayerA
 		LIMIT 10
 	`
 
-	rows, err := h.service.db.QueryContext(ctx, query)
+	rows, err := h.service.db.QueryContext(ctx, query, seasonID)
 	if err != nil {
 		log.Printf("Error getting dominating winners: %v", err)
 		return []PlayerAchievement{}
@@ -1718,7 +1789,7 @@ func (h *ClubWrappedHandler) getDominatingWinners(ctx context.Context) []PlayerA
 	return achievements
 }
 
-func (h *ClubWrappedHandler) calculateClubSeasonHighlights(ctx context.Context, highlights *ClubSeasonHighlights) error {
+func (h *ClubWrappedHandler) calculateClubSeasonHighlights(ctx context.Context, seasonID uint, highlights *ClubSeasonHighlights) error {
 	// Page 10: Season Highlights - timeline, biggest upset, etc.
 	return nil
 }
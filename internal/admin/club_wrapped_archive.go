@@ -0,0 +1,342 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// WrappedArchive is what a Wrapped page shows besides the season's stats:
+// links to every season's edition and how this season compares to the last
+type WrappedArchive struct {
+	BasePath    string
+	Seasons     []models.Season
+	Comparison  *WrappedComparison
+	CanRefreeze bool
+}
+
+// WrappedComparison sets a season's Wrapped against the season before
+type WrappedComparison struct {
+	PreviousSeasonID   uint
+	PreviousSeasonYear int
+	Club               []WrappedDelta
+	Personal           []WrappedDelta
+}
+
+// WrappedDelta is one stat this season against last season
+type WrappedDelta struct {
+	Label    string
+	Current  float64
+	Previous float64
+	Change   float64
+	Percent  bool // compared in percentage points
+	Sentence string
+}
+
+// wrappedSnapshot is a stored edition of a finished season's Wrapped
+type wrappedSnapshot struct {
+	Data      string    `db:"data"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// wrappedSeason picks the season a request is for: ?season= if given,
+// otherwise the active season, otherwise the most recent. It also returns
+// every season, newest first, for the archive links.
+func (h *ClubWrappedHandler) wrappedSeason(ctx context.Context, r *http.Request) (*models.Season, []models.Season, error) {
+	seasons, err := h.service.seasonRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load seasons: %w", err)
+	}
+	if len(seasons) == 0 {
+		return nil, nil, errors.New("no seasons")
+	}
+
+	if v := r.URL.Query().Get("season"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid season %q", v)
+		}
+		for i := range seasons {
+			if seasons[i].ID == uint(id) {
+				return &seasons[i], seasons, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("season %d not found", id)
+	}
+	for i := range seasons {
+		if seasons[i].IsActive {
+			return &seasons[i], seasons, nil
+		}
+	}
+	return &seasons[0], seasons, nil
+}
+
+// seasonFinished reports whether a season's Wrapped should be frozen: it is
+// no longer the active season and its last day has passed
+func seasonFinished(season *models.Season, now time.Time) bool {
+	return !season.IsActive && now.After(season.EndDate)
+}
+
+// loadClubWrapped returns the club edition for a season, from its snapshot
+// once the season has finished. The first view after a season finishes
+// builds and stores the snapshot.
+func (h *ClubWrappedHandler) loadClubWrapped(ctx context.Context, season *models.Season) (*ClubWrappedData, error) {
+	finished := seasonFinished(season, time.Now())
+	if finished {
+		var data ClubWrappedData
+		if frozenAt, ok := h.loadWrappedSnapshot(ctx, season.ID, "", &data); ok {
+			data.FrozenAt = &frozenAt
+			return &data, nil
+		}
+	}
+
+	data, err := h.generateClubWrappedData(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	if finished {
+		if frozenAt, ok := h.saveWrappedSnapshot(ctx, season.ID, "", data); ok {
+			data.FrozenAt = &frozenAt
+		}
+	}
+	return data, nil
+}
+
+// loadPersonalWrapped returns a player's edition for a season, frozen the
+// same way as the club edition. The player ID comes from a cookie, so
+// there's no edition, and nothing is stored, unless it names a real player
+// who played in the season.
+func (h *ClubWrappedHandler) loadPersonalWrapped(ctx context.Context, season *models.Season, playerID string) *PersonalWrappedData {
+	finished := seasonFinished(season, time.Now())
+	if finished {
+		var data PersonalWrappedData
+		if _, ok := h.loadWrappedSnapshot(ctx, season.ID, playerID, &data); ok {
+			return &data
+		}
+	}
+
+	if _, err := h.service.playerRepository.FindByID(ctx, playerID); err != nil {
+		return nil
+	}
+	data := h.getPersonalWrappedData(ctx, season.ID, playerID)
+	if data == nil || data.MatchesPlayed == 0 {
+		return nil
+	}
+	if finished {
+		h.saveWrappedSnapshot(ctx, season.ID, playerID, data)
+	}
+	return data
+}
+
+// loadWrappedSnapshot decodes a stored edition into v, returning when it
+// was frozen
+func (h *ClubWrappedHandler) loadWrappedSnapshot(ctx context.Context, seasonID uint, playerID string, v interface{}) (time.Time, bool) {
+	var snapshot wrappedSnapshot
+	err := h.service.db.GetContext(ctx, &snapshot, `
+		SELECT data, created_at FROM wrapped_snapshots WHERE season_id = ? AND player_id = ?
+	`, seasonID, playerID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error loading wrapped snapshot for season %d: %v", seasonID, err)
+		}
+		return time.Time{}, false
+	}
+	if err := json.Unmarshal([]byte(snapshot.Data), v); err != nil {
+		log.Printf("Error decoding wrapped snapshot for season %d: %v", seasonID, err)
+		return time.Time{}, false
+	}
+	return snapshot.CreatedAt, true
+}
+
+// saveWrappedSnapshot stores an edition unless one was stored first, and
+// returns when it was frozen
+func (h *ClubWrappedHandler) saveWrappedSnapshot(ctx context.Context, seasonID uint, playerID string, v interface{}) (time.Time, bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error encoding wrapped snapshot for season %d: %v", seasonID, err)
+		return time.Time{}, false
+	}
+	frozenAt := time.Now()
+	_, err = h.service.db.ExecContext(ctx, `
+		INSERT INTO wrapped_snapshots (season_id, player_id, data, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (season_id, player_id) DO NOTHING
+	`, seasonID, playerID, string(data), frozenAt)
+	if err != nil {
+		log.Printf("Error saving wrapped snapshot for season %d: %v", seasonID, err)
+		return time.Time{}, false
+	}
+	return frozenAt, true
+}
+
+// handleRefreeze deletes a season's snapshots, club and personal, so they
+// are rebuilt from current results on the next view
+func (h *ClubWrappedHandler) handleRefreeze(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := strconv.ParseUint(r.FormValue("season"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid season", http.StatusBadRequest)
+		return
+	}
+	if _, err := h.service.db.ExecContext(r.Context(), `DELETE FROM wrapped_snapshots WHERE season_id = ?`, seasonID); err != nil {
+		logAndError(w, "Failed to clear wrapped snapshots", err, http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/admin/league/wrapped?season=%d", seasonID), http.StatusSeeOther)
+}
+
+// wrappedArchive builds the archive links and compares the edition with
+// the previous season's, personal stats included when playerID is set
+func (h *ClubWrappedHandler) wrappedArchive(ctx context.Context, basePath string, seasons []models.Season, current *ClubWrappedData, playerID string) WrappedArchive {
+	archive := WrappedArchive{
+		BasePath: basePath,
+		Seasons:  seasons,
+	}
+
+	previous := previousSeason(seasons, current.SeasonID)
+	if previous == nil {
+		return archive
+	}
+	previousData, err := h.loadClubWrapped(ctx, previous)
+	if err != nil {
+		log.Printf("Error loading previous season's wrapped: %v", err)
+		return archive
+	}
+	if playerID != "" {
+		previousData.Personal = h.loadPersonalWrapped(ctx, previous, playerID)
+	}
+	archive.Comparison = compareWrapped(current, previousData)
+	return archive
+}
+
+// previousSeason is the season that started most recently before seasonID's
+func previousSeason(seasons []models.Season, seasonID uint) *models.Season {
+	var current *models.Season
+	for i := range seasons {
+		if seasons[i].ID == seasonID {
+			current = &seasons[i]
+		}
+	}
+	if current == nil {
+		return nil
+	}
+	var previous *models.Season
+	for i := range seasons {
+		s := &seasons[i]
+		if s.StartDate.Before(current.StartDate) && (previous == nil || s.StartDate.After(previous.StartDate)) {
+			previous = s
+		}
+	}
+	return previous
+}
+
+// wrappedMetric describes a stat for a comparison sentence, e.g. "We played
+// 14 more rubbers than in 2025"
+type wrappedMetric struct {
+	label    string
+	verb     string
+	one      string
+	many     string
+	current  float64
+	previous float64
+}
+
+// compareWrapped sets one season's editions against the previous season's
+func compareWrapped(current, previous *ClubWrappedData) *WrappedComparison {
+	comparison := &WrappedComparison{
+		PreviousSeasonID:   previous.SeasonID,
+		PreviousSeasonYear: previous.SeasonYear,
+	}
+	year := previous.SeasonYear
+
+	cur, prev := current.OverallStats, previous.OverallStats
+	for _, m := range []wrappedMetric{
+		{"Rubbers", "played", "rubber", "rubbers", float64(cur.TotalMatchups), float64(prev.TotalMatchups)},
+		{"Fixtures", "played", "fixture", "fixtures", float64(cur.TotalFixtures), float64(prev.TotalFixtures)},
+		{"Fixtures won", "won", "fixture", "fixtures", float64(fixturesWon(current.FixtureBreakdown)), float64(fixturesWon(previous.FixtureBreakdown))},
+		{"Players", "fielded", "player", "players", float64(cur.PlayersUsed), float64(prev.PlayersUsed)},
+		{"Hours on court", "spent", "hour on court", "hours on court", cur.HoursOnCourt, prev.HoursOnCourt},
+	} {
+		comparison.Club = append(comparison.Club, countDelta("We", m, year))
+	}
+	comparison.Club = append(comparison.Club, percentDelta("Fixture win rate", "Our fixture win rate",
+		fixtureWinRate(current.FixtureBreakdown), fixtureWinRate(previous.FixtureBreakdown), year))
+
+	me, before := current.Personal, previous.Personal
+	if me == nil || before == nil || before.MatchesPlayed == 0 {
+		return comparison
+	}
+	for _, m := range []wrappedMetric{
+		{"Rubbers", "played", "rubber", "rubbers", float64(me.MatchesPlayed), float64(before.MatchesPlayed)},
+		{"Fixtures", "played in", "fixture", "fixtures", float64(me.FixturesPlayed), float64(before.FixturesPlayed)},
+		{"Partners", "played with", "partner", "partners", float64(me.UniquePartners), float64(before.UniquePartners)},
+		{"Three-setters", "played", "three-set rubber", "three-set rubbers", float64(me.ThreeSetMatches), float64(before.ThreeSetMatches)},
+		{"Bagels", "delivered", "bagel", "bagels", float64(me.BagelsDelivered), float64(before.BagelsDelivered)},
+	} {
+		comparison.Personal = append(comparison.Personal, countDelta("You", m, year))
+	}
+	comparison.Personal = append(comparison.Personal, percentDelta("Win rate", "Your win rate", me.WinPercentage, before.WinPercentage, year))
+	return comparison
+}
+
+// countDelta compares a count, e.g. "You played 14 more rubbers than in 2025"
+func countDelta(subject string, m wrappedMetric, year int) WrappedDelta {
+	delta := WrappedDelta{Label: m.label, Current: m.current, Previous: m.previous, Change: m.current - m.previous}
+	amount := math.Abs(delta.Change)
+	noun := m.many
+	if amount == 1 {
+		noun = m.one
+	}
+	switch {
+	case delta.Change > 0:
+		delta.Sentence = fmt.Sprintf("%s %s %s more %s than in %d", subject, m.verb, formatAmount(amount), noun, year)
+	case delta.Change < 0:
+		delta.Sentence = fmt.Sprintf("%s %s %s fewer %s than in %d", subject, m.verb, formatAmount(amount), noun, year)
+	default:
+		delta.Sentence = fmt.Sprintf("%s %s the same number of %s as in %d", subject, m.verb, m.many, year)
+	}
+	return delta
+}
+
+// percentDelta compares a percentage in points, e.g. "Your win rate was up
+// 5.2 points on 2025"
+func percentDelta(label, subject string, current, previous float64, year int) WrappedDelta {
+	delta := WrappedDelta{Label: label, Current: current, Previous: previous, Change: current - previous, Percent: true}
+	switch points := math.Round(delta.Change*10) / 10; {
+	case points > 0:
+		delta.Sentence = fmt.Sprintf("%s was up %.1f points on %d", subject, points, year)
+	case points < 0:
+		delta.Sentence = fmt.Sprintf("%s was down %.1f points on %d", subject, -points, year)
+	default:
+		delta.Sentence = fmt.Sprintf("%s was the same as in %d", subject, year)
+	}
+	return delta
+}
+
+// formatAmount prints an amount with only the decimals it needs, e.g. 10.5
+// hours on court
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// fixturesWon counts the fixtures we won from a breakdown
+func fixturesWon(b ClubFixtureBreakdown) int {
+	return b.Perfect_8_0 + b.NearPerfect_7_1 + b.Strong_6_2 + b.Close_5_3
+}
+
+// fixtureWinRate scores wins as 1 and draws as half
+func fixtureWinRate(b ClubFixtureBreakdown) float64 {
+	if b.TotalFixtures == 0 {
+		return 0
+	}
+	return (float64(fixturesWon(b)) + float64(b.Draw_4_4)/2) * 100 / float64(b.TotalFixtures)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/sharecard"
+)
+
+// wrappedCardLifetime is how long a shared Wrapped link keeps unfurling.
+// Platforms fetch the preview when the link is posted, so a year covers
+// the posts that matter.
+const wrappedCardLifetime = 365 * 24 * time.Hour
+
+// wrappedShare signs a card link for the season's edition, the player's own
+// when playerID is set, and returns the page's link preview. The shared page
+// link carries the same token, so it unfurls without the access cookie.
+func (h *ClubWrappedHandler) wrappedShare(data *ClubWrappedData, playerID string) sharecard.Meta {
+	token, err := h.service.linkSigner.Sign(auth.LinkClaims{
+		Purpose:   auth.LinkPurposeWrappedCard,
+		Subject:   playerID,
+		Resource:  strconv.FormatUint(uint64(data.SeasonID), 10),
+		ExpiresAt: time.Now().Add(wrappedCardLifetime).Unix(),
+	})
+	if err != nil {
+		log.Printf("Failed to sign Wrapped card link: %v", err)
+		return sharecard.Meta{}
+	}
+
+	meta := wrappedMeta(data, playerID)
+	meta.URL = sharecard.AbsoluteURL(fmt.Sprintf("/club/wrapped?season=%d&card=%s", data.SeasonID, url.QueryEscape(token)))
+	meta.Image = sharecard.AbsoluteURL("/club/wrapped/card/" + token + ".png")
+	return meta
+}
+
+// wrappedMeta titles and describes an edition for its link preview
+func wrappedMeta(data *ClubWrappedData, playerID string) sharecard.Meta {
+	if p := data.Personal; playerID != "" && p != nil {
+		return sharecard.Meta{
+			Title:       fmt.Sprintf("%s Season %d Wrapped", possessive(p.PlayerName), data.SeasonYear),
+			Description: fmt.Sprintf("%d rubbers with %d partners and a %.0f%% win rate for %s", p.MatchesPlayed, p.UniquePartners, p.WinPercentage, data.ClubName),
+		}
+	}
+	stats := data.OverallStats
+	return sharecard.Meta{
+		Title:       fmt.Sprintf("%s Season %d Wrapped", data.ClubName, data.SeasonYear),
+		Description: fmt.Sprintf("%d rubbers across %d fixtures and a %.0f%% win rate", stats.TotalMatchups, stats.TotalFixtures, stats.ClubWinPercentage),
+	}
+}
+
+// verifyWrappedCard resolves a card token to its season and player
+func (h *ClubWrappedHandler) verifyWrappedCard(r *http.Request, token string) (*models.Season, string, error) {
+	claims, err := h.service.linkSigner.Verify(token, auth.LinkPurposeWrappedCard)
+	if err != nil {
+		return nil, "", err
+	}
+	seasonID, err := strconv.ParseUint(claims.Resource, 10, 32)
+	if err != nil {
+		return nil, "", auth.ErrLinkInvalid
+	}
+	season, err := h.service.seasonRepository.FindByID(r.Context(), uint(seasonID))
+	if err != nil {
+		return nil, "", err
+	}
+	return season, claims.Subject, nil
+}
+
+// HandleWrappedCard handles GET /club/wrapped/card/{token}.png (or .svg)
+func (h *ClubWrappedHandler) HandleWrappedCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/club/wrapped/card/")
+	format, ok := sharecard.ParseFormat(path.Ext(name))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	season, playerID, err := h.verifyWrappedCard(r, strings.TrimSuffix(name, path.Ext(name)))
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, auth.ErrLinkExpired) {
+			status = http.StatusGone
+		}
+		http.Error(w, "Card not found", status)
+		return
+	}
+
+	data, err := h.loadClubWrapped(r.Context(), season)
+	if err != nil {
+		logAndError(w, "Failed to generate wrapped data", err, http.StatusInternalServerError)
+		return
+	}
+	if playerID == "" {
+		sharecard.Serve(w, clubWrappedCard(data), format)
+		return
+	}
+	personal := h.loadPersonalWrapped(r.Context(), season, playerID)
+	if personal == nil || personal.MatchesPlayed == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	sharecard.Serve(w, personalWrappedCard(data, personal), format)
+}
+
+// renderWrappedPreview answers a shared Wrapped link opened without the
+// access cookie: a teaser carrying the card, so the link unfurls, and
+// nothing else of the edition
+func (h *ClubWrappedHandler) renderWrappedPreview(w http.ResponseWriter, r *http.Request, token string) bool {
+	season, playerID, err := h.verifyWrappedCard(r, token)
+	if err != nil {
+		return false
+	}
+	data, err := h.loadClubWrapped(r.Context(), season)
+	if err != nil {
+		log.Printf("Failed to load Wrapped for preview: %v", err)
+		return false
+	}
+	if playerID != "" {
+		data.Personal = h.loadPersonalWrapped(r.Context(), season, playerID)
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/wrapped_preview.html")
+	if err != nil {
+		log.Printf("Error parsing wrapped preview template: %v", err)
+		return false
+	}
+	share := wrappedMeta(data, playerID)
+	share.URL = sharecard.AbsoluteURL(r.URL.RequestURI())
+	share.Image = sharecard.AbsoluteURL("/club/wrapped/card/" + token + ".png")
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"WrappedData": data,
+		"Share":       share,
+	}); err != nil {
+		logAndError(w, err.Error(), err, http.StatusInternalServerError)
+	}
+	return true
+}
+
+// clubWrappedCard sums up the club's season
+func clubWrappedCard(data *ClubWrappedData) sharecard.Card {
+	stats := data.OverallStats
+	card := sharecard.Card{
+		Eyebrow: fmt.Sprintf("Season %d Wrapped", data.SeasonYear),
+		Title:   data.ClubName,
+		Stats: []sharecard.Stat{
+			{Value: strconv.Itoa(stats.TotalMatchups), Label: "rubbers"},
+			{Value: strconv.Itoa(stats.TotalFixtures), Label: "fixtures"},
+			{Value: fmt.Sprintf("%.0f%%", stats.ClubWinPercentage), Label: "win rate"},
+			{Value: strconv.Itoa(stats.PlayersUsed), Label: "players"},
+		},
+		Footer: data.ClubName + " Season Wrapped",
+	}
+	if stats.HoursOnCourt > 0 {
+		card.Rows = append(card.Rows, sharecard.Row{Left: "On court", Middle: fmt.Sprintf("%.0f hours", stats.HoursOnCourt)})
+	}
+	if len(data.TopPairings) > 0 {
+		p := data.TopPairings[0]
+		card.Rows = append(card.Rows, sharecard.Row{
+			Left:   "Top pairing",
+			Middle: sharecard.Pair(p.Player1Name, p.Player2Name),
+			Right:  fmt.Sprintf("%d together", p.MatchesTogether),
+		})
+	}
+	if len(data.BestAwayVenues) > 0 {
+		v := data.BestAwayVenues[0]
+		card.Rows = append(card.Rows, sharecard.Row{Left: "Best away", Middle: v.VenueName, Right: fmt.Sprintf("%.0f%%", v.WinPercentage)})
+	}
+	return card
+}
+
+// personalWrappedCard sums up one player's season
+func personalWrappedCard(data *ClubWrappedData, p *PersonalWrappedData) sharecard.Card {
+	card := sharecard.Card{
+		Eyebrow:  fmt.Sprintf("Season %d Wrapped", data.SeasonYear),
+		Title:    possessive(p.PlayerName) + " season",
+		Subtitle: data.ClubName,
+		Stats: []sharecard.Stat{
+			{Value: strconv.Itoa(p.MatchesPlayed), Label: "rubbers"},
+			{Value: fmt.Sprintf("%.0f%%", p.WinPercentage), Label: "win rate"},
+			{Value: strconv.Itoa(p.UniquePartners), Label: "partners"},
+			{Value: strconv.Itoa(p.ThreeSetMatches), Label: "three-setters"},
+		},
+		Footer: data.ClubName + " Season Wrapped",
+	}
+	if p.BestPartnerName != "" {
+		card.Rows = append(card.Rows, sharecard.Row{
+			Left:   "Best partner",
+			Middle: sharecard.Initials(p.BestPartnerName),
+			Right:  fmt.Sprintf("%.0f%%", p.BestPartnerWinPercentage),
+		})
+	}
+	if p.LongestWinStreak > 1 {
+		card.Rows = append(card.Rows, sharecard.Row{Left: "Best run", Middle: fmt.Sprintf("%d wins in a row", p.LongestWinStreak)})
+	}
+	if p.BagelsDelivered > 0 {
+		card.Rows = append(card.Rows, sharecard.Row{Left: "Bagels", Middle: fmt.Sprintf("%d sets won 6-0", p.BagelsDelivered)})
+	}
+	return card
+}
+
+// possessive names whose season it is by first name only ("Ann's"), since
+// a shared card mustn't carry a player's full name
+func possessive(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0] + "'s"
+	}
+	return "My"
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestWrappedShareCardsUnfurlWithoutTheAccessCookie(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "wrapped_cards.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026', 2026, ?, ?, 1)`, start, start.AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`, start, start.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('ann', 'Ann', 'Able', 1), ('bob', 'Bob', 'Baker', 1), ('h1', 'Hal', 'Hove', 2), ('h2', 'Hetty', 'Hove', 2)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (1, 1, 2, 1, 1, 1, ?, '', 'Completed', '')`, start.AddDate(0, 0, 3))
+	for id, matchupType := range []string{"Mens", "1st Mixed"} {
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, 1, ?, 'Finished', 2, 0, '')`, id+1, matchupType)
+		exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, 'ann', 1), (?, 'bob', 1), (?, 'h1', 0), (?, 'h2', 0)`,
+			id+1, id+1, id+1, id+1)
+	}
+
+	t.Setenv("APP_BASE_URL", "http://tennis.example")
+	h := NewClubWrappedHandler(NewService(db, "", 1, ""), filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"))
+	cardLink := regexp.MustCompile(`<meta property="og:image" content="http://tennis\.example/club/wrapped/card/([^"]+)\.png">`)
+	shareLink := regexp.MustCompile(`data-share-url="http://tennis\.example(/club/wrapped\?[^"]+)"`)
+	get := func(handler http.HandlerFunc, path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		req.Host = "attacker.example"
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "admin"}))
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	// The admin edition offers the club card
+	body := get(h.HandleWrapped, "/admin/league/wrapped").Body.String()
+	m := cardLink.FindStringSubmatch(body)
+	if m == nil || !strings.Contains(body, `data-testid="wrapped-share"`) {
+		t.Fatalf("admin wrapped has no club card to share")
+	}
+	club := get(h.HandleWrappedCard, "/club/wrapped/card/"+m[1]+".svg")
+	if club.Code != http.StatusOK || !strings.Contains(club.Body.String(), "SEASON 2026 WRAPPED") || !strings.Contains(club.Body.String(), ">rubbers<") {
+		t.Errorf("club card = %d %s", club.Code, club.Body.String())
+	}
+
+	// A player's edition shares their own card, by first name only
+	access := []*http.Cookie{{Name: "wrapped_access", Value: "granted"}, {Name: "wrapped_player_id", Value: "ann"}}
+	body = get(h.HandlePublicWrapped, "/club/wrapped", access...).Body.String()
+	m = cardLink.FindStringSubmatch(body)
+	share := shareLink.FindStringSubmatch(body)
+	if m == nil || share == nil {
+		t.Fatalf("personal wrapped has no card to share")
+	}
+	personal := get(h.HandleWrappedCard, "/club/wrapped/card/"+m[1]+".svg").Body.String()
+	if !strings.Contains(personal, "Ann&#39;s season") || !strings.Contains(personal, ">B.B.<") || strings.Contains(personal, "Able") {
+		t.Errorf("personal card = %s", personal)
+	}
+	if png := get(h.HandleWrappedCard, "/club/wrapped/card/"+m[1]+".png"); png.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("png card content type = %s", png.Header().Get("Content-Type"))
+	}
+
+	// The shared link unfurls for someone without the cookie, and shows
+	// them only the card
+	preview := get(h.HandlePublicWrapped, html.UnescapeString(share[1]))
+	if preview.Code != http.StatusOK || !strings.Contains(preview.Body.String(), `data-testid="wrapped-preview"`) ||
+		!strings.Contains(preview.Body.String(), "/club/wrapped/card/"+m[1]+".png") {
+		t.Errorf("shared link without the cookie = %d %s", preview.Code, preview.Body.String())
+	}
+	if strings.Contains(preview.Body.String(), "Individual Matchups") {
+		t.Errorf("preview shows the full edition")
+	}
+
+	// Forged or missing tokens get nothing
+	if rec := get(h.HandlePublicWrapped, "/club/wrapped?card=forged.token"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("forged share link = %d; want 401", rec.Code)
+	}
+	if rec := get(h.HandlePublicWrapped, "/club/wrapped"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrapped without the cookie = %d; want 401", rec.Code)
+	}
+	if rec := get(h.HandleWrappedCard, "/club/wrapped/card/forged.token.png"); rec.Code != http.StatusNotFound {
+		t.Errorf("forged card = %d; want 404", rec.Code)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestClubWrappedIsPerSeasonFrozenAndCompared(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "wrapped.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	// 2025 is over; 2026 is being played
+	lastSeason := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	thisSeason := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES
+		(1, '2025', 2025, ?, ?, 0), (2, '2026', 2026, ?, ?, 1)`,
+		lastSeason, lastSeason.AddDate(0, 6, 0), thisSeason, thisSeason.AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES
+		(1, 1, 1, ?, ?, 'Week 1'), (2, 1, 2, ?, ?, 'Week 1')`,
+		lastSeason, lastSeason.AddDate(0, 0, 6), thisSeason, thisSeason.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES
+		(1, 'Division 1', 1, 'Thursday', 1, 1), (2, 'Division 1', 1, 'Thursday', 1, 2)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES
+		(1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'St Ann''s A', 1, 2, 2), (4, 'Hove A', 2, 2, 2)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('ann', 'Ann', 'Able', 1), ('bob', 'Bob', 'Baker', 1), ('h1', 'Hal', 'Hove', 2), ('h2', 'Hetty', 'Hove', 2)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes) VALUES
+		(1, 1, 2, 1, 1, 1, ?, '', 'Completed', ''),
+		(2, 3, 4, 2, 2, 2, ?, '', 'Completed', '')`,
+		lastSeason.AddDate(0, 0, 3), thisSeason.AddDate(0, 0, 3))
+
+	rubber := func(id, fixtureID int, matchupType string) {
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, ?, ?, 'Finished', 2, 0, '')`,
+			id, fixtureID, matchupType)
+		for _, p := range []string{"ann", "bob"} {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 1)`, id, p)
+		}
+		for _, p := range []string{"h1", "h2"} {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 0)`, id, p)
+		}
+	}
+	// One rubber in 2025, three in 2026
+	rubber(1, 1, "Mens")
+	rubber(2, 2, "Mens")
+	rubber(3, 2, "1st Mixed")
+	rubber(4, 2, "2nd Mixed")
+
+	h := NewClubWrappedHandler(NewService(db, "", 1, ""), filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"))
+	seasons, err := h.service.seasonRepository.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("seasons: %v", err)
+	}
+	var last, this *models.Season
+	for i := range seasons {
+		if seasons[i].ID == 1 {
+			last = &seasons[i]
+		} else {
+			this = &seasons[i]
+		}
+	}
+
+	// Each season only counts its own rubbers
+	current, err := h.loadClubWrapped(ctx, this)
+	if err != nil {
+		t.Fatalf("2026 wrapped: %v", err)
+	}
+	if current.SeasonYear != 2026 || current.OverallStats.TotalMatchups != 3 || current.FrozenAt != nil {
+		t.Errorf("2026 wrapped = year %d, %d rubbers, frozen %v; want 2026, 3, not frozen",
+			current.SeasonYear, current.OverallStats.TotalMatchups, current.FrozenAt)
+	}
+	previous, err := h.loadClubWrapped(ctx, last)
+	if err != nil {
+		t.Fatalf("2025 wrapped: %v", err)
+	}
+	if previous.OverallStats.TotalMatchups != 1 || previous.FrozenAt == nil {
+		t.Errorf("2025 wrapped = %d rubbers, frozen %v; want 1 and frozen", previous.OverallStats.TotalMatchups, previous.FrozenAt)
+	}
+
+	// A late correction to 2025 doesn't change its frozen edition
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (5, 1, 'Womens', 'Finished', 0, 2, '')`)
+	if again, _ := h.loadClubWrapped(ctx, last); again.OverallStats.TotalMatchups != 1 {
+		t.Errorf("frozen 2025 wrapped now has %d rubbers; want it to stay at 1", again.OverallStats.TotalMatchups)
+	}
+
+	// Year on year: 3 rubbers against 1
+	comparison := compareWrapped(current, previous)
+	if comparison.Club[0].Sentence != "We played 2 more rubbers than in 2025" {
+		t.Errorf("club comparison = %q", comparison.Club[0].Sentence)
+	}
+
+	// A player's edition compares with their own last season
+	req := httptest.NewRequest("GET", "/club/wrapped?season=2", nil)
+	req.AddCookie(&http.Cookie{Name: "wrapped_access", Value: "granted"})
+	req.AddCookie(&http.Cookie{Name: "wrapped_player_id", Value: "ann"})
+	rec := httptest.NewRecorder()
+	h.HandlePublicWrapped(rec, req)
+	body := rec.Body.String()
+	for _, want := range []string{"Season 2026 Wrapped", `data-testid="wrapped-archive"`, `href="/club/wrapped?season=1"`,
+		"You played 2 more rubbers than in 2025", "Your win rate was the same as in 2025"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("public wrapped is missing %s", want)
+		}
+	}
+
+	// An admin can rebuild a frozen season to pick up the correction
+	form := url.Values{"action": {"refreeze"}, "season": {"1"}}
+	req = httptest.NewRequest("POST", "/admin/league/wrapped", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "admin"}))
+	rec = httptest.NewRecorder()
+	h.HandleWrapped(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("refreeze status = %d; want a redirect", rec.Code)
+	}
+	if rebuilt, _ := h.loadClubWrapped(ctx, last); rebuilt.OverallStats.TotalMatchups != 2 {
+		t.Errorf("rebuilt 2025 wrapped has %d rubbers; want the corrected 2", rebuilt.OverallStats.TotalMatchups)
+	}
+
+	// A finished season only freezes editions for real players who played
+	// in it, since the player ID comes from a cookie anyone can set
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('cal', 'Cal', 'Cole', 1)`)
+	for _, id := range []string{"no-such-player", "cal"} {
+		if personal := h.loadPersonalWrapped(ctx, last, id); personal != nil {
+			t.Errorf("2025 edition for %s = %+v; want none", id, personal)
+		}
+	}
+	if personal := h.loadPersonalWrapped(ctx, last, "ann"); personal == nil || personal.MatchesPlayed != 1 {
+		t.Errorf("Ann's 2025 edition = %+v; want one rubber", personal)
+	}
+	var frozen []string
+	if err := db.SelectContext(ctx, &frozen, `SELECT player_id FROM wrapped_snapshots WHERE season_id = 1 AND player_id != ''`); err != nil {
+		t.Fatalf("snapshots: %v", err)
+	}
+	if len(frozen) != 1 || frozen[0] != "ann" {
+		t.Errorf("frozen player editions = %v; want only ann", frozen)
+	}
+}
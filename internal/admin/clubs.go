@@ -181,6 +181,12 @@ func (h *ClubsHandler) handleClubsList(w http.ResponseWriter, r *http.Request) {
 func (h *ClubsHandler) handleClubDetail(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Admin club detail handler called with path: %s, method: %s", r.URL.Path, r.Method)
 
+	// Date-range venue overrides have their own forms on the club page
+	if strings.Contains(r.URL.Path, "/venue-overrides") {
+		h.handleVenueOverride(w, r)
+		return
+	}
+
 	// Check for delete action
 	if strings.HasSuffix(r.URL.Path, "/delete") {
 		h.handleClubDelete(w, r)
@@ -230,6 +236,10 @@ func (h *ClubsHandler) handleClubDetailGet(w http.ResponseWriter, r *http.Reques
 		successMsg = "Team created successfully."
 	case "created":
 		successMsg = "Club created successfully."
+	case "override_saved":
+		successMsg = "Venue override saved. Players in affected fixtures have been notified."
+	case "override_deleted":
+		successMsg = "Venue override removed. Players in affected fixtures have been notified."
 	}
 
 	// Get club dependencies for delete confirmation
@@ -245,6 +255,22 @@ func (h *ClubsHandler) handleClubDetailGet(w http.ResponseWriter, r *http.Reques
 		log.Printf("Failed to load club teams: %v", err)
 	}
 
+	// Record against every other club across all seasons
+	rivalries, err := h.service.GetClubRivalries(r.Context(), clubID)
+	if err != nil {
+		log.Printf("Failed to load club rivalries: %v", err)
+	}
+
+	// Date-range venue overrides, and the clubs they can move to
+	venueOverrides, err := h.service.GetClubVenueOverrides(r.Context(), clubID)
+	if err != nil {
+		log.Printf("Failed to load venue overrides: %v", err)
+	}
+	allClubs, err := h.service.GetAllClubs()
+	if err != nil {
+		log.Printf("Failed to load clubs for venue overrides: %v", err)
+	}
+
 	// Fetch active season and divisions for the "Add Team" form
 	activeSeason, _ := h.service.GetActiveSeason()
 	var divisions []models.Division
@@ -270,6 +296,10 @@ func (h *ClubsHandler) handleClubDetailGet(w http.ResponseWriter, r *http.Reques
 		"Success":              successMsg,
 		"Dependencies":         deps,
 		"ClubTeams":            clubTeams,
+		"Rivalries":            rivalries,
+		"VenueOverrides":       venueOverrides,
+		"AllClubs":             allClubs,
+		"Error":                r.URL.Query().Get("error"),
 		"ActiveSeason":         activeSeason,
 		"Divisions":            divisions,
 		"HomeClubName":         homeClubNameFromContext(r),
@@ -16,6 +16,7 @@ import (
 	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/config"
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/templates"
 )
 
 // getUserFromContext is a helper to get the user from request context
@@ -42,8 +43,17 @@ func homeClubLogoFromContext(r *http.Request) string {
 	return config.GetHomeClubLogoPath(r.Context())
 }
 
-// parseTemplate loads and parses a template file with helper functions and partials
+// adminTemplates caches the parsed admin pages; templates.LoadAll parses
+// them all at startup
+var adminTemplates = templates.NewSet("admin", buildTemplate, "admin/*.html", "admin_standalone.html")
+
+// parseTemplate returns a parsed admin template with helper functions and partials
 func parseTemplate(templateDir, templatePath string) (*template.Template, error) {
+	return adminTemplates.Get(templateDir, templatePath)
+}
+
+// buildTemplate loads and parses a template file with helper functions and partials
+func buildTemplate(templateDir, templatePath string) (*template.Template, error) {
 	fullPath := filepath.Join(templateDir, templatePath)
 
 	// Define template functions
@@ -0,0 +1,208 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/players"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// courthiveStub serves the calendar and public viewer endpoints the sync
+// calls, with one doubles cup: a semi-final each and a final still to play.
+func courthiveStub(t *testing.T) *httptest.Server {
+	t.Helper()
+	individual := func(name string) map[string]interface{} {
+		return map[string]interface{}{"participantType": "INDIVIDUAL", "participantName": name}
+	}
+	pair := func(name string, individuals ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"participantType": "PAIR", "participantName": name, "individualParticipants": individuals}
+	}
+	archerBaker := pair("Archer/Baker", individual("Alice Archer"), map[string]interface{}{
+		"participantType": "INDIVIDUAL", "person": map[string]string{"standardGivenName": "Bob", "standardFamilyName": "Baker"},
+	})
+	coleDrake := pair("Cole/Drake", individual("Cara Cole"), individual("Dan Drake"))
+	evansFord := pair("Evans/Ford", individual("Eve Evans"), individual("Finn Ford"))
+
+	eventData := map[string]interface{}{
+		"eventInfo": map[string]string{"eventId": "e1", "eventName": "Mixed Doubles", "eventType": "DOUBLES"},
+		"drawsData": []map[string]interface{}{{
+			"drawId": "d1", "drawName": "Main Draw", "drawType": "SINGLE_ELIMINATION",
+			"structures": []map[string]interface{}{{
+				"structureId": "s1",
+				"roundMatchUps": map[string]interface{}{
+					"2": []map[string]interface{}{{
+						"matchUpId": "m3", "roundNumber": 2, "roundPosition": 1, "roundName": "Final",
+						"matchUpStatus": "TO_BE_PLAYED",
+						"sides":         []map[string]interface{}{{"sideNumber": 1, "participant": archerBaker}, {"sideNumber": 2, "participant": evansFord}},
+					}},
+					"1": []map[string]interface{}{
+						{
+							"matchUpId": "m1", "roundNumber": 1, "roundPosition": 1, "roundName": "Semi-final",
+							"matchUpStatus": "COMPLETED", "winningSide": 1,
+							"sides":    []map[string]interface{}{{"sideNumber": 1, "participant": archerBaker}, {"sideNumber": 2, "participant": coleDrake}},
+							"score":    map[string]string{"scoreStringSide1": "6-3 6-4", "scoreStringSide2": "3-6 4-6"},
+							"schedule": map[string]string{"scheduledDate": "2026-06-13"},
+						},
+						{
+							"matchUpId": "m2", "roundNumber": 1, "roundPosition": 2, "roundName": "Semi-final",
+							"matchUpStatus": "BYE", "winningSide": 1,
+							"sides": []map[string]interface{}{{"sideNumber": 1, "participant": evansFord}, {"sideNumber": 2}},
+						},
+					},
+				},
+			}},
+		}},
+	}
+
+	// Only the visible tournaments' draws should be fetched, and CourtHive
+	// fails for broken-2026
+	checkTournament := func(w http.ResponseWriter, r *http.Request, body interface{}) {
+		var req struct {
+			TournamentID string `json:"tournamentId"`
+			EventID      string `json:"eventId"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err == nil && req.TournamentID == "broken-2026" {
+			http.Error(w, "upstream error", http.StatusBadGateway)
+			return
+		}
+		if err != nil || req.TournamentID != "cup-2026" {
+			t.Errorf("%s for tournament %q (%v); want only cup-2026", r.URL.Path, req.TournamentID, err)
+			http.Error(w, "unknown tournament", http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/factory/eventdata" && req.EventID != "e1" {
+			t.Errorf("eventdata for event %q; want e1", req.EventID)
+			http.Error(w, "unknown event", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/provider/calendar", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"calendar": map[string]interface{}{"tournaments": []map[string]interface{}{
+				{"tournamentId": "cup-2026", "tournament": map[string]string{"tournamentName": "Parks Cup", "startDate": "2026-06-06", "endDate": "2026-07-25"}},
+				{"tournamentId": "hidden-2026", "tournament": map[string]string{"tournamentName": "Club Champs", "startDate": "2026-08-01", "endDate": "2026-08-02"}},
+			}},
+		})
+	})
+	mux.HandleFunc("/factory/tournamentinfo", func(w http.ResponseWriter, r *http.Request) {
+		checkTournament(w, r, map[string]interface{}{
+			"success": true,
+			"tournamentInfo": map[string]interface{}{
+				"tournamentId": "cup-2026", "startDate": "2026-06-06",
+				"eventInfo": []map[string]string{{"eventId": "e1"}},
+			},
+		})
+	})
+	mux.HandleFunc("/factory/eventdata", func(w http.ResponseWriter, r *http.Request) {
+		checkTournament(w, r, map[string]interface{}{"success": true, "eventData": eventData})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestSyncFromCourtHiveStoresDrawsAndPlayerResults(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "courthive.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', '')`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('alice', 'Alice', 'Archer', 1), ('bob', 'Bob', 'Baker', 1), ('cara', 'Cara', 'Cole', 1)`)
+	exec(`INSERT INTO tournament_providers (id, name, provider_abbr) VALUES (1, 'Parks League Cup', 'PLC')`)
+	exec(`INSERT INTO tournaments (name, courthive_tournament_id, provider_id, is_visible) VALUES ('Broken Cup', 'broken-2026', 1, 1), ('Parks Cup', 'cup-2026', 1, 1)`)
+
+	server := courthiveStub(t)
+	defer server.Close()
+	svc := NewService(db, server.URL, 1, "")
+
+	// Syncing twice replaces the draws rather than duplicating them. The
+	// broken tournament is reported without stopping the others.
+	for i := 0; i < 2; i++ {
+		result, err := svc.SyncFromCourtHive(ctx, 1)
+		if err != nil {
+			t.Fatalf("sync %d: %v", i+1, err)
+		}
+		if result.New+result.Updated+result.Unchanged != 2 || result.Draws != 1 || result.Matches != 3 {
+			t.Fatalf("sync %d: %+v; want 2 tournaments, 1 draw, 3 matches", i+1, result)
+		}
+		if len(result.DrawErrors) != 1 || !strings.Contains(result.DrawErrors[0], "Broken Cup") {
+			t.Errorf("sync %d: draw errors = %v; want one for Broken Cup", i+1, result.DrawErrors)
+		}
+		if result.MatchedPlayers != 3 || len(result.UnmatchedParticipants) != 3 {
+			t.Errorf("sync %d: matched %d, unmatched %v; want 3 and [Dan Drake Eve Evans Finn Ford]",
+				i+1, result.MatchedPlayers, result.UnmatchedParticipants)
+		}
+	}
+
+	tournament, err := svc.tournamentRepository.FindByCourthiveTournamentID(ctx, "cup-2026")
+	if err != nil {
+		t.Fatal(err)
+	}
+	draws, err := svc.GetTournamentDraws(ctx, tournament.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(draws) != 1 || draws[0].EventName != "Mixed Doubles" || len(draws[0].Matches) != 3 {
+		t.Fatalf("draws = %+v; want the Mixed Doubles draw with 3 matches", draws)
+	}
+	semi := draws[0].Matches[0]
+	if semi.Side1Name != "Archer/Baker" || semi.Side2Name != "Cole/Drake" || semi.WinningSide == nil || *semi.WinningSide != 1 || len(semi.Players) != 4 {
+		t.Errorf("semi-final = %+v; want Archer/Baker beating Cole/Drake with 4 players", semi)
+	}
+	if final := draws[0].Matches[2]; final.RoundName != "Final" || final.WinningSide != nil {
+		t.Errorf("final = %+v; want an undecided Final", final)
+	}
+
+	// The decided semi-final appears in the players' match history; the
+	// bye and the unplayed final do not
+	playerService := players.NewService(db, 1)
+	for _, tc := range []struct {
+		playerID string
+		won      bool
+		partner  string
+		score    string
+	}{
+		{"alice", true, "B.B.", "6-3 6-4"},
+		{"bob", true, "A.A.", "6-3 6-4"},
+		{"cara", false, "D.D.", "3-6 4-6"},
+	} {
+		records, stats, err := playerService.GetPlayerMatchHistory(tc.playerID, nil)
+		if err != nil {
+			t.Fatalf("%s history: %v", tc.playerID, err)
+		}
+		if len(records) != 1 || stats.TotalMatches != 1 {
+			t.Fatalf("%s history = %+v; want the semi-final only", tc.playerID, records)
+		}
+		record := records[0]
+		if !record.IsCup || record.DivisionName != "Parks Cup" || record.RoundName != "Semi-final" ||
+			record.WonMatch != tc.won || record.PartnerName != tc.partner || record.SetScores != tc.score || record.FixtureDate.Format("2006-01-02") != "2026-06-13" {
+			t.Errorf("%s record = %+v", tc.playerID, record)
+		}
+	}
+}
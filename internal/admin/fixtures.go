@@ -4,6 +4,7 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -60,6 +61,11 @@ func (h *FixturesHandler) HandleFixtures(w http.ResponseWriter, r *http.Request)
 			h.handleCalendarDownload(w, r)
 			return
 		}
+		// Check if this is a match-night pack download request
+		if strings.HasSuffix(r.URL.Path, "/match-pack.pdf") {
+			h.handleMatchPack(w, r)
+			return
+		}
 		// Check if this is a notes update request
 		if strings.HasSuffix(r.URL.Path, "/notes") {
 			h.handleUpdateFixtureNotes(w, r)
@@ -94,6 +100,10 @@ func (h *FixturesHandler) HandleFixtures(w http.ResponseWriter, r *http.Request)
 			h.handleRemindAvailability(w, r)
 			return
 		}
+		if strings.HasSuffix(r.URL.Path, "/find-sub") {
+			h.handleFindSub(w, r)
+			return
+		}
 		h.handleFixtureDetail(w, r)
 		return
 	}
@@ -426,6 +436,16 @@ func (h *FixturesHandler) handleFixtureDetailGet(w http.ResponseWriter, r *http.
 		}
 	}
 
+	// Head-to-head record between the two sides across seasons
+	var headToHead *services.HeadToHead
+	if detail, ok := fixtureDetail.(*FixtureDetail); ok && detail.HomeTeam != nil && detail.AwayTeam != nil {
+		if h2h, h2hErr := h.service.GetTeamHeadToHead(r.Context(), detail.HomeTeam.ID, detail.AwayTeam.ID); h2hErr == nil {
+			headToHead = h2h
+		} else {
+			log.Printf("Failed to load head-to-head for fixture %d: %v", fixtureID, h2hErr)
+		}
+	}
+
 	// Load the fixture detail template
 	tmpl, err := parseTemplate(h.templateDir, "admin/fixture_detail.html")
 	if err != nil {
@@ -446,6 +466,7 @@ func (h *FixturesHandler) handleFixtureDetailGet(w http.ResponseWriter, r *http.
 		"IsAwayClub":        isAwayClub,
 		"IsDerby":           isDerby,
 		"ManagingTeam":      managingTeam,
+		"HeadToHead":        headToHead,
 		"HomeClubName":      homeClubNameFromContext(r),
 	}); err != nil {
 		logAndError(w, err.Error(), err, http.StatusInternalServerError)
@@ -469,6 +490,8 @@ func (h *FixturesHandler) handleFixtureDetailPost(w http.ResponseWriter, r *http
 		h.handleUpdateFixtureNotes(w, r)
 	case "set_day_captain":
 		h.handleSetDayCaptain(w, r, fixtureID)
+	case "acknowledge_alert":
+		h.handleAcknowledgeSelectionAlert(w, r, fixtureID)
 	default:
 		log.Printf("Unknown action: %s", action)
 		http.Error(w, "Unknown action", http.StatusBadRequest)
@@ -838,6 +861,22 @@ func (h *FixturesHandler) handleTeamSelectionGet(w http.ResponseWriter, r *http.
 		selectionPercentage = (selectedCount * 100) / 8
 	}
 
+	// Partnership chemistry across every season, so captains can see how
+	// each candidate pairing has done together. Not worth failing the page over.
+	partnerships, err := h.service.GetPartnerships(r.Context(), PartnershipFilter{})
+	if err != nil {
+		log.Printf("Failed to load partnerships for team selection: %v", err)
+	}
+	pairChemistry, err := json.Marshal(PartnershipChemistry(partnerships))
+	if err != nil {
+		log.Printf("Failed to encode partnerships for team selection: %v", err)
+		pairChemistry = []byte("{}")
+	}
+	var selectedIDs []string
+	for _, sp := range fixtureDetail.SelectedPlayers {
+		selectedIDs = append(selectedIDs, sp.PlayerID)
+	}
+
 	// Execute the template with data
 	templateData := map[string]interface{}{
 		"FixtureDetail":       fixtureDetail,
@@ -845,6 +884,8 @@ func (h *FixturesHandler) handleTeamSelectionGet(w http.ResponseWriter, r *http.
 		"AllHomeClubPlayers":  availableHomeClubPlayers,
 		"SelectionPercentage": selectionPercentage,
 		"HomeClubName":        homeClubNameFromContext(r),
+		"PairChemistry":       string(pairChemistry),
+		"CandidatePairings":   PartnershipsAmong(partnerships, selectedIDs),
 	}
 
 	// Include managing team information if present
@@ -871,6 +912,8 @@ func (h *FixturesHandler) handleTeamSelectionPost(w http.ResponseWriter, r *http
 		h.handleClearFixturePlayers(w, r, fixtureID)
 	case "set_day_captain":
 		h.handleSetDayCaptain(w, r, fixtureID)
+	case "acknowledge_alert":
+		h.handleAcknowledgeSelectionAlert(w, r, fixtureID)
 	default:
 		http.Error(w, "Unknown action", http.StatusBadRequest)
 	}
@@ -1250,6 +1293,28 @@ func (h *FixturesHandler) handleSetDayCaptain(w http.ResponseWriter, r *http.Req
 	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
 }
 
+// handleAcknowledgeSelectionAlert dismisses the 'availability changed' flag on
+// a selected player
+func (h *FixturesHandler) handleAcknowledgeSelectionAlert(w http.ResponseWriter, r *http.Request, fixtureID uint) {
+	playerID := r.FormValue("player_id")
+	if playerID == "" {
+		http.Error(w, "Player ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.AcknowledgeSelectionAlert(r.Context(), fixtureID, playerID); err != nil {
+		logAndError(w, "Failed to acknowledge alert", err, http.StatusInternalServerError)
+		return
+	}
+
+	managingTeamParam := r.FormValue("managing_team_id")
+	redirectURL := fmt.Sprintf("/admin/league/fixtures/%d/team-selection", fixtureID)
+	if managingTeamParam != "" {
+		redirectURL += fmt.Sprintf("?managingTeam=%s", managingTeamParam)
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
 // handleWeekOverview handles the week overview page for Instagram story screenshots
 func (h *FixturesHandler) handleWeekOverview(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Admin week overview handler called with path: %s, method: %s", r.URL.Path, r.Method)
@@ -1354,6 +1419,11 @@ func (h *FixturesHandler) handleFixtureEditGet(w http.ResponseWriter, r *http.Re
 
 	// Get navigation context from query parameters
 	navigationContext := getNavigationContextFromRequest(r)
+	clubs, changes := h.fixtureEditOptions(r.Context(), fixtureID)
+	venueClubID := uint(0)
+	if fixtureDetail.VenueClubID != nil {
+		venueClubID = *fixtureDetail.VenueClubID
+	}
 
 	// Execute the template with data
 	if err := renderTemplate(w, tmpl, map[string]interface{}{
@@ -1361,6 +1431,9 @@ func (h *FixturesHandler) handleFixtureEditGet(w http.ResponseWriter, r *http.Re
 		"FixtureDetail":     fixtureDetail,
 		"NavigationContext": navigationContext,
 		"HomeClubName":      homeClubNameFromContext(r),
+		"Clubs":             clubs,
+		"Changes":           changes,
+		"VenueClubID":       venueClubID,
 	}); err != nil {
 		logAndError(w, err.Error(), err, http.StatusInternalServerError)
 	}
@@ -1424,8 +1497,36 @@ func (h *FixturesHandler) handleFixtureEditPost(w http.ResponseWriter, r *http.R
 	// Get additional notes
 	notes := r.FormValue("notes")
 
-	// Update the fixture with new schedule
-	err = h.service.UpdateFixtureSchedule(fixtureID, newScheduledDate, rescheduleReason, notes)
+	edit := FixtureEdit{
+		ScheduledDate:    newScheduledDate,
+		RescheduleReason: rescheduleReason,
+		Notes:            notes,
+	}
+
+	// Status is limited to the states an admin sets by hand
+	switch statusStr := r.FormValue("status"); statusStr {
+	case "":
+	case string(models.Scheduled), string(models.Postponed), string(models.Cancelled):
+		status := models.FixtureStatus(statusStr)
+		edit.Status = &status
+	default:
+		h.renderEditWithError(w, r, user, fixtureDetail, "Invalid fixture status")
+		return
+	}
+
+	// Venue: "" leaves it alone, "0" reverts to the home club, otherwise a club ID
+	if venueStr := r.FormValue("venue_club_id"); venueStr != "" {
+		venueID, err := strconv.ParseUint(venueStr, 10, 32)
+		if err != nil {
+			h.renderEditWithError(w, r, user, fixtureDetail, "Invalid venue")
+			return
+		}
+		venueClubID := uint(venueID)
+		edit.VenueClubID = &venueClubID
+	}
+
+	// Save everything in one go so players get a single notification
+	err = h.service.ApplyFixtureEdit(r.Context(), fixtureID, edit)
 	if err != nil {
 		h.renderEditWithError(w, r, user, fixtureDetail, fmt.Sprintf("Failed to update fixture: %v", err))
 		return
@@ -1460,6 +1561,11 @@ func (h *FixturesHandler) renderEditWithError(w http.ResponseWriter, r *http.Req
 
 	// Get navigation context from query parameters
 	navigationContext := getNavigationContextFromRequest(r)
+	clubs, changes := h.fixtureEditOptions(r.Context(), fixtureDetail.ID)
+	venueClubID := uint(0)
+	if fixtureDetail.VenueClubID != nil {
+		venueClubID = *fixtureDetail.VenueClubID
+	}
 
 	// Execute the template with error
 	if err := renderTemplate(w, tmpl, map[string]interface{}{
@@ -1468,11 +1574,28 @@ func (h *FixturesHandler) renderEditWithError(w http.ResponseWriter, r *http.Req
 		"NavigationContext": navigationContext,
 		"Error":             errorMsg,
 		"HomeClubName":      homeClubNameFromContext(r),
+		"Clubs":             clubs,
+		"Changes":           changes,
+		"VenueClubID":       venueClubID,
 	}); err != nil {
 		logAndError(w, err.Error(), err, http.StatusInternalServerError)
 	}
 }
 
+// fixtureEditOptions loads the venue choices and change history shown on the
+// fixture edit page. Failures just leave the section empty.
+func (h *FixturesHandler) fixtureEditOptions(ctx context.Context, fixtureID uint) ([]models.Club, []models.FixtureChange) {
+	clubs, err := h.service.GetClubs()
+	if err != nil {
+		log.Printf("Failed to load clubs for fixture edit: %v", err)
+	}
+	changes, err := h.service.GetFixtureChanges(ctx, fixtureID)
+	if err != nil {
+		log.Printf("Failed to load change history for fixture %d: %v", fixtureID, err)
+	}
+	return clubs, changes
+}
+
 // getNavigationContextFromRequest extracts navigation context from query parameters
 func getNavigationContextFromRequest(r *http.Request) map[string]string {
 	return map[string]string{
@@ -1539,6 +1662,9 @@ func (h *FixturesHandler) handleCalendarDownload(w http.ResponseWriter, r *http.
 		weekNumber,
 		resolution.Club,
 	)
+	if seq, err := h.service.fixtureChangeRepository.CountByFixture(ctx, fixture.ID); err == nil {
+		event.Sequence = seq
+	}
 
 	icalContent := services.GenerateICalEvent(event)
 
@@ -1676,7 +1802,7 @@ func (h *FixturesHandler) handleNotifySelectedPlayers(w http.ResponseWriter, r *
 			"body":  body,
 			"data": map[string]string{
 				"type": "selection",
-				"url":  "/my-availability/" + token,
+				"url":  h.service.linkSigner.NotificationLink(sp.Player.ID, token, ""),
 			},
 		}
 
@@ -1776,13 +1902,12 @@ func (h *FixturesHandler) handleRemindAvailability(w http.ResponseWriter, r *htt
 			continue
 		}
 
-		payload := map[string]interface{}{
-			"title": "Availability Reminder",
-			"body":  fmt.Sprintf("Please update your availability for %s vs %s on %s", teamName, opponentName, fixtureDate),
-			"data": map[string]string{
-				"type": "availability_reminder",
-				"url":  "/my-availability/" + token,
-			},
+		body := fmt.Sprintf("Please update your availability for %s vs %s on %s", teamName, opponentName, fixtureDate)
+		payload, err := h.service.availabilityReminderPayload(player, token, &detail.Fixture, body)
+		if err != nil {
+			log.Printf("Error building availability reminder for player %s: %v", player.ID, err)
+			noSubscription++
+			continue
 		}
 
 		sent, _ := h.pushService.SendToPlayer(token, payload)
@@ -1803,3 +1928,58 @@ func (h *FixturesHandler) handleResults(w http.ResponseWriter, r *http.Request)
 	resultsHandler := NewResultsHandler(h.service, h.templateDir)
 	resultsHandler.HandleResults(w, r)
 }
+
+// handleFindSub broadcasts a 'can you fill in?' offer to eligible players from
+// the club's other teams when a team is short for a fixture
+func (h *FixturesHandler) handleFindSub(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.service.pushService == nil {
+		http.Error(w, "Push notifications not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	fixtureID, err := parseIDFromPath(r.URL.Path, "/admin/league/fixtures/")
+	if err != nil {
+		http.Error(w, "Invalid fixture ID", http.StatusBadRequest)
+		return
+	}
+	teamID, err := strconv.ParseUint(r.URL.Query().Get("team_id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	fixture, err := h.service.fixtureRepository.FindByID(ctx, fixtureID)
+	if err != nil {
+		http.Error(w, "Fixture not found", http.StatusNotFound)
+		return
+	}
+
+	var createdBy *int64
+	if user, err := getUserFromContext(r); err == nil {
+		createdBy = &user.ID
+	}
+
+	gender := h.service.shortGenderForTeam(ctx, fixture, uint(teamID))
+	result, err := h.service.BroadcastSubOffer(ctx, fixtureID, uint(teamID), gender, createdBy)
+	if err != nil {
+		log.Printf("Failed to broadcast sub offer for fixture %d team %d: %v", fixtureID, teamID, err)
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<span class="notif-result">Could not send sub offer.</span>`)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if result.Candidates == 0 {
+		fmt.Fprint(w, `<span class="notif-result">No eligible subs available.</span>`)
+		return
+	}
+	fmt.Fprintf(w, `<span class="notif-result">Sub offer sent to %d of %d eligible players (%d have no notifications).</span>`,
+		result.Notified, result.Candidates, result.NoSubscription)
+}
@@ -36,6 +36,10 @@ type Handler struct {
 	planning          *PlanningHandler
 	planningLink      *PlanningLinkHandler
 	captainNotes      *CaptainNotesHandler
+	scouting          *ScoutingHandler
+	partnerships      *PartnershipsHandler
+	pushReachability  *PushReachabilityHandler
+	rateLimits        *RateLimitsHandler
 }
 
 // New creates a new admin handler
@@ -69,6 +73,10 @@ func New(db *database.DB, templateDir string, courthiveAPIURL string, homeClubID
 		planning:          NewPlanningHandler(service, templateDir),
 		planningLink:      NewPlanningLinkHandler(service, templateDir),
 		captainNotes:      NewCaptainNotesHandler(service, templateDir),
+		scouting:          NewScoutingHandler(service, templateDir),
+		partnerships:      NewPartnershipsHandler(service, templateDir),
+		pushReachability:  NewPushReachabilityHandler(service, templateDir),
+		rateLimits:        NewRateLimitsHandler(nil, templateDir),
 	}
 }
 
@@ -138,6 +146,7 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middle
 	adminMux.HandleFunc("/admin/league/tournaments/edit/", h.tournaments.HandleTournamentEdit)
 	adminMux.HandleFunc("/admin/league/tournaments/toggle-visibility/", h.tournaments.HandleToggleVisibility)
 	adminMux.HandleFunc("/admin/league/tournaments/sync/", h.tournaments.HandleSync)
+	adminMux.HandleFunc("/admin/league/tournaments/entries/", h.tournaments.HandleEntries)
 
 	// Captain planning dashboard (Sprint 017)
 	adminMux.HandleFunc("/admin/league/planning", h.planning.HandleDashboard)
@@ -151,11 +160,24 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middle
 	adminMux.HandleFunc("/admin/league/captain-notes", h.captainNotes.HandleNotes)
 	adminMux.HandleFunc("/admin/league/captain-notes/", h.captainNotes.HandleNotes)
 
+	// Opposition scouting reports from imported match cards
+	adminMux.HandleFunc("/admin/league/scouting/", h.scouting.HandleScouting)
+
+	// Partnership chemistry matrix for captains picking pairs
+	adminMux.HandleFunc("/admin/league/partnerships", h.partnerships.HandlePartnerships)
+
 	// Selection overview routes
 	adminMux.HandleFunc("/admin/league/selection-overview", h.selectionOverview.HandleSelectionOverview)
 	adminMux.HandleFunc("/admin/league/selection-overview/", h.selectionOverview.HandleSelectionOverview)
 	adminMux.HandleFunc("/admin/league/selection-overview/refresh", h.selectionOverview.HandleSelectionOverview)
 
+	// Push notification reachability report
+	adminMux.HandleFunc("/admin/league/push-reachability", h.pushReachability.HandlePushReachability)
+
+	// Clients currently held back by the rate limiter
+	adminMux.HandleFunc("/admin/league/rate-limits", h.rateLimits.HandleRateLimits)
+	adminMux.HandleFunc("/admin/league/rate-limits/", h.rateLimits.HandleRateLimits)
+
 	// Preferred name approval routes
 	adminMux.HandleFunc("/admin/league/preferred-names", h.service.HandlePreferredNameApprovals)
 	adminMux.HandleFunc("/admin/league/preferred-names/", h.service.HandlePreferredNameApprovals)
@@ -180,4 +202,6 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middle
 func (h *Handler) RegisterPublicRoutes(mux *http.ServeMux) {
 	// Public Season Wrapped route protected by a lightweight access cookie
 	mux.HandleFunc("/club/wrapped", h.clubWrapped.HandlePublicWrapped)
+	// Signed share cards, readable by anyone the link is posted to
+	mux.HandleFunc("/club/wrapped/card/", h.clubWrapped.HandleWrappedCard)
 }
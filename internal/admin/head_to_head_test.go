@@ -0,0 +1,120 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/services"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestHeadToHeadFollowsTeamsAndClubsAcrossSeasons(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "h2h.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	// St Ann's A and Hove A meet in three seasons, moving from Division 2
+	// to Division 1 along the way. Each season has its own team rows.
+	start := func(year int) time.Time { return time.Date(year, 4, 1, 0, 0, 0, 0, time.UTC) }
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES
+		(1, '2024', 2024, ?, ?, 0), (2, '2025', 2025, ?, ?, 0), (3, '2026', 2026, ?, ?, 1)`,
+		start(2024), start(2024).AddDate(0, 6, 0), start(2025), start(2025).AddDate(0, 6, 0), start(2026), start(2026).AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES
+		(1, 1, 1, ?, ?, 'Week 1'), (2, 1, 2, ?, ?, 'Week 1'), (3, 1, 3, ?, ?, 'Week 1')`,
+		start(2024), start(2024).AddDate(0, 0, 6), start(2025), start(2025).AddDate(0, 0, 6), start(2026), start(2026).AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES
+		(1, 'Division 2', 2, 'Thursday', 1, 1), (2, 'Division 1', 1, 'Thursday', 1, 2), (3, 'Division 1', 1, 'Thursday', 1, 3)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES
+		(1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', ''), (3, 'Preston', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1),
+		(3, 'St Ann''s A', 1, 2, 2), (4, 'Hove A', 2, 2, 2),
+		(5, 'St Ann''s A', 1, 3, 3), (6, 'Hove A', 2, 3, 3), (7, 'St Ann''s B', 1, 3, 3), (8, 'Preston A', 3, 3, 3)`)
+
+	fixture := func(id, home, away, season int, date time.Time, status string, homeScore, awayScore int) {
+		exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+			VALUES (?, ?, ?, ?, ?, ?, ?, '', ?, '')`, id, home, away, season, season, season, date, status)
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, ?, 'Mens', 'Finished', ?, ?, '')`,
+			id, id, homeScore, awayScore)
+	}
+	fixture(1, 2, 1, 1, start(2024).AddDate(0, 1, 0), "Completed", 6, 2) // Hove A beat us at Hove
+	fixture(2, 3, 4, 2, start(2025).AddDate(0, 1, 0), "Completed", 5, 3) // we won at home
+	fixture(3, 6, 5, 3, start(2026).AddDate(0, 1, 0), "Completed", 0, 8) // we won 8-0 away
+	fixture(4, 7, 6, 3, start(2026).AddDate(0, 2, 0), "Completed", 4, 4) // St Ann's B drew with Hove A
+	fixture(5, 5, 8, 3, start(2026).AddDate(0, 2, 0), "Completed", 2, 6) // Preston beat us
+	fixture(6, 5, 6, 3, start(2026).AddDate(0, 3, 0), "Scheduled", 0, 0)
+	fixture(7, 6, 8, 3, start(2026).AddDate(0, 3, 0), "Completed", 4, 4) // not ours
+
+	svc := NewService(db, "", 1, "")
+	if _, err := svc.GetTeamHeadToHead(ctx, 1, 5); err == nil {
+		t.Error("head-to-head between two seasons of the same team succeeded; want an error")
+	}
+
+	h2h, err := svc.GetTeamHeadToHead(ctx, 5, 6)
+	if err != nil {
+		t.Fatalf("team head-to-head: %v", err)
+	}
+	if h2h.Played != 3 || h2h.WonA != 2 || h2h.WonB != 1 || h2h.Drawn != 0 || h2h.RubbersA != 15 || h2h.RubbersB != 9 {
+		t.Errorf("record = P%d W%d L%d D%d rubbers %d-%d; want P3 W2 L1 D0 rubbers 15-9",
+			h2h.Played, h2h.WonA, h2h.WonB, h2h.Drawn, h2h.RubbersA, h2h.RubbersB)
+	}
+	var meetings []string
+	for _, m := range h2h.Meetings {
+		meetings = append(meetings, m.SeasonName+" "+m.DivisionName+" "+m.Result)
+	}
+	if want := []string{"2026 Division 1 W", "2025 Division 1 W", "2024 Division 2 L"}; !reflect.DeepEqual(meetings, want) {
+		t.Errorf("meetings = %v; want %v", meetings, want)
+	}
+	if h2h.BiggestWinA == nil || h2h.BiggestWinA.FixtureID != 3 || h2h.BiggestWinB == nil || h2h.BiggestWinB.FixtureID != 1 {
+		t.Errorf("biggest wins = %+v, %+v; want the 8-0 and the 6-2", h2h.BiggestWinA, h2h.BiggestWinB)
+	}
+	if want := (services.HeadToHeadStreak{Holder: "St Ann's A", Result: "W", Length: 2}); h2h.CurrentStreak != want {
+		t.Errorf("current streak = %+v; want %+v", h2h.CurrentStreak, want)
+	}
+	if h2h.LongestStreakA != 2 || h2h.LongestStreakB != 1 {
+		t.Errorf("longest streaks = %d, %d; want 2, 1", h2h.LongestStreakA, h2h.LongestStreakB)
+	}
+
+	// Club records take in every team and ignore fixtures between others
+	rivalries, err := svc.GetClubRivalries(ctx, 1)
+	if err != nil {
+		t.Fatalf("club rivalries: %v", err)
+	}
+	type record struct {
+		opponent            string
+		played, won, drawn  int
+		lost, current, runs int
+	}
+	var got []record
+	for _, r := range rivalries {
+		got = append(got, record{r.SideB, r.Played, r.WonA, r.Drawn, r.WonB, r.CurrentStreak.Length, r.LongestStreakA})
+	}
+	want := []record{{"Hove", 4, 2, 1, 1, 1, 2}, {"Preston", 1, 0, 0, 1, 1, 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rivalries = %+v; want %+v", got, want)
+	}
+	if rivalries[0].CurrentStreak.Result != "D" || rivalries[0].CurrentStreak.Holder != "" {
+		t.Errorf("Hove current streak = %+v; want the latest draw", rivalries[0].CurrentStreak)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// matchPackRubbers is the fixture format the blank score grid follows when
+// no matchups have been set up yet, in match card order
+var matchPackRubbers = []models.MatchupType{models.FirstMixed, models.SecondMixed, models.Mens, models.Womens}
+
+// MatchPack is everything a day captain takes to a fixture on paper
+type MatchPack struct {
+	Fixture     *FixtureDetail
+	OurTeam     *models.Team
+	Opposition  *models.Team
+	IsAway      bool
+	Venue       *services.VenueResolution
+	Weather     *services.WeatherData
+	Rubbers     []MatchPackRubber
+	GeneratedAt time.Time
+
+	// Opposition contact: their captains this season and their club's details
+	OppositionCaptains []models.Player
+	OppositionClub     *models.Club
+}
+
+// MatchPackRubber is one row of the score grid, with whichever pairings are
+// already known
+type MatchPackRubber struct {
+	Type     models.MatchupType
+	HomePair string
+	AwayPair string
+}
+
+// GetMatchPack gathers the match-night pack for a fixture as seen by the
+// managing team. A zero managingTeamID picks the home club's side.
+func (s *Service) GetMatchPack(ctx context.Context, fixtureID, managingTeamID uint) (*MatchPack, error) {
+	if managingTeamID == 0 {
+		id, err := s.determineManagingTeamID(ctx, fixtureID)
+		if err != nil {
+			return nil, err
+		}
+		managingTeamID = id
+	}
+
+	detail, err := s.GetFixtureDetailWithTeamContext(fixtureID, managingTeamID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.HomeTeam == nil || detail.AwayTeam == nil {
+		return nil, fmt.Errorf("fixture %d is missing a team", fixtureID)
+	}
+
+	pack := &MatchPack{
+		Fixture:     detail,
+		OurTeam:     detail.HomeTeam,
+		Opposition:  detail.AwayTeam,
+		Rubbers:     matchPackRubbersFor(detail.Matchups),
+		GeneratedAt: time.Now(),
+	}
+	if detail.AwayTeam.ID == managingTeamID {
+		pack.OurTeam, pack.Opposition, pack.IsAway = detail.AwayTeam, detail.HomeTeam, true
+	}
+
+	venueResolver := services.NewVenueResolver(s.clubRepository, s.teamRepository, s.venueOverrideRepository)
+	if resolution, err := venueResolver.ResolveFixtureVenue(ctx, &detail.Fixture); err == nil {
+		pack.Venue = resolution
+		pack.Weather = s.matchPackWeather(resolution.Club, detail.ScheduledDate)
+	} else {
+		log.Printf("Failed to resolve venue for match pack %d: %v", fixtureID, err)
+	}
+
+	if captains, err := s.playerRepository.FindCaptainsByTeam(ctx, pack.Opposition.ID, detail.SeasonID); err == nil {
+		pack.OppositionCaptains = captains
+	}
+	if club, err := s.clubRepository.FindByID(ctx, pack.Opposition.ClubID); err == nil {
+		pack.OppositionClub = club
+	}
+
+	return pack, nil
+}
+
+// matchPackWeather fetches the forecast for the venue, which is only
+// available in the week before the match
+func (s *Service) matchPackWeather(club *models.Club, date time.Time) *services.WeatherData {
+	if s.weatherService == nil || club == nil || club.Latitude == nil || club.Longitude == nil {
+		return nil
+	}
+	if time.Until(date) > 7*24*time.Hour || date.Before(time.Now().Truncate(24*time.Hour)) {
+		return nil
+	}
+	weather, err := s.weatherService.GetForecastForDate(*club.Latitude, *club.Longitude, date)
+	if err != nil {
+		log.Printf("Failed to fetch weather for match pack: %v", err)
+		return nil
+	}
+	return weather
+}
+
+// matchPackRubbersFor lays out the score grid from the fixture's matchups,
+// falling back to the standard four rubbers for any not yet created
+func matchPackRubbersFor(matchups []MatchupWithPlayers) []MatchPackRubber {
+	byType := make(map[models.MatchupType]MatchupWithPlayers, len(matchups))
+	for _, m := range matchups {
+		byType[m.Matchup.Type] = m
+	}
+
+	var rubbers []MatchPackRubber
+	for _, matchupType := range matchPackRubbers {
+		rubber := MatchPackRubber{Type: matchupType}
+		if m, ok := byType[matchupType]; ok {
+			rubber.HomePair = matchPackPair(m.Players, true)
+			rubber.AwayPair = matchPackPair(m.Players, false)
+			delete(byType, matchupType)
+		}
+		rubbers = append(rubbers, rubber)
+	}
+	// Anything outside the usual format keeps its own row
+	for _, m := range matchups {
+		if _, ok := byType[m.Matchup.Type]; ok {
+			rubbers = append(rubbers, MatchPackRubber{
+				Type:     m.Matchup.Type,
+				HomePair: matchPackPair(m.Players, true),
+				AwayPair: matchPackPair(m.Players, false),
+			})
+		}
+	}
+	return rubbers
+}
+
+// matchPackPair names one side's pairing in a rubber
+func matchPackPair(players []MatchupPlayerWithInfo, home bool) string {
+	var names []string
+	for _, p := range players {
+		if p.MatchupPlayer.IsHome == home {
+			names = append(names, p.Player.FirstName+" "+p.Player.LastName)
+		}
+	}
+	return strings.Join(names, " & ")
+}
+
+// handleMatchPack handles GET /admin/league/fixtures/{id}/match-pack.pdf
+func (h *FixturesHandler) handleMatchPack(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/match-pack.pdf")
+	fixtureID, err := parseIDFromPath(path, "/admin/league/fixtures/")
+	if err != nil {
+		logAndError(w, "Invalid fixture ID", err, http.StatusBadRequest)
+		return
+	}
+
+	var managingTeamID uint
+	if param := r.URL.Query().Get("managingTeam"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			logAndError(w, "Invalid managing team", err, http.StatusBadRequest)
+			return
+		}
+		managingTeamID = uint(id)
+	}
+
+	pack, err := h.service.GetMatchPack(r.Context(), fixtureID, managingTeamID)
+	if err != nil {
+		logAndError(w, "Fixture not found", err, http.StatusNotFound)
+		return
+	}
+
+	pdf := renderMatchPack(pack)
+	if err := pdf.Error(); err != nil {
+		logAndError(w, "Failed to build match pack", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"match-pack-%d.pdf\"", fixtureID))
+	if err := pdf.Output(w); err != nil {
+		log.Printf("Failed to write match pack for fixture %d: %v", fixtureID, err)
+	}
+}
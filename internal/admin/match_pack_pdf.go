@@ -0,0 +1,339 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// Match pack page layout, in millimetres on A4 portrait
+const (
+	matchPackMargin = 15.0
+	matchPackWidth  = 210 - 2*matchPackMargin
+	matchPackLine   = 5.5
+)
+
+// matchPackPDF wraps the document with the pack's text helpers. Text goes
+// through the cp1252 translator so names, dashes and degrees print with the
+// core fonts.
+type matchPackPDF struct {
+	*fpdf.Fpdf
+	tr func(string) string
+}
+
+// renderMatchPack lays out a printable pack: the team sheet, venue,
+// weather and opposition on the first page, and a blank score card to fill
+// in on the night on the second. Check Error() before writing it out.
+func renderMatchPack(pack *MatchPack) *fpdf.Fpdf {
+	pdf := &matchPackPDF{Fpdf: fpdf.New("P", "mm", "A4", "")}
+	pdf.tr = pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(matchPackMargin, matchPackMargin, matchPackMargin)
+	pdf.SetAutoPageBreak(true, matchPackMargin+5)
+	pdf.SetTitle(pdf.tr(matchPackTitle(pack)), false)
+	pdf.SetCreator("Jim.Tennis", false)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-matchPackMargin)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(127, 140, 141)
+		pdf.CellFormat(0, 5, pdf.tr(fmt.Sprintf("%s · printed %s · page %d",
+			matchPackTitle(pack), pack.GeneratedAt.Format("2 Jan 2006 15:04"), pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.header(pack)
+	pdf.teamSheet(pack)
+	pdf.venue(pack)
+	pdf.weather(pack)
+	pdf.opposition(pack)
+
+	pdf.AddPage()
+	pdf.scoreCard(pack)
+
+	return pdf.Fpdf
+}
+
+// matchPackTitle names the fixture home side first, as the league does
+func matchPackTitle(pack *MatchPack) string {
+	return pack.Fixture.HomeTeam.Name + " v " + pack.Fixture.AwayTeam.Name
+}
+
+func (pdf *matchPackPDF) header(pack *MatchPack) {
+	detail := pack.Fixture
+
+	pdf.SetFillColor(44, 62, 80)
+	pdf.Rect(0, 0, 210, 38, "F")
+	pdf.SetTextColor(236, 240, 241)
+	pdf.SetY(10)
+	pdf.SetFont("Helvetica", "B", 9)
+	var eyebrow []string
+	eyebrow = append(eyebrow, "MATCH-NIGHT PACK")
+	if detail.Division != nil {
+		eyebrow = append(eyebrow, strings.ToUpper(detail.Division.Name))
+	}
+	if detail.Week != nil {
+		eyebrow = append(eyebrow, fmt.Sprintf("WEEK %d", detail.Week.WeekNumber))
+	}
+	pdf.CellFormat(0, 5, pdf.tr(strings.Join(eyebrow, " · ")), "", 1, "L", false, 0, "")
+
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 9, pdf.tr(matchPackTitle(pack)), "", 1, "L", false, 0, "")
+
+	where := "Home"
+	if pack.IsAway {
+		where = "Away"
+	}
+	if pack.Venue != nil && pack.Venue.Club != nil {
+		where += " at " + pack.Venue.Club.Name
+	}
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, pdf.tr(detail.ScheduledDate.Format("Monday 2 January 2006, 15:04")+" · "+where), "", 1, "L", false, 0, "")
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(44)
+}
+
+func (pdf *matchPackPDF) section(title string) {
+	pdf.Ln(3)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetTextColor(44, 62, 80)
+	pdf.CellFormat(0, 7, pdf.tr(title), "B", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(1.5)
+}
+
+// field prints a labelled line, wrapping long values, and skips it when
+// there is nothing to say
+func (pdf *matchPackPDF) field(label, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(32, matchPackLine, pdf.tr(label), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.MultiCell(0, matchPackLine, pdf.tr(value), "", "L", false)
+}
+
+// blank prints a labelled line to fill in by hand
+func (pdf *matchPackPDF) blank(label string) {
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(32, matchPackLine+2, pdf.tr(label), "", 0, "L", false, 0, "")
+	x, y := pdf.GetXY()
+	pdf.SetDrawColor(189, 195, 199)
+	pdf.Line(x, y+matchPackLine+1, x+90, y+matchPackLine+1)
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Ln(matchPackLine + 2)
+}
+
+func (pdf *matchPackPDF) teamSheet(pack *MatchPack) {
+	detail := pack.Fixture
+	pdf.section("Team sheet · " + pack.OurTeam.Name)
+
+	if detail.DayCaptain != nil {
+		pdf.field("Day captain", detail.DayCaptain.FirstName+" "+detail.DayCaptain.LastName)
+	}
+	if len(detail.SelectedPlayers) == 0 {
+		pdf.field("Selected", "No players selected yet")
+	}
+	for i, sp := range detail.SelectedPlayers {
+		label := ""
+		if i == 0 {
+			label = "Selected"
+		}
+		name := fmt.Sprintf("%d. %s %s", i+1, sp.Player.FirstName, sp.Player.LastName)
+		switch sp.AvailabilityStatus {
+		case models.IfNeeded:
+			name += " (if needed)"
+		case models.Unavailable:
+			name += " (unavailable)"
+		case models.Unknown, "":
+			name += " (not confirmed)"
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(32, matchPackLine, label, "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, matchPackLine, pdf.tr(name), "", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(2)
+	for _, rubber := range pack.Rubbers {
+		pair := rubber.HomePair
+		if pack.IsAway {
+			pair = rubber.AwayPair
+		}
+		if pair == "" {
+			pair = "To be paired"
+		}
+		pdf.field(string(rubber.Type), pair)
+	}
+}
+
+func (pdf *matchPackPDF) venue(pack *MatchPack) {
+	pdf.section("Venue")
+	if pack.Venue == nil || pack.Venue.Club == nil {
+		pdf.field("Venue", pack.Fixture.VenueLocation)
+		pdf.field("", "Venue details aren't on record for this fixture")
+		return
+	}
+
+	club := pack.Venue.Club
+	pdf.field("Club", club.Name)
+	pdf.field("Address", matchPackAddress(club))
+	if pack.Venue.IsOverridden {
+		pdf.field("Venue change", pack.Venue.OverrideReason)
+	}
+	pdf.field("Courts", matchPackCourts(club))
+	pdf.field("Parking", matchPackDeref(club.ParkingInfo))
+	pdf.field("Getting there", matchPackDeref(club.TransportInfo))
+	pdf.field("Tips", matchPackDeref(club.Tips))
+	if url := matchPackDeref(club.GoogleMapsURL); url != "" {
+		pdf.field("Map", url)
+	} else if club.Latitude != nil && club.Longitude != nil {
+		pdf.field("Map", fmt.Sprintf("https://www.google.com/maps?q=%f,%f", *club.Latitude, *club.Longitude))
+	}
+	pdf.field("Phone", club.PhoneNumber)
+}
+
+func (pdf *matchPackPDF) weather(pack *MatchPack) {
+	pdf.section("Weather")
+	w := pack.Weather
+	if w == nil {
+		pdf.field("Forecast", "Available in the week before the match. Check the fixture page before you set off.")
+		return
+	}
+	pdf.field("Forecast", fmt.Sprintf("%s, %.0f° to %.0f°C, %d%% chance of rain",
+		w.Description, w.TemperatureMin, w.TemperatureMax, w.PrecipitationPercent))
+}
+
+func (pdf *matchPackPDF) opposition(pack *MatchPack) {
+	pdf.section("Opposition · " + pack.Opposition.Name)
+
+	var captains []string
+	for _, c := range pack.OppositionCaptains {
+		captains = append(captains, c.FirstName+" "+c.LastName)
+	}
+	if len(captains) == 0 {
+		captains = append(captains, "Not on record")
+	}
+	pdf.field("Captain", strings.Join(captains, ", "))
+	if club := pack.OppositionClub; club != nil {
+		pdf.field("Club", club.Name)
+		pdf.field("Club phone", club.PhoneNumber)
+		pdf.field("Website", club.Website)
+	}
+	pdf.blank("Contact tonight")
+}
+
+// scoreCard draws the blank grid: one row per rubber with a box for each
+// set and the points, home side on the left as on the league's card
+func (pdf *matchPackPDF) scoreCard(pack *MatchPack) {
+	detail := pack.Fixture
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 9, "Score card", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, pdf.tr(matchPackTitle(pack)+" · "+detail.ScheduledDate.Format("2 January 2006")), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	widths := []float64{26, 46, 46, 16, 16, 16, 14}
+	headings := []string{"Rubber", detail.HomeTeam.Name, detail.AwayTeam.Name, "Set 1", "Set 2", "Set 3", "Points"}
+
+	pdf.SetFillColor(236, 240, 241)
+	pdf.SetFont("Helvetica", "B", 9)
+	for i, heading := range headings {
+		pdf.CellFormat(widths[i], 8, pdf.tr(matchPackFit(pdf, heading, widths[i])), "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	const rowHeight = 16.0
+	for _, rubber := range pack.Rubbers {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(widths[0], rowHeight, pdf.tr(string(rubber.Type)), "1", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(widths[1], rowHeight, pdf.tr(matchPackFit(pdf, rubber.HomePair, widths[1])), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], rowHeight, pdf.tr(matchPackFit(pdf, rubber.AwayPair, widths[2])), "1", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.SetTextColor(189, 195, 199)
+		for i := 3; i < len(widths); i++ {
+			pdf.CellFormat(widths[i], rowHeight, "-", "1", 0, "C", false, 0, "")
+		}
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(-1)
+	}
+
+	total := 0.0
+	for _, w := range widths[:len(widths)-1] {
+		total += w
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(total, 10, "Total", "1", 0, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.SetTextColor(189, 195, 199)
+	pdf.CellFormat(widths[len(widths)-1], 10, "-", "1", 1, "C", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.Ln(3)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(0, 5, pdf.tr(fmt.Sprintf("Each rubber is worth 2 points to the winners, or 1 each if shared: %d points in all. Write games as home-away.",
+		2*len(pack.Rubbers))), "", "L", false)
+
+	pdf.Ln(8)
+	pdf.blank(detail.HomeTeam.Name)
+	pdf.blank(detail.AwayTeam.Name)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetTextColor(127, 140, 141)
+	pdf.CellFormat(0, 5, "Captains' signatures", "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// matchPackAddress joins the club's address parts, falling back to the
+// free-text address for clubs without them
+func matchPackAddress(club *models.Club) string {
+	var parts []string
+	for _, p := range []*string{club.AddressLine1, club.AddressLine2, club.City, club.Postcode} {
+		if v := matchPackDeref(p); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	if len(parts) == 0 {
+		return club.Address
+	}
+	return strings.Join(parts, ", ")
+}
+
+// matchPackCourts describes the courts ("6 hard courts")
+func matchPackCourts(club *models.Club) string {
+	surface := strings.ToLower(matchPackDeref(club.CourtSurface))
+	if club.CourtCount == nil {
+		return surface
+	}
+	courts := "courts"
+	if *club.CourtCount == 1 {
+		courts = "court"
+	}
+	return strings.Join(strings.Fields(fmt.Sprintf("%d %s %s", *club.CourtCount, surface, courts)), " ")
+}
+
+func matchPackDeref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+// matchPackFit trims text to fit a grid cell in the current font
+func matchPackFit(pdf *matchPackPDF, text string, width float64) string {
+	const padding = 2.0
+	if pdf.GetStringWidth(pdf.tr(text)) <= width-padding {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(pdf.tr(string(runes)+"…")) > width-padding {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
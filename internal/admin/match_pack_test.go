@@ -0,0 +1,134 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestMatchPackCarriesSheetVenueOppositionAndScoreCard(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "match_pack.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	// Far enough ahead that no forecast is fetched
+	matchNight := time.Now().AddDate(0, 2, 0).Truncate(24 * time.Hour).Add(18*time.Hour + 30*time.Minute)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`, matchNight.AddDate(0, -3, 0), matchNight.AddDate(0, 3, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 7, 1, ?, ?, 'Week 7')`, matchNight, matchNight.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (3, 'Preston', '', '', '')`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number, address_line_1, city, postcode, court_surface, court_count, parking_info, transport_info, tips)
+		VALUES (2, 'Hove', '', 'https://hove.example', '01273 000000', 'Hove Park', 'Hove', 'BN3 7BF', 'Hard', 6, 'Free on Goldstone Crescent', 'Bus 7 to Hove Park', 'Courts 5 and 6 are by the cafe')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'Preston A', 3, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('ann', 'Ann', 'Able', 1), ('bob', 'Bob', 'Baker', 1), ('cat', 'Cat', 'Cole', 1), ('h1', 'Hal', 'Hove', 2)`)
+	exec(`INSERT INTO captains (player_id, team_id, role, season_id) VALUES ('h1', 2, 'Team', 1), ('ann', 1, 'Team', 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes, day_captain_id) VALUES
+		(1, 2, 1, 1, 1, 1, ?, 'Hove Park', 'Scheduled', '', 'cat'),
+		(2, 2, 3, 1, 1, 1, ?, '', 'Scheduled', '', NULL)`, matchNight, matchNight)
+
+	// We're away at Hove with Ann and Bob paired in the Mens and Cat a maybe
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes, managing_team_id) VALUES (1, 1, 'Mens', 'Pending', 0, 0, '', 1)`)
+	exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (1, 'ann', 0), (1, 'bob', 0)`)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id) VALUES (1, 'ann', 0, 1, 1), (1, 'bob', 0, 2, 1), (1, 'cat', 0, 3, 1)`)
+	exec(`INSERT INTO player_fixture_availability (player_id, fixture_id, status) VALUES ('cat', 1, 'IfNeeded')`)
+
+	service := NewService(db, "", 1, "")
+	pack, err := service.GetMatchPack(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("GetMatchPack: %v", err)
+	}
+	if !pack.IsAway || pack.OurTeam.ID != 1 || pack.Opposition.ID != 2 {
+		t.Errorf("pack sides = away %v, ours %d, theirs %d", pack.IsAway, pack.OurTeam.ID, pack.Opposition.ID)
+	}
+	if pack.Venue == nil || pack.Venue.Club == nil || pack.Venue.Club.Name != "Hove" {
+		t.Fatalf("pack venue = %+v", pack.Venue)
+	}
+	if pack.Weather != nil {
+		t.Errorf("forecast fetched two months out")
+	}
+	if len(pack.OppositionCaptains) != 1 || pack.OppositionCaptains[0].ID != "h1" {
+		t.Errorf("opposition captains = %+v", pack.OppositionCaptains)
+	}
+	var types []string
+	for _, rubber := range pack.Rubbers {
+		types = append(types, string(rubber.Type))
+		if rubber.Type == models.Mens && (rubber.AwayPair != "Ann Able & Bob Baker" || rubber.HomePair != "") {
+			t.Errorf("mens pairing = %q v %q", rubber.HomePair, rubber.AwayPair)
+		}
+	}
+	if got := strings.Join(types, ", "); got != "1st Mixed, 2nd Mixed, Mens, Womens" {
+		t.Errorf("score grid rubbers = %s", got)
+	}
+
+	pdf := renderMatchPack(pack)
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if pdf.PageCount() != 2 {
+		t.Errorf("pack has %d pages; want the sheet and the score card", pdf.PageCount())
+	}
+	for _, want := range []string{
+		"Hove A v St Ann's A", "Away at Hove", "Day captain", "Cat Cole \\(if needed\\)", "Ann Able & Bob Baker",
+		"Hove Park, Hove, BN3 7BF", "6 hard courts", "Free on Goldstone Crescent", "Bus 7 to Hove Park", "Courts 5 and 6 are by the cafe",
+		"Hal Hove", "01273 000000", "Contact tonight", "Score card", "Set 3", "8 points in all",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("pack is missing %q", want)
+		}
+	}
+
+	h := NewFixturesHandler(service, filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"), nil)
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "admin"}))
+		rec := httptest.NewRecorder()
+		h.HandleFixtures(rec, req)
+		return rec
+	}
+
+	rec := get("/admin/league/fixtures/1/match-pack.pdf")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" || !strings.HasPrefix(rec.Body.String(), "%PDF") {
+		t.Fatalf("match pack download = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if disposition := rec.Header().Get("Content-Disposition"); !strings.Contains(disposition, "match-pack-1.pdf") {
+		t.Errorf("content disposition = %s", disposition)
+	}
+	if rec := get("/admin/league/fixtures/1/match-pack.pdf?managingTeam=abc"); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad managing team = %d; want 400", rec.Code)
+	}
+	// We aren't playing in fixture 2, so there's no side to pack for
+	if rec := get("/admin/league/fixtures/2/match-pack.pdf"); rec.Code != http.StatusNotFound {
+		t.Errorf("fixture without us = %d; want 404", rec.Code)
+	}
+}
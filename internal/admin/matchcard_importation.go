@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/observability"
+	"jim-dot-tennis/internal/repository"
 	"jim-dot-tennis/internal/services"
 )
 
@@ -153,6 +155,7 @@ func (h *MatchCardImportationHandler) processImport(w http.ResponseWriter, r *ht
 		h.service.playerRepository,
 		h.service.divisionRepository,
 		h.service.seasonRepository,
+		repository.NewUnitOfWork(h.service.db),
 		h.service.homeClubID,
 	)
 
@@ -170,10 +173,16 @@ func (h *MatchCardImportationHandler) processImport(w http.ResponseWriter, r *ht
 		ClearExistingMatchups: req.ClearExisting,
 	}
 
-	// Run the import with auto-nonce extraction
-	ctx := context.Background()
+	// Run the import with auto-nonce extraction. It carries on if the admin
+	// navigates away, but keeps the request ID for its logs.
+	ctx := context.WithoutCancel(r.Context())
 	result, err := matchCardService.ImportWeekMatchCardsWithAutoNonce(ctx, config, req.Week)
 
+	itemErrors := 0
+	if result != nil {
+		itemErrors = len(result.Errors)
+	}
+	observability.RecordImportRun("match_cards", time.Since(startTime), err, itemErrors)
 	processingTime := time.Since(startTime).Milliseconds()
 
 	if err != nil {
@@ -0,0 +1,84 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// PartnershipsHandler renders the partnership chemistry matrix
+type PartnershipsHandler struct {
+	service     *Service
+	templateDir string
+}
+
+// NewPartnershipsHandler creates a new partnerships handler
+func NewPartnershipsHandler(service *Service, templateDir string) *PartnershipsHandler {
+	return &PartnershipsHandler{
+		service:     service,
+		templateDir: templateDir,
+	}
+}
+
+// HandlePartnerships handles GET /admin/league/partnerships?season=&division=
+func (h *PartnershipsHandler) HandlePartnerships(w http.ResponseWriter, r *http.Request) {
+	user, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var filter PartnershipFilter
+	if id, err := strconv.ParseUint(r.URL.Query().Get("season"), 10, 32); err == nil {
+		filter.SeasonID = uint(id)
+	}
+
+	seasons, err := h.service.GetAllSeasons()
+	if err != nil {
+		logAndError(w, "Failed to load seasons", err, http.StatusInternalServerError)
+		return
+	}
+
+	// Divisions only make sense within a season
+	var divisions []models.Division
+	if filter.SeasonID != 0 {
+		divisions, err = h.service.GetDivisionsBySeason(filter.SeasonID)
+		if err != nil {
+			logAndError(w, "Failed to load divisions", err, http.StatusInternalServerError)
+			return
+		}
+		if id, err := strconv.ParseUint(r.URL.Query().Get("division"), 10, 32); err == nil {
+			filter.DivisionID = uint(id)
+		}
+	}
+
+	partnerships, err := h.service.GetPartnerships(r.Context(), filter)
+	if err != nil {
+		logAndError(w, "Failed to load partnerships", err, http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/partnerships.html")
+	if err != nil {
+		logAndError(w, "Failed to parse template", err, http.StatusInternalServerError)
+		return
+	}
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"User":         user,
+		"Seasons":      seasons,
+		"Divisions":    divisions,
+		"Filter":       filter,
+		"Partnerships": partnerships,
+		"Matrix":       BuildPartnershipMatrix(partnerships),
+		"HomeClubName": homeClubNameFromContext(r),
+	}); err != nil {
+		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
+	}
+}
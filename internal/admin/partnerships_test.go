@@ -0,0 +1,162 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestPartnershipsCountPairsFromTheirSideOfTheNet(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "partnerships.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	lastSeason := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	thisSeason := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES
+		(1, '2025', 2025, ?, ?, 0), (2, '2026', 2026, ?, ?, 1)`,
+		lastSeason, lastSeason.AddDate(0, 6, 0), thisSeason, thisSeason.AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES
+		(1, 1, 1, ?, ?, 'Week 1'), (2, 1, 2, ?, ?, 'Week 1')`,
+		lastSeason, lastSeason.AddDate(0, 0, 6), thisSeason, thisSeason.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES
+		(1, 'Division 1', 1, 'Thursday', 1, 1), (2, 'Division 1', 1, 'Thursday', 1, 2)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES
+		(1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'St Ann''s A', 1, 2, 2), (4, 'Hove A', 2, 2, 2)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('ann', 'Ann', 'Able', 1), ('bob', 'Bob', 'Baker', 1), ('cat', 'Cat', 'Cole', 1),
+		('h1', 'Hal', 'Hove', 2), ('h2', 'Hetty', 'Hove', 2)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes) VALUES
+		(1, 2, 1, 1, 1, 1, ?, '', 'Completed', ''),
+		(2, 3, 4, 2, 2, 2, ?, '', 'Completed', '')`,
+		lastSeason.AddDate(0, 1, 0), thisSeason.AddDate(0, 0, 7))
+
+	rubber := func(id, fixtureID int, matchupType, status string, homeScore, awayScore int, sets [6]interface{}, home, away []string) {
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes,
+			home_set1, away_set1, home_set2, away_set2, home_set3, away_set3) VALUES (?, ?, ?, ?, ?, ?, '', ?, ?, ?, ?, ?, ?)`,
+			append([]interface{}{id, fixtureID, matchupType, status, homeScore, awayScore}, sets[:]...)...)
+		for _, p := range home {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 1)`, id, p)
+		}
+		for _, p := range away {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 0)`, id, p)
+		}
+	}
+	hove := []string{"h1", "h2"}
+	// Last season away at Hove: Ann and Bob won in three sets as 1st Mixed
+	rubber(1, 1, "1st Mixed", "Finished", 0, 2, [6]interface{}{6, 4, 3, 6, 4, 6}, hove, []string{"ann", "bob"})
+	// and Ann and Cat lost their Womens
+	rubber(2, 1, "Womens", "Finished", 2, 0, [6]interface{}{6, 1, 6, 2, nil, nil}, hove, []string{"ann", "cat"})
+	// This season at home: Ann and Bob halved a Mens
+	rubber(3, 2, "Mens", "Finished", 1, 1, [6]interface{}{6, 3, 2, 6, nil, nil}, []string{"bob", "ann"}, hove)
+	// and an unfinished rubber doesn't count
+	rubber(4, 2, "2nd Mixed", "Playing", 0, 0, [6]interface{}{nil, nil, nil, nil, nil, nil}, []string{"bob", "cat"}, hove)
+
+	service := NewService(db, "", 1, "")
+	partnerships, err := service.GetPartnerships(ctx, PartnershipFilter{})
+	if err != nil {
+		t.Fatalf("partnerships: %v", err)
+	}
+	if len(partnerships) != 2 {
+		t.Fatalf("partnerships = %+v; want Ann & Bob and Ann & Cat", partnerships)
+	}
+
+	annBob := partnerships[0]
+	if annBob.Player1Name != "Ann Able" || annBob.Player2Name != "Bob Baker" {
+		t.Fatalf("most played pair = %s & %s; want Ann & Bob", annBob.Player1Name, annBob.Player2Name)
+	}
+	if annBob.Played != 2 || annBob.Won != 1 || annBob.Drawn != 1 || annBob.Lost != 0 || annBob.WinPercent != 50 {
+		t.Errorf("Ann & Bob record = %+v; want played 2, won 1, drawn 1", annBob)
+	}
+	// 4-6 6-3 6-4 away, then 6-3 2-6 at home
+	if annBob.SetsWon != 3 || annBob.SetsLost != 2 || annBob.GamesWon != 24 || annBob.GamesLost != 22 {
+		t.Errorf("Ann & Bob sets %d-%d games %d-%d; want 3-2 and 24-22", annBob.SetsWon, annBob.SetsLost, annBob.GamesWon, annBob.GamesLost)
+	}
+	if annBob.SetsRatio() != 1.5 {
+		t.Errorf("Ann & Bob sets ratio = %v; want 1.5", annBob.SetsRatio())
+	}
+	wantTypes := []PartnershipTypeRecord{
+		{Type: models.FirstMixed, Played: 1, Won: 1},
+		{Type: models.Mens, Played: 1, Drawn: 1},
+	}
+	if !reflect.DeepEqual(annBob.ByType, wantTypes) {
+		t.Errorf("Ann & Bob by type = %+v; want %+v", annBob.ByType, wantTypes)
+	}
+	if annCat := partnerships[1]; annCat.Lost != 1 || annCat.SetsLost != 2 || annCat.GamesRatio() != 3.0/12 {
+		t.Errorf("Ann & Cat = %+v; want a straight-sets loss", annCat)
+	}
+
+	// Filtering by season keeps only that season's rubbers
+	thisYear, err := service.GetPartnerships(ctx, PartnershipFilter{SeasonID: 2, DivisionID: 2})
+	if err != nil {
+		t.Fatalf("season partnerships: %v", err)
+	}
+	if len(thisYear) != 1 || thisYear[0].Played != 1 || thisYear[0].Drawn != 1 {
+		t.Errorf("2026 partnerships = %+v; want Ann & Bob's halved Mens only", thisYear)
+	}
+
+	// The selection page looks pairs up whichever way round they are
+	chemistry := PartnershipChemistry(partnerships)
+	if got := chemistry[PartnershipKey("bob", "ann")]; got.Played != 2 || got.SetsRatio != 1.5 {
+		t.Errorf("chemistry for bob|ann = %+v; want Ann & Bob's record", got)
+	}
+	if among := PartnershipsAmong(partnerships, []string{"ann", "cat"}); len(among) != 1 || among[0].Player2ID != "cat" {
+		t.Errorf("pairings among Ann and Cat = %+v; want just them", among)
+	}
+
+	matrix := BuildPartnershipMatrix(partnerships)
+	var names []string
+	for _, p := range matrix.Players {
+		names = append(names, p.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Ann Able", "Bob Baker", "Cat Cole"}) {
+		t.Errorf("matrix players = %v; want Ann, Bob, Cat", names)
+	}
+	if matrix.Cells[1][0] != matrix.Cells[0][1] || matrix.Cells[0][1].Played != 2 || matrix.Cells[1][2] != nil {
+		t.Errorf("matrix cells are not symmetric or Bob & Cat have a record")
+	}
+
+	// The matrix page renders for a captain
+	h := NewPartnershipsHandler(service, filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"))
+	req := httptest.NewRequest("GET", "/admin/league/partnerships?season=1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "captain"}))
+	rec := httptest.NewRecorder()
+	h.HandlePartnerships(rec, req)
+	body := rec.Body.String()
+	for _, want := range []string{`data-testid="partnership-matrix"`, `class="chem-strong"`, `Ann Able</strong> &amp; <strong>Cat Cole`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("partnerships page is missing %s", want)
+		}
+	}
+	if strings.Contains(body, `class="chem-good"`) {
+		t.Errorf("partnerships page shows this season's draw under last season")
+	}
+}
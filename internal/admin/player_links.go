@@ -0,0 +1,66 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// handlePlayerLink handles the player link lifecycle actions:
+//
+//	POST /admin/league/players/{id}/link/rotate  (grace_days, reason)
+//	POST /admin/league/players/{id}/link/revoke  (retired_id)
+func (h *PlayersHandler) handlePlayerLink(w http.ResponseWriter, r *http.Request) {
+	user, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/admin/league/players/"), "/")
+	if len(pathParts) != 3 || pathParts[0] == "" || pathParts[1] != "link" {
+		http.Error(w, "Invalid player link URL", http.StatusBadRequest)
+		return
+	}
+	playerID := pathParts[0]
+
+	switch pathParts[2] {
+	case "rotate":
+		graceDays, err := strconv.Atoi(r.FormValue("grace_days"))
+		if err != nil {
+			logAndError(w, "Invalid grace period", err, http.StatusBadRequest)
+			return
+		}
+		match, err := h.service.RotatePlayerLink(r.Context(), playerID, graceDays,
+			strings.TrimSpace(r.FormValue("reason")), user.Username)
+		if err != nil {
+			logAndError(w, "Failed to rotate player link", err, http.StatusInternalServerError)
+			return
+		}
+		log.Printf("User %s rotated link for player %s (grace %d days); new pairing %d", user.Username, playerID, graceDays, match.ID)
+	case "revoke":
+		retiredID, err := strconv.ParseUint(r.FormValue("retired_id"), 10, 32)
+		if err != nil {
+			logAndError(w, "Invalid retired link ID", err, http.StatusBadRequest)
+			return
+		}
+		if err := h.service.RevokeRetiredPlayerLink(r.Context(), playerID, uint(retiredID)); err != nil {
+			logAndError(w, "Failed to revoke link", err, http.StatusBadRequest)
+			return
+		}
+		log.Printf("User %s revoked retired link %d for player %s", user.Username, retiredID, playerID)
+	default:
+		http.Error(w, "Unknown link action", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/league/players/%s/edit#private-link", playerID), http.StatusSeeOther)
+}
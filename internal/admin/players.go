@@ -41,6 +41,12 @@ func (h *PlayersHandler) HandlePlayers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check if this is a private link rotate/revoke request
+	if strings.Contains(r.URL.Path, "/link/") {
+		h.handlePlayerLink(w, r)
+		return
+	}
+
 	// Check if this is an edit request
 	if strings.Contains(r.URL.Path, "/edit") {
 		h.handlePlayerEdit(w, r)
@@ -379,6 +385,15 @@ func (h *PlayersHandler) handlePlayerEditGet(w http.ResponseWriter, r *http.Requ
 		currentFantasyMatchID = *player.FantasyMatchID
 	}
 
+	// Link history and the devices that have opened the player's links
+	var linkActivity *PlayerLinkActivity
+	if currentFantasyDetail != nil {
+		linkActivity, err = h.service.GetPlayerLinkActivity(r.Context(), playerID, currentFantasyDetail.Match.AuthToken)
+		if err != nil {
+			log.Printf("Failed to load link activity for %s: %v", playerID, err)
+		}
+	}
+
 	// Check push notification status
 	hasPushNotifications := false
 	fantasyAuthToken := ""
@@ -401,6 +416,7 @@ func (h *PlayersHandler) handlePlayerEditGet(w http.ResponseWriter, r *http.Requ
 		"FantasyAuthToken":      fantasyAuthToken,
 		"MyTennisSummary":       myTennisSummary,
 		"CaptainNotes":          captainNotes,
+		"LinkActivity":          linkActivity,
 	}); err != nil {
 		logAndError(w, err.Error(), err, http.StatusInternalServerError)
 	}
@@ -1174,8 +1190,8 @@ func (h *PlayersHandler) handleRemindAllPlayers(w http.ResponseWriter, r *http.R
 			"title": "Set Your Availability",
 			"body":  fmt.Sprintf("Please set your availability for the week of %s", weekLabel),
 			"data": map[string]string{
-				"type": "availability-reminder",
-				"url":  "/my-availability/" + token,
+				"type": notificationTypeAvailabilityReminder,
+				"url":  h.service.linkSigner.NotificationLink(player.ID, token, ""),
 			},
 		}
 
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/config"
 	"jim-dot-tennis/internal/models"
 )
@@ -251,7 +253,8 @@ func (h *PointsHandler) getCompletedMatchupsWithPlayers(ctx context.Context, sea
 	}
 	defer rows.Close()
 
-	var matchupsWithPlayers []CompletedMatchupWithPlayers
+	var matchups []CompletedMatchupWithPlayers
+	var matchupIDs []uint
 
 	for rows.Next() {
 		var matchup models.Matchup
@@ -269,64 +272,79 @@ func (h *PointsHandler) getCompletedMatchupsWithPlayers(ctx context.Context, sea
 			return nil, fmt.Errorf("failed to scan matchup: %w", err)
 		}
 
-		// Get players for this matchup
-		homePlayers, awayPlayers, err := h.getMatchupPlayers(ctx, matchup.ID)
-		if err != nil {
-			log.Printf("Warning: Failed to get players for matchup %d: %v", matchup.ID, err)
-			continue
-		}
-
-		matchupsWithPlayers = append(matchupsWithPlayers, CompletedMatchupWithPlayers{
+		matchups = append(matchups, CompletedMatchupWithPlayers{
 			Matchup:       matchup,
-			HomePlayers:   homePlayers,
-			AwayPlayers:   awayPlayers,
 			FixtureStatus: models.FixtureStatus(fixtureStatusStr),
 		})
+		matchupIDs = append(matchupIDs, matchup.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read matchups: %w", err)
+	}
+	rows.Close()
+
+	// Get the players for every matchup at once
+	homePlayers, awayPlayers, err := h.getMatchupPlayers(ctx, matchupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matchups {
+		matchups[i].HomePlayers = homePlayers[matchups[i].Matchup.ID]
+		matchups[i].AwayPlayers = awayPlayers[matchups[i].Matchup.ID]
 	}
 
-	return matchupsWithPlayers, nil
+	return matchups, nil
 }
 
-// getMatchupPlayers retrieves home and away players for a specific matchup
-func (h *PointsHandler) getMatchupPlayers(ctx context.Context, matchupID uint) ([]models.Player, []models.Player, error) {
-	query := `
+// getMatchupPlayers retrieves the home and away players for several matchups,
+// keyed by matchup ID
+func (h *PointsHandler) getMatchupPlayers(ctx context.Context, matchupIDs []uint) (map[uint][]models.Player, map[uint][]models.Player, error) {
+	homePlayers := make(map[uint][]models.Player)
+	awayPlayers := make(map[uint][]models.Player)
+	if len(matchupIDs) == 0 {
+		return homePlayers, awayPlayers, nil
+	}
+
+	query, args, err := sqlx.In(`
 		SELECT p.id, p.first_name, p.last_name, p.preferred_name, p.gender, p.reporting_privacy,
-		       p.club_id, p.fantasy_match_id, p.is_active, p.created_at, p.updated_at, mp.is_home
+		       p.club_id, p.fantasy_match_id, p.is_active, p.created_at, p.updated_at, mp.matchup_id, mp.is_home
 		FROM players p
 		INNER JOIN matchup_players mp ON p.id = mp.player_id
-		WHERE mp.matchup_id = ?
-		ORDER BY mp.is_home DESC, p.last_name ASC
-	`
+		WHERE mp.matchup_id IN (?)
+		ORDER BY mp.matchup_id, mp.is_home DESC, p.last_name ASC
+	`, matchupIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build matchup players query: %w", err)
+	}
 
-	rows, err := h.service.db.QueryContext(ctx, query, matchupID)
+	rows, err := h.service.db.QueryContext(ctx, h.service.db.Rebind(query), args...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to query matchup players: %w", err)
 	}
 	defer rows.Close()
 
-	var homePlayers, awayPlayers []models.Player
-
 	for rows.Next() {
 		var player models.Player
+		var matchupID uint
 		var isHome bool
 
 		err := rows.Scan(
 			&player.ID, &player.FirstName, &player.LastName, &player.PreferredName,
 			&player.Gender, &player.ReportingPrivacy, &player.ClubID, &player.FantasyMatchID,
-			&player.IsActive, &player.CreatedAt, &player.UpdatedAt, &isHome,
+			&player.IsActive, &player.CreatedAt, &player.UpdatedAt, &matchupID, &isHome,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan player: %w", err)
 		}
 
 		if isHome {
-			homePlayers = append(homePlayers, player)
+			homePlayers[matchupID] = append(homePlayers[matchupID], player)
 		} else {
-			awayPlayers = append(awayPlayers, player)
+			awayPlayers[matchupID] = append(awayPlayers[matchupID], player)
 		}
 	}
 
-	return homePlayers, awayPlayers, nil
+	return homePlayers, awayPlayers, rows.Err()
 }
 
 // processMatchupPoints calculates and assigns points for a single matchup
@@ -592,10 +610,15 @@ func (h *PointsHandler) getRescheduledWeekHeader() (string, error) {
 	}
 
 	// Build descriptive header for these rescheduled fixtures
+	var teamIDs []uint
+	for _, f := range fixturesInWindow {
+		teamIDs = append(teamIDs, f.HomeTeamID, f.AwayTeamID)
+	}
+	teams, _ := h.service.teamRepository.FindByIDs(ctx, teamIDs)
+
 	var parts []string
 	for _, f := range fixturesInWindow {
-		homeTeam, _ := h.service.teamRepository.FindByID(ctx, f.HomeTeamID)
-		awayTeam, _ := h.service.teamRepository.FindByID(ctx, f.AwayTeamID)
+		homeTeam, awayTeam := teams[f.HomeTeamID], teams[f.AwayTeamID]
 		homeName := fmt.Sprintf("Team %d", f.HomeTeamID)
 		awayName := fmt.Sprintf("Team %d", f.AwayTeamID)
 		if homeTeam != nil && homeTeam.Name != "" {
@@ -0,0 +1,83 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// reachabilityWindowDays is the default look-back for the reachability report
+const reachabilityWindowDays = 30
+
+// PushReachabilityHandler renders the push notification reachability report
+type PushReachabilityHandler struct {
+	service     *Service
+	templateDir string
+}
+
+// NewPushReachabilityHandler creates a new push reachability handler
+func NewPushReachabilityHandler(service *Service, templateDir string) *PushReachabilityHandler {
+	return &PushReachabilityHandler{
+		service:     service,
+		templateDir: templateDir,
+	}
+}
+
+// HandlePushReachability handles GET /admin/league/push-reachability.
+// ?days=N changes the look-back window; ?player_id=X adds that player's
+// recent delivery attempts below the report.
+func (h *PushReachabilityHandler) HandlePushReachability(w http.ResponseWriter, r *http.Request) {
+	user, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days := reachabilityWindowDays
+	if d, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && d > 0 {
+		days = d
+	}
+
+	report, err := h.service.GetPushReachability(r.Context(), days)
+	if err != nil {
+		logAndError(w, "Failed to load push reachability", err, http.StatusInternalServerError)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, row := range report {
+		counts[string(row.Status)]++
+	}
+
+	data := map[string]interface{}{
+		"User":   user,
+		"Report": report,
+		"Days":   days,
+		"Counts": counts,
+	}
+
+	if playerID := r.URL.Query().Get("player_id"); playerID != "" {
+		player, deliveries, err := h.service.GetPlayerPushDeliveries(r.Context(), playerID, 50)
+		if err != nil {
+			logAndError(w, "Failed to load push deliveries", err, http.StatusNotFound)
+			return
+		}
+		data["SelectedPlayer"] = player
+		data["Deliveries"] = deliveries
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/push_reachability.html")
+	if err != nil {
+		logAndError(w, "Failed to parse template", err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := renderTemplate(w, tmpl, data); err != nil {
+		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
+	}
+}
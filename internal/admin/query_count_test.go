@@ -0,0 +1,204 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The fixtures list, team selection and points table load their related rows
+// in batches, so the number of queries they make does not grow with the
+// number of fixtures, players or matchups on the screen.
+func TestScreenQueryCountsDoNotGrow(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "queries.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	seasonStart := time.Now().AddDate(0, -2, 0).Truncate(24 * time.Hour)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`,
+		seasonStart, seasonStart.AddDate(0, 6, 0))
+	for i := 1; i <= 18; i++ {
+		exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (?, ?, 1, ?, ?, ?)`,
+			i, i, seasonStart.AddDate(0, 0, (i-1)*7), seasonStart.AddDate(0, 0, i*7-1), fmt.Sprintf("Week %d", i))
+	}
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s', 1, 1, 1), (2, 'Hove', 2, 1, 1), (3, 'Hove B', 2, 1, 1)`)
+
+	playerCount := 0
+	addPlayers := func(n int) {
+		for i := 0; i < n; i++ {
+			playerCount++
+			id := fmt.Sprintf("p%d", playerCount)
+			exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES (?, 'Player', ?, 1)`, id, id)
+			exec(`INSERT INTO player_teams (player_id, team_id, season_id) VALUES (?, 1, 1)`, id)
+		}
+	}
+	fixtureCount := 0
+	addFixture := func(date time.Time, status models.FixtureStatus) int {
+		fixtureCount++
+		awayTeam := 2 + fixtureCount%2
+		exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+			VALUES (?, 1, ?, 1, 1, ?, ?, '', ?, '')`, fixtureCount, awayTeam, fixtureCount%18+1, date, string(status))
+		return fixtureCount
+	}
+	addCompletedFixture := func() {
+		fixtureID := addFixture(seasonStart.AddDate(0, 0, fixtureCount), models.Completed)
+		for i, matchupType := range []string{"Mens", "Womens"} {
+			matchupID := fixtureID*10 + i
+			exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, ?, ?, 'Finished', 2, 0, '')`,
+				matchupID, fixtureID, matchupType)
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, 'p1', 1), (?, 'p2', 1)`, matchupID, matchupID)
+		}
+	}
+
+	svc := NewService(db, "", 1, "")
+	count := func(screen func()) (total, availability int) {
+		db.OnQuery(func(query string) {
+			total++
+			if strings.Contains(query, "availability") {
+				availability++
+			}
+		})
+		defer db.OnQuery(nil)
+		screen()
+		return total, availability
+	}
+
+	addPlayers(4)
+	for i := 0; i < 2; i++ {
+		addFixture(time.Now().AddDate(0, 0, 7+i), models.Scheduled)
+	}
+	addCompletedFixture()
+
+	fixturesScreen := func() {
+		if _, fixtures, err := svc.GetHomeClubFixtures(); err != nil || len(fixtures) == 0 {
+			t.Fatalf("GetHomeClubFixtures: %d fixtures, %v", len(fixtures), err)
+		}
+	}
+	selectionScreen := func() {
+		if _, _, err := svc.GetAvailablePlayersWithEligibilityForTeamSelection(1, 0); err != nil {
+			t.Fatalf("team selection: %v", err)
+		}
+	}
+	points := NewPointsHandler(svc, "")
+	pointsScreen := func() {
+		if _, _, err := points.calculatePlayerPoints(); err != nil {
+			t.Fatalf("points table: %v", err)
+		}
+	}
+
+	fixturesBefore, _ := count(fixturesScreen)
+	_, selectionAvailability := count(selectionScreen)
+	pointsBefore, _ := count(pointsScreen)
+
+	addPlayers(4)
+	for i := 0; i < 4; i++ {
+		addFixture(time.Now().AddDate(0, 0, 14+i), models.Scheduled)
+		addCompletedFixture()
+	}
+
+	if after, _ := count(fixturesScreen); after != fixturesBefore {
+		t.Errorf("fixtures list: %d queries with 2 fixtures, %d with 6", fixturesBefore, after)
+	}
+	if _, after := count(selectionScreen); selectionAvailability != 1 || after != 1 {
+		t.Errorf("team selection: %d availability queries for 4 players, %d for 8; want 1", selectionAvailability, after)
+	}
+	if after, _ := count(pointsScreen); after != pointsBefore {
+		t.Errorf("points table: %d queries with 1 completed fixture, %d with 5", pointsBefore, after)
+	}
+}
+
+// The joined availability query keeps the precedence of the per-player
+// lookups it replaced: fixture-specific, then a date exception, then the
+// general day-of-week pattern, then Unknown.
+func TestResolveFixtureAvailabilityPrecedence(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "availability.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	fixtureDate := time.Date(2026, 6, 18, 18, 30, 0, 0, time.UTC) // a Thursday
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`,
+		fixtureDate.AddDate(0, -2, 0), fixtureDate.AddDate(0, 3, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`,
+		fixtureDate.AddDate(0, 0, -3), fixtureDate.AddDate(0, 0, 3))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'St Ann''s', 1, 1, 1), (2, 'Hove', 2, 1, 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (1, 1, 2, 1, 1, 1, ?, '', 'Scheduled', '')`, fixtureDate)
+	for _, id := range []string{"fixture", "exception", "general", "unset"} {
+		exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES (?, 'Player', ?, 1)`, id, id)
+	}
+
+	// Each player has every lower-priority kind set too, disagreeing with
+	// the one that should win
+	for _, id := range []string{"fixture", "exception", "general"} {
+		exec(`INSERT INTO player_general_availability (player_id, day_of_week, status, season_id, notes) VALUES (?, 'Thursday', 'IfNeeded', 1, 'Thursdays if needed')`, id)
+		exec(`INSERT INTO player_general_availability (player_id, day_of_week, status, season_id, notes) VALUES (?, 'Tuesday', 'Unavailable', 1, '')`, id)
+	}
+	for _, id := range []string{"fixture", "exception"} {
+		exec(`INSERT INTO player_availability_exceptions (player_id, status, start_date, end_date, reason) VALUES (?, 'Unavailable', ?, ?, 'Away')`,
+			id, fixtureDate.AddDate(0, 0, -2), fixtureDate.AddDate(0, 0, 2))
+	}
+	exec(`INSERT INTO player_fixture_availability (player_id, fixture_id, status, notes) VALUES ('fixture', 1, 'Available', 'Back early')`)
+
+	svc := NewService(db, "", 1, "")
+	fixture, err := svc.fixtureRepository.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := svc.resolveFixtureAvailability(ctx, fixture, []string{"fixture", "exception", "general", "unset", "missing"})
+
+	want := map[string]PlayerAvailabilityInfo{
+		"fixture":   {Status: models.Available, Notes: "Back early"},
+		"exception": {Status: models.Unavailable, Notes: "Away"},
+		"general":   {Status: models.IfNeeded, Notes: "Thursdays if needed"},
+		"unset":     {Status: models.Unknown},
+		"missing":   {Status: models.Unknown},
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("%s: got %+v, want %+v", id, got[id], w)
+		}
+	}
+}
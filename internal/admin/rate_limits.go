@@ -0,0 +1,87 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"log"
+	"net/http"
+
+	"jim-dot-tennis/internal/ratelimit"
+)
+
+// RateLimitsHandler shows the clients the rate limiter is currently holding
+// back and lets an admin lift a block
+type RateLimitsHandler struct {
+	limiter     *ratelimit.Limiter
+	templateDir string
+}
+
+// NewRateLimitsHandler creates a new rate limits handler
+func NewRateLimitsHandler(limiter *ratelimit.Limiter, templateDir string) *RateLimitsHandler {
+	return &RateLimitsHandler{
+		limiter:     limiter,
+		templateDir: templateDir,
+	}
+}
+
+// SetRateLimiter gives the admin pages the limiter protecting the public routes
+func (h *Handler) SetRateLimiter(limiter *ratelimit.Limiter) {
+	h.rateLimits.limiter = limiter
+}
+
+// HandleRateLimits handles GET /admin/league/rate-limits and
+// POST /admin/league/rate-limits/clear
+func (h *RateLimitsHandler) HandleRateLimits(w http.ResponseWriter, r *http.Request) {
+	user, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/admin/league/rate-limits/clear" {
+		h.handleClear(w, r, user.Username)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var clients []ratelimit.Client
+	if h.limiter != nil {
+		clients = h.limiter.Throttled()
+	}
+
+	data := map[string]interface{}{
+		"User":       user,
+		"Enabled":    h.limiter != nil,
+		"Clients":    clients,
+		"SuccessMsg": r.URL.Query().Get("success"),
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/rate_limits.html")
+	if err != nil {
+		logAndError(w, "Failed to parse template", err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := renderTemplate(w, tmpl, data); err != nil {
+		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
+	}
+}
+
+// handleClear lifts the limit on one client
+func (h *RateLimitsHandler) handleClear(w http.ResponseWriter, r *http.Request, admin string) {
+	if h.limiter == nil {
+		http.Error(w, "Rate limiting is not enabled", http.StatusNotFound)
+		return
+	}
+	key := ratelimit.Key{
+		Group: r.FormValue("group"),
+		By:    r.FormValue("by"),
+		Value: r.FormValue("value"),
+	}
+	h.limiter.Clear(key)
+	log.Printf("Admin %s cleared rate limit on %s", admin, key)
+	http.Redirect(w, r, "/admin/league/rate-limits?success=Client+unblocked", http.StatusSeeOther)
+}
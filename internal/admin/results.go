@@ -348,27 +348,13 @@ func (h *ResultsHandler) handleResultsPost(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Save results
-	if err := h.service.SaveMatchupResults(fixtureID, entries); err != nil {
+	// Save results, mirror them onto the other slate for derbies so both
+	// captain views stay in sync, and mark the fixture as completed
+	if err := h.service.SaveFixtureResults(r.Context(), fixtureID, entries, isDerby, managingTeamID); err != nil {
 		logAndError(w, "Failed to save results", err, http.StatusInternalServerError)
 		return
 	}
 
-	// For derbies, mirror the score/concession/retirement fields onto the other
-	// team's slate so both captain views stay in sync. Players are NOT mirrored
-	// — each slate keeps its own roster (the whole reason dual slates exist).
-	if isDerby {
-		if err := h.service.MirrorDerbyResults(fixtureID, managingTeamID, entries); err != nil {
-			log.Printf("Warning: failed to mirror derby results for fixture %d: %v", fixtureID, err)
-		}
-	}
-
-	// Mark fixture as completed
-	if err := h.service.CompleteFixtureWithResults(fixtureID); err != nil {
-		logAndError(w, "Failed to complete fixture", err, http.StatusInternalServerError)
-		return
-	}
-
 	// Redirect back to fixture detail, preserving the active managing team for derbies
 	redirectURL := fmt.Sprintf("/admin/league/fixtures/%d", fixtureID)
 	if isDerby {
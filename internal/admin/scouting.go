@@ -0,0 +1,59 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"net/http"
+)
+
+// ScoutingHandler renders opposition scouting reports
+type ScoutingHandler struct {
+	service     *Service
+	templateDir string
+}
+
+// NewScoutingHandler creates a new scouting handler
+func NewScoutingHandler(service *Service, templateDir string) *ScoutingHandler {
+	return &ScoutingHandler{
+		service:     service,
+		templateDir: templateDir,
+	}
+}
+
+// HandleScouting handles GET /admin/league/scouting/{teamID}
+func (h *ScoutingHandler) HandleScouting(w http.ResponseWriter, r *http.Request) {
+	user, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	teamID, err := parseIDFromPath(r.URL.Path, "/admin/league/scouting/")
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetScoutingReport(r.Context(), teamID)
+	if err != nil {
+		logAndError(w, "Failed to build scouting report", err, http.StatusNotFound)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/scouting.html")
+	if err != nil {
+		logAndError(w, "Failed to parse template", err, http.StatusInternalServerError)
+		return
+	}
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"User":         user,
+		"Report":       report,
+		"HomeClubName": homeClubNameFromContext(r),
+	}); err != nil {
+		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
+	}
+}
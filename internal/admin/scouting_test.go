@@ -0,0 +1,135 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestScoutingReportAggregatesOppositionLineups(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "scouting.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	// Hove A played us last season and this season, and Preston this season.
+	// Each season's Hove A is its own team row.
+	lastSeason := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	thisSeason := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES
+		(1, '2025', 2025, ?, ?, 0), (2, '2026', 2026, ?, ?, 1)`,
+		lastSeason, lastSeason.AddDate(0, 6, 0), thisSeason, thisSeason.AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES
+		(1, 1, 1, ?, ?, 'Week 1'), (2, 1, 2, ?, ?, 'Week 1')`,
+		lastSeason, lastSeason.AddDate(0, 0, 6), thisSeason, thisSeason.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES
+		(1, 'Division 1', 1, 'Thursday', 1, 1), (2, 'Division 1', 1, 'Thursday', 1, 2)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES
+		(1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', ''), (3, 'Preston', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1),
+		(3, 'St Ann''s A', 1, 2, 2), (4, 'Hove A', 2, 2, 2), (5, 'Preston A', 3, 2, 2)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('us1', 'Una', 'Us', 1), ('us2', 'Ulla', 'Us', 1),
+		('h1', 'Hal', 'Hove', 2), ('h2', 'Hetty', 'Hove', 2), ('h3', 'Hugo', 'Hove', 2), ('h4', 'Hana', 'Hove', 2)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes) VALUES
+		(1, 2, 1, 1, 1, 1, ?, '', 'Completed', ''),
+		(2, 3, 4, 2, 2, 2, ?, '', 'Completed', ''),
+		(3, 4, 5, 2, 2, 2, ?, '', 'Completed', ''),
+		(4, 4, 3, 2, 2, 2, ?, '', 'Scheduled', '')`,
+		lastSeason.AddDate(0, 1, 0), thisSeason.AddDate(0, 0, 7), thisSeason.AddDate(0, 0, 14), thisSeason.AddDate(0, 2, 0))
+
+	rubber := func(id, fixtureID int, matchupType string, homeScore, awayScore int, home, away []string) {
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, ?, ?, 'Finished', ?, ?, '')`,
+			id, fixtureID, matchupType, homeScore, awayScore)
+		for _, p := range home {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 1)`, id, p)
+		}
+		for _, p := range away {
+			exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, 0)`, id, p)
+		}
+	}
+	us := []string{"us1", "us2"}
+	// Last season at Hove: they won both rubbers
+	rubber(1, 1, "Mens", 2, 0, []string{"h1", "h2"}, us)
+	rubber(2, 1, "Womens", 2, 0, []string{"h1", "h3"}, us)
+	// This season at ours: we won one and halved one
+	rubber(3, 2, "Mens", 2, 0, us, []string{"h1", "h2"})
+	rubber(4, 2, "Womens", 1, 1, us, []string{"h3", "h4"})
+	// This season against Preston, whose players we don't know
+	rubber(5, 3, "Mens", 2, 0, []string{"h1", "h2"}, nil)
+
+	svc := NewService(db, "", 1, "")
+	if _, err := svc.GetScoutingReport(ctx, 3); err == nil {
+		t.Error("scouting our own team succeeded; want an error")
+	}
+
+	report, err := svc.GetScoutingReport(ctx, 4)
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.ClubName != "Hove" || report.SeasonName != "2026" || report.CardedFixtures != 2 {
+		t.Errorf("report = %s, %s, %d carded fixtures; want Hove, 2026, 2", report.ClubName, report.SeasonName, report.CardedFixtures)
+	}
+
+	// Two meetings played (a loss last season, a win this) and one to come
+	wantRecord := ScoutingRecord{Played: 2, Won: 1, Lost: 1, RubbersWon: 1, RubbersLost: 2}
+	if report.Record != wantRecord {
+		t.Errorf("record = %+v; want %+v", report.Record, wantRecord)
+	}
+	var results []string
+	for _, m := range report.Meetings {
+		results = append(results, m.SeasonName+":"+m.Result)
+	}
+	if want := []string{"2026:", "2026:W", "2025:L"}; !reflect.DeepEqual(results, want) {
+		t.Errorf("meetings = %v; want %v", results, want)
+	}
+
+	type turnout struct {
+		name                  string
+		thisSeason, past, pct int
+		rubbers, won, lost    int
+	}
+	var got []turnout
+	for _, p := range report.Players {
+		got = append(got, turnout{p.Name, p.ThisSeason, p.PastMeetings, p.TurnoutPercent, p.Rubbers, p.Won, p.Lost})
+	}
+	want := []turnout{
+		{"Hal Hove", 2, 1, 100, 4, 3, 1},
+		{"Hetty Hove", 2, 1, 100, 3, 2, 1},
+		{"Hugo Hove", 1, 1, 50, 2, 1, 0},
+		{"Hana Hove", 1, 0, 50, 1, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("players = %+v; want %+v", got, want)
+	}
+	if partners := report.Players[0].Partners; !reflect.DeepEqual(partners, []string{"Hetty Hove", "Hugo Hove"}) {
+		t.Errorf("Hal's partners = %v; want Hetty then Hugo", partners)
+	}
+
+	if len(report.Pairings) != 3 || report.Pairings[0].Names != "Hal Hove & Hetty Hove" || report.Pairings[0].Played != 3 ||
+		report.Pairings[0].Won != 2 || report.Pairings[0].Lost != 1 {
+		t.Errorf("pairings = %+v; want Hal & Hetty first, 2-1 in 3", report.Pairings)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The acknowledge form on the team selection page posts back to that page,
+// so the team selection route must clear the alert and return the captain
+// to the page with the derby context intact.
+func TestAcknowledgeSelectionAlertFromTeamSelection(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "selection_alerts.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	fixtureDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 7)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`, fixtureDate.AddDate(0, -1, 0), fixtureDate.AddDate(0, 3, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`, fixtureDate, fixtureDate.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Test League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Tuesday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'Home', '', '', ''), (2, 'Rivals', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'Home A', 1, 1, 1), (2, 'Rivals', 2, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('picked', 'Picked', 'Player', 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (10, 1, 2, 1, 1, 1, ?, '', 'Scheduled', '')`, fixtureDate)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id) VALUES (10, 'picked', 1, 1, 1)`)
+	exec(`INSERT INTO selection_alerts (fixture_id, player_id, team_id, previous_status, new_status) VALUES (10, 'picked', 1, 'Available', 'Unavailable')`)
+
+	service := NewService(db, "", 1, "")
+	h := NewFixturesHandler(service, filepath.Join(filepath.Dir(findMigrationsPathAdmin(t)), "templates"), nil)
+
+	form := url.Values{"action": {"acknowledge_alert"}, "player_id": {"picked"}, "managing_team_id": {"1"}}
+	req := httptest.NewRequest("POST", "/admin/league/fixtures/10/team-selection", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, models.User{Username: "captain"}))
+	rec := httptest.NewRecorder()
+	h.HandleFixtures(rec, req)
+
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("acknowledge = %d %s; want 303", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Location"); got != "/admin/league/fixtures/10/team-selection?managingTeam=1" {
+		t.Errorf("redirect = %s", got)
+	}
+	if open := service.openSelectionAlertsByPlayer(ctx, 10); len(open) != 0 {
+		t.Errorf("alert still open after acknowledging: %+v", open)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"time"
 
+	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 	"jim-dot-tennis/internal/repository"
@@ -33,11 +34,20 @@ type Service struct {
 	venueOverrideRepository      repository.VenueOverrideRepository
 	tournamentProviderRepository repository.TournamentProviderRepository
 	tournamentRepository         repository.TournamentRepository
+	tournamentDrawRepository     repository.TournamentDrawRepository
+	tournamentEntryRepository    repository.TournamentEntryRepository
 	tennisPreferenceRepository   repository.PlayerTennisPreferenceRepository
 	captainNoteRepository        repository.CaptainNoteRepository
+	subOfferRepository           repository.SubOfferRepository
+	selectionAlertRepository     repository.SelectionAlertRepository
+	fixtureChangeRepository      repository.FixtureChangeRepository
+	playerTokenRepository        repository.PlayerTokenRepository
 	weatherService               *services.WeatherService
+	headToHeadService            *services.HeadToHeadService
 	teamEligibilityService       *TeamEligibilityService
 	pushService                  *webpush.Service
+	linkSigner                   *auth.LinkSigner
+	passwordTokens               *auth.PasswordTokens
 	courthiveAPIURL              string
 }
 
@@ -62,9 +72,18 @@ func NewService(db *database.DB, courthiveAPIURL string, homeClubID uint, bhplta
 		venueOverrideRepository:      repository.NewVenueOverrideRepository(db),
 		tournamentProviderRepository: repository.NewTournamentProviderRepository(db),
 		tournamentRepository:         repository.NewTournamentRepository(db),
+		tournamentDrawRepository:     repository.NewTournamentDrawRepository(db),
+		tournamentEntryRepository:    repository.NewTournamentEntryRepository(db),
 		tennisPreferenceRepository:   repository.NewPlayerTennisPreferenceRepository(db),
 		captainNoteRepository:        repository.NewCaptainNoteRepository(db),
+		subOfferRepository:           repository.NewSubOfferRepository(db),
+		selectionAlertRepository:     repository.NewSelectionAlertRepository(db),
+		fixtureChangeRepository:      repository.NewFixtureChangeRepository(db),
+		playerTokenRepository:        repository.NewPlayerTokenRepository(db),
 		weatherService:               services.NewWeatherService(),
+		headToHeadService:            services.NewHeadToHeadService(db),
+		linkSigner:                   auth.NewLinkSigner(db),
+		passwordTokens:               auth.NewPasswordTokens(db, auth.NewPasswordLinkSenderFromEnv()),
 		courthiveAPIURL:              courthiveAPIURL,
 	}
 
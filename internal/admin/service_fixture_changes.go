@@ -0,0 +1,351 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// fixtureChangeDateFormat is how dates appear in change notifications and the
+// change log — short enough for a push body, specific enough to be useful
+const fixtureChangeDateFormat = "Mon 2 Jan 15:04"
+
+// fixtureSnapshot captures the player-visible details of a fixture that the
+// change pipeline watches: date, status and resolved venue
+type fixtureSnapshot struct {
+	fixture   models.Fixture
+	venueID   uint
+	venueName string
+}
+
+// FixtureEdit is a combined edit from the fixture edit form. Status and
+// VenueClubID are optional; nil leaves them unchanged. A VenueClubID of 0
+// clears any per-fixture override so the venue resolves normally again.
+type FixtureEdit struct {
+	ScheduledDate    time.Time
+	RescheduleReason models.RescheduledReason
+	Notes            string
+	Status           *models.FixtureStatus
+	VenueClubID      *uint
+}
+
+// ApplyFixtureEdit saves a fixture edit and notifies affected players once
+// about everything that changed
+func (s *Service) ApplyFixtureEdit(ctx context.Context, fixtureID uint, edit FixtureEdit) error {
+	return s.withFixtureChangeNotifications(ctx, []uint{fixtureID}, func() error {
+		if err := s.updateFixtureSchedule(ctx, fixtureID, edit.ScheduledDate, edit.RescheduleReason, edit.Notes); err != nil {
+			return err
+		}
+		if edit.Status == nil && edit.VenueClubID == nil {
+			return nil
+		}
+
+		fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
+		if err != nil {
+			return err
+		}
+		if edit.Status != nil {
+			fixture.Status = *edit.Status
+		}
+		if edit.VenueClubID != nil {
+			if *edit.VenueClubID == 0 {
+				fixture.VenueClubID = nil
+			} else {
+				fixture.VenueClubID = edit.VenueClubID
+			}
+		}
+		return s.fixtureRepository.Update(ctx, fixture)
+	})
+}
+
+// GetFixtureChanges returns a fixture's change log, oldest first
+func (s *Service) GetFixtureChanges(ctx context.Context, fixtureID uint) ([]models.FixtureChange, error) {
+	return s.fixtureChangeRepository.FindByFixture(ctx, fixtureID)
+}
+
+// SaveVenueOverride creates or updates a date-range venue override and
+// notifies players in every fixture whose resolved venue moves as a result
+func (s *Service) SaveVenueOverride(ctx context.Context, override *models.VenueOverride) error {
+	fixtureIDs := s.fixturesForClubVenueRange(ctx, override.ClubID, override.StartDate, override.EndDate)
+	if override.ID != 0 {
+		// An edit may shrink the range; fixtures that drop out change too.
+		if existing, err := s.venueOverrideRepository.FindByID(ctx, override.ID); err == nil {
+			fixtureIDs = append(fixtureIDs, s.fixturesForClubVenueRange(ctx, existing.ClubID, existing.StartDate, existing.EndDate)...)
+		}
+	}
+
+	return s.withFixtureChangeNotifications(ctx, fixtureIDs, func() error {
+		if override.ID == 0 {
+			return s.venueOverrideRepository.Create(ctx, override)
+		}
+		return s.venueOverrideRepository.Update(ctx, override)
+	})
+}
+
+// DeleteVenueOverride removes a date-range venue override and notifies
+// players in fixtures that move back to their usual venue
+func (s *Service) DeleteVenueOverride(ctx context.Context, id uint) error {
+	existing, err := s.venueOverrideRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	fixtureIDs := s.fixturesForClubVenueRange(ctx, existing.ClubID, existing.StartDate, existing.EndDate)
+
+	return s.withFixtureChangeNotifications(ctx, fixtureIDs, func() error {
+		return s.venueOverrideRepository.Delete(ctx, id)
+	})
+}
+
+// fixturesForClubVenueRange lists fixtures hosted by clubID's teams between
+// from and to — the ones a date-range override for that club can move
+func (s *Service) fixturesForClubVenueRange(ctx context.Context, clubID uint, from, to time.Time) []uint {
+	fixtures, err := s.fixtureRepository.FindByClubAndDateRange(ctx, clubID, from, to.Add(24*time.Hour))
+	if err != nil {
+		log.Printf("Failed to find fixtures for club %d venue range: %v", clubID, err)
+		return nil
+	}
+
+	var ids []uint
+	for _, f := range fixtures {
+		home, err := s.teamRepository.FindByID(ctx, f.HomeTeamID)
+		if err != nil || home.ClubID != clubID {
+			continue
+		}
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
+
+// withFixtureChangeNotifications runs a write that may change one or more
+// fixtures, then logs and notifies players about any date, status or venue
+// change it caused. Notification is best-effort and never fails the write.
+func (s *Service) withFixtureChangeNotifications(ctx context.Context, fixtureIDs []uint, write func() error) error {
+	before := make(map[uint]*fixtureSnapshot, len(fixtureIDs))
+	for _, id := range fixtureIDs {
+		if _, seen := before[id]; seen {
+			continue
+		}
+		if snap, err := s.snapshotFixture(ctx, id); err == nil {
+			before[id] = snap
+		}
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	for id, prev := range before {
+		next, err := s.snapshotFixture(ctx, id)
+		if err != nil {
+			continue
+		}
+		changes := diffFixtureSnapshots(prev, next)
+		if len(changes) == 0 {
+			continue
+		}
+		s.recordAndNotifyFixtureChanges(ctx, prev, next, changes)
+	}
+	return nil
+}
+
+// snapshotFixture loads a fixture and resolves its current venue
+func (s *Service) snapshotFixture(ctx context.Context, fixtureID uint) (*fixtureSnapshot, error) {
+	fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	snap := &fixtureSnapshot{fixture: *fixture}
+
+	venueResolver := services.NewVenueResolver(s.clubRepository, s.teamRepository, s.venueOverrideRepository)
+	if resolution, err := venueResolver.ResolveFixtureVenue(ctx, fixture); err == nil && resolution.Club != nil {
+		snap.venueID = resolution.Club.ID
+		snap.venueName = resolution.Club.Name
+	}
+	return snap, nil
+}
+
+// diffFixtureSnapshots returns one change entry per watched field that differs
+func diffFixtureSnapshots(prev, next *fixtureSnapshot) []models.FixtureChange {
+	var changes []models.FixtureChange
+	if !prev.fixture.ScheduledDate.Equal(next.fixture.ScheduledDate) {
+		changes = append(changes, models.FixtureChange{
+			FixtureID:  next.fixture.ID,
+			ChangeType: models.FixtureChangeDate,
+			OldValue:   prev.fixture.ScheduledDate.Format(fixtureChangeDateFormat),
+			NewValue:   next.fixture.ScheduledDate.Format(fixtureChangeDateFormat),
+		})
+	}
+	if prev.fixture.Status != next.fixture.Status && isPlayerVisibleStatusChange(prev.fixture.Status, next.fixture.Status) {
+		changes = append(changes, models.FixtureChange{
+			FixtureID:  next.fixture.ID,
+			ChangeType: models.FixtureChangeStatus,
+			OldValue:   string(prev.fixture.Status),
+			NewValue:   string(next.fixture.Status),
+		})
+	}
+	if prev.venueID != next.venueID {
+		changes = append(changes, models.FixtureChange{
+			FixtureID:  next.fixture.ID,
+			ChangeType: models.FixtureChangeVenue,
+			OldValue:   prev.venueName,
+			NewValue:   next.venueName,
+		})
+	}
+	return changes
+}
+
+// isPlayerVisibleStatusChange reports whether a status transition is worth
+// telling players about: moving into or out of Postponed/Cancelled. Routine
+// progress (Scheduled -> InProgress -> Completed) is not.
+func isPlayerVisibleStatusChange(from, to models.FixtureStatus) bool {
+	disrupted := func(st models.FixtureStatus) bool {
+		return st == models.Postponed || st == models.Cancelled
+	}
+	return disrupted(from) || disrupted(to)
+}
+
+// recordAndNotifyFixtureChanges appends the changes to the fixture's change
+// log (which also bumps its iCal SEQUENCE) and pushes a single summary to
+// every selected or available player
+func (s *Service) recordAndNotifyFixtureChanges(ctx context.Context, prev, next *fixtureSnapshot, changes []models.FixtureChange) {
+	for i := range changes {
+		if err := s.fixtureChangeRepository.Create(ctx, &changes[i]); err != nil {
+			log.Printf("Failed to record %s change for fixture %d: %v", changes[i].ChangeType, next.fixture.ID, err)
+		}
+	}
+	log.Printf("Fixture %d changed: %s", next.fixture.ID, describeFixtureChanges(changes))
+
+	if s.pushService == nil {
+		return
+	}
+
+	recipients := s.fixtureChangeRecipients(ctx, &prev.fixture)
+	if len(recipients) == 0 {
+		return
+	}
+
+	matchLabel := fmt.Sprintf("Fixture %d", next.fixture.ID)
+	if home, err := s.teamRepository.FindByID(ctx, next.fixture.HomeTeamID); err == nil {
+		if away, err := s.teamRepository.FindByID(ctx, next.fixture.AwayTeamID); err == nil {
+			matchLabel = fmt.Sprintf("%s vs %s", home.Name, away.Name)
+		}
+	}
+	body := matchLabel + ": " + describeFixtureChanges(changes)
+
+	notified := 0
+	for _, player := range recipients {
+		token := s.getPlayerFantasyToken(ctx, player)
+		if token == "" {
+			continue
+		}
+		payload := map[string]interface{}{
+			"title": fixtureChangeTitle(changes, next.fixture.Status),
+			"body":  body,
+			"data": map[string]string{
+				"type": "fixture-change",
+				"url":  s.linkSigner.NotificationLink(player.ID, token, fmt.Sprintf("/fixture/%d", next.fixture.ID)),
+			},
+		}
+		sent, err := s.pushService.SendToPlayer(token, payload)
+		if err != nil {
+			log.Printf("Failed to send fixture change to player %s: %v", player.ID, err)
+		}
+		if sent > 0 {
+			notified++
+		}
+	}
+
+	// Store the reach against the last change of the batch; they all went
+	// out in the same notification.
+	last := changes[len(changes)-1]
+	if last.ID != 0 {
+		if err := s.fixtureChangeRepository.SetNotifiedCount(ctx, last.ID, notified); err != nil {
+			log.Printf("Failed to record notified count for fixture change %d: %v", last.ID, err)
+		}
+	}
+}
+
+// fixtureChangeRecipients returns everyone who should hear about a change:
+// players already selected, plus home-club players in the fixture's teams who
+// had said they were Available for it (as it stood before the change)
+func (s *Service) fixtureChangeRecipients(ctx context.Context, fixture *models.Fixture) []models.Player {
+	seen := map[string]bool{}
+	var recipients []models.Player
+	add := func(playerID string) {
+		if seen[playerID] {
+			return
+		}
+		seen[playerID] = true
+		if player, err := s.playerRepository.FindByID(ctx, playerID); err == nil && player.IsActive {
+			recipients = append(recipients, *player)
+		}
+	}
+
+	if selected, err := s.fixtureRepository.FindSelectedPlayers(ctx, fixture.ID); err == nil {
+		for _, fp := range selected {
+			add(fp.PlayerID)
+		}
+	}
+
+	for _, teamID := range []uint{fixture.HomeTeamID, fixture.AwayTeamID} {
+		team, err := s.teamRepository.FindByID(ctx, teamID)
+		if err != nil || team.ClubID != s.homeClubID {
+			continue
+		}
+		roster, err := s.teamRepository.FindPlayersInTeam(ctx, teamID, fixture.SeasonID)
+		if err != nil {
+			continue
+		}
+		for _, pt := range roster {
+			if !pt.IsActive || seen[pt.PlayerID] {
+				continue
+			}
+			if resolveCell(ctx, s, pt.PlayerID, fixture).Status == models.Available {
+				add(pt.PlayerID)
+			}
+		}
+	}
+	return recipients
+}
+
+// describeFixtureChanges renders changes as "Date: Tue 5 May 18:00 → Thu 7 May 18:00; Venue: …"
+func describeFixtureChanges(changes []models.FixtureChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, c := range changes {
+		parts = append(parts, fmt.Sprintf("%s: %s → %s", c.ChangeType, c.OldValue, c.NewValue))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// fixtureChangeTitle picks the push title, leading with the most disruptive change
+func fixtureChangeTitle(changes []models.FixtureChange, status models.FixtureStatus) string {
+	if len(changes) > 1 {
+		switch status {
+		case models.Cancelled:
+			return "Fixture cancelled"
+		case models.Postponed:
+			return "Fixture postponed"
+		}
+		return "Fixture updated"
+	}
+	switch changes[0].ChangeType {
+	case models.FixtureChangeDate:
+		return "Fixture rescheduled"
+	case models.FixtureChangeVenue:
+		return "Venue changed"
+	}
+	switch status {
+	case models.Cancelled:
+		return "Fixture cancelled"
+	case models.Postponed:
+		return "Fixture postponed"
+	}
+	return "Fixture back on"
+}
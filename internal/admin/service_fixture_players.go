@@ -5,7 +5,7 @@ package admin
 import (
 	"context"
 	"fmt"
-	"time"
+	"log"
 
 	"jim-dot-tennis/internal/models"
 )
@@ -16,6 +16,9 @@ type SelectedPlayerInfo struct {
 	Player             models.Player             `json:"player"`
 	AvailabilityStatus models.AvailabilityStatus `json:"availability_status"`
 	AvailabilityNotes  string                    `json:"availability_notes"`
+	// AvailabilityAlert is set when the player changed their availability
+	// after being selected and no captain has acknowledged it yet
+	AvailabilityAlert *models.SelectionAlert `json:"availability_alert,omitempty"`
 }
 
 // PlayerWithEligibility combines player information with availability and eligibility for team selection
@@ -48,14 +51,7 @@ func (s *Service) GetAvailablePlayersForFixture(fixtureID uint) ([]models.Player
 	// Find the home club team
 	var homeClubTeam *models.Team
 
-	// Get home team
-	homeTeam, err := s.teamRepository.FindByID(ctx, fixture.HomeTeamID)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Get away team
-	awayTeam, err := s.teamRepository.FindByID(ctx, fixture.AwayTeamID)
+	homeTeam, awayTeam, err := s.fixtureTeams(ctx, fixture)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -74,13 +70,17 @@ func (s *Service) GetAvailablePlayersForFixture(fixtureID uint) ([]models.Player
 		return nil, nil, err
 	}
 
-	var teamPlayers []models.Player
-	teamPlayerMap := make(map[string]bool) // Track team player IDs for deduplication
+	teamPlayerIDs := make([]string, 0, len(teamPlayerTeams))
 	for _, pt := range teamPlayerTeams {
-		if player, err := s.playerRepository.FindByID(ctx, pt.PlayerID); err == nil {
-			teamPlayers = append(teamPlayers, *player)
-			teamPlayerMap[player.ID] = true
-		}
+		teamPlayerIDs = append(teamPlayerIDs, pt.PlayerID)
+	}
+	teamPlayers, err := s.findPlayersInOrder(ctx, teamPlayerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	teamPlayerMap := make(map[string]bool) // Track team player IDs for deduplication
+	for _, player := range teamPlayers {
+		teamPlayerMap[player.ID] = true
 	}
 
 	// Get all home club players
@@ -115,12 +115,7 @@ func (s *Service) GetAvailablePlayersForFixtureWithTeamContext(fixtureID uint, m
 	homeClubID := s.homeClubID
 
 	// Get home and away teams
-	homeTeam, err := s.teamRepository.FindByID(ctx, fixture.HomeTeamID)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	awayTeam, err := s.teamRepository.FindByID(ctx, fixture.AwayTeamID)
+	homeTeam, awayTeam, err := s.fixtureTeams(ctx, fixture)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -163,13 +158,17 @@ func (s *Service) GetAvailablePlayersForFixtureWithTeamContext(fixtureID uint, m
 		return nil, nil, err
 	}
 
-	var teamPlayers []models.Player
-	teamPlayerMap := make(map[string]bool) // Track team player IDs for deduplication
+	teamPlayerIDs := make([]string, 0, len(teamPlayerTeams))
 	for _, pt := range teamPlayerTeams {
-		if player, err := s.playerRepository.FindByID(ctx, pt.PlayerID); err == nil {
-			teamPlayers = append(teamPlayers, *player)
-			teamPlayerMap[player.ID] = true
-		}
+		teamPlayerIDs = append(teamPlayerIDs, pt.PlayerID)
+	}
+	teamPlayers, err := s.findPlayersInOrder(ctx, teamPlayerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	teamPlayerMap := make(map[string]bool) // Track team player IDs for deduplication
+	for _, player := range teamPlayers {
+		teamPlayerMap[player.ID] = true
 	}
 
 	// Get all home club players
@@ -224,6 +223,30 @@ func (s *Service) RemovePlayerFromFixture(fixtureID uint, playerID string) error
 	return s.fixtureRepository.RemoveSelectedPlayer(ctx, fixtureID, playerID)
 }
 
+// AcknowledgeSelectionAlert clears the 'availability changed' flag for a
+// selected player once a captain has seen it
+func (s *Service) AcknowledgeSelectionAlert(ctx context.Context, fixtureID uint, playerID string) error {
+	return s.selectionAlertRepository.Acknowledge(ctx, fixtureID, playerID)
+}
+
+// openSelectionAlertsByPlayer returns the newest unacknowledged alert per
+// player for a fixture
+func (s *Service) openSelectionAlertsByPlayer(ctx context.Context, fixtureID uint) map[string]*models.SelectionAlert {
+	alerts, err := s.selectionAlertRepository.FindOpenByFixture(ctx, fixtureID)
+	if err != nil {
+		log.Printf("Failed to load selection alerts for fixture %d: %v", fixtureID, err)
+		return nil
+	}
+	byPlayer := make(map[string]*models.SelectionAlert, len(alerts))
+	for i := range alerts {
+		// Newest first, so keep the first one seen per player.
+		if _, seen := byPlayer[alerts[i].PlayerID]; !seen {
+			byPlayer[alerts[i].PlayerID] = &alerts[i]
+		}
+	}
+	return byPlayer
+}
+
 // ClearFixturePlayerSelection removes all selected players from a fixture
 func (s *Service) ClearFixturePlayerSelection(fixtureID uint) error {
 	ctx := context.Background()
@@ -300,27 +323,29 @@ func (s *Service) GetAvailablePlayersWithEligibilityForTeamSelection(fixtureID u
 	var teamID uint
 	if managingTeamID > 0 {
 		teamID = managingTeamID
-	} else {
-		// For non-derby matches, determine the home club team
-		homeClubID := s.homeClubID
-
-		// Check if home team is the home club
-		homeTeam, err := s.teamRepository.FindByID(ctx, fixture.HomeTeamID)
-		if err == nil && homeTeam.ClubID == homeClubID {
+	} else if homeTeam, awayTeam, err := s.fixtureTeams(ctx, fixture); err == nil {
+		// For non-derby matches, use whichever team is the home club's
+		if homeTeam.ClubID == s.homeClubID {
 			teamID = homeTeam.ID
-		} else {
-			// Check if away team is the home club
-			awayTeam, err := s.teamRepository.FindByID(ctx, fixture.AwayTeamID)
-			if err == nil && awayTeam.ClubID == homeClubID {
-				teamID = awayTeam.ID
-			}
+		} else if awayTeam.ClubID == s.homeClubID {
+			teamID = awayTeam.ID
 		}
 	}
 
+	// Resolve everyone's availability in one query rather than several per player
+	playerIDs := make([]string, 0, len(teamPlayers)+len(allHomeClubPlayers))
+	for _, player := range teamPlayers {
+		playerIDs = append(playerIDs, player.ID)
+	}
+	for _, player := range allHomeClubPlayers {
+		playerIDs = append(playerIDs, player.ID)
+	}
+	availabilities := s.resolveFixtureAvailability(ctx, fixture, playerIDs)
+
 	// Convert team players to players with availability and eligibility
 	var teamPlayersWithEligibility []PlayerWithEligibility
 	for _, player := range teamPlayers {
-		availability := s.determinePlayerAvailabilityForFixture(ctx, player.ID, fixtureID, fixture.ScheduledDate)
+		availability := availabilities[player.ID]
 
 		// Get eligibility information
 		var eligibility *PlayerEligibilityInfo
@@ -346,7 +371,7 @@ func (s *Service) GetAvailablePlayersWithEligibilityForTeamSelection(fixtureID u
 	// Convert all home club players to players with availability and eligibility
 	var allHomeClubPlayersWithEligibility []PlayerWithEligibility
 	for _, player := range allHomeClubPlayers {
-		availability := s.determinePlayerAvailabilityForFixture(ctx, player.ID, fixtureID, fixture.ScheduledDate)
+		availability := availabilities[player.ID]
 
 		// Get eligibility information
 		var eligibility *PlayerEligibilityInfo
@@ -372,45 +397,51 @@ func (s *Service) GetAvailablePlayersWithEligibilityForTeamSelection(fixtureID u
 	return teamPlayersWithEligibility, allHomeClubPlayersWithEligibility, nil
 }
 
-// determinePlayerAvailabilityForFixture determines a player's availability for a specific fixture
-// following the priority order: fixture-specific > date exception > general day-of-week > unknown
-func (s *Service) determinePlayerAvailabilityForFixture(ctx context.Context, playerID string, fixtureID uint, fixtureDate time.Time) PlayerAvailabilityInfo {
-	// 1. Check fixture-specific availability first (highest priority)
-	if fixtureAvail, err := s.availabilityRepository.GetPlayerFixtureAvailability(ctx, playerID, fixtureID); err == nil && fixtureAvail != nil {
-		return PlayerAvailabilityInfo{
-			Status: fixtureAvail.Status,
-			Notes:  fixtureAvail.Notes,
-		}
+// resolveFixtureAvailability returns each player's availability for a fixture,
+// following the priority order: fixture-specific > date exception > general
+// day-of-week > unknown. Players missing from the result are Unknown.
+func (s *Service) resolveFixtureAvailability(ctx context.Context, fixture *models.Fixture, playerIDs []string) map[string]PlayerAvailabilityInfo {
+	result := make(map[string]PlayerAvailabilityInfo, len(playerIDs))
+	for _, id := range playerIDs {
+		result[id] = PlayerAvailabilityInfo{Status: models.Unknown}
 	}
 
-	// 2. Check for date-specific exceptions
-	if dateAvail, err := s.availabilityRepository.GetPlayerAvailabilityByDate(ctx, playerID, fixtureDate); err == nil && dateAvail != nil {
-		return PlayerAvailabilityInfo{
-			Status: dateAvail.Status,
-			Notes:  dateAvail.Reason,
-		}
+	resolved, err := s.availabilityRepository.ResolveFixtureAvailability(ctx, playerIDs, fixture.ID, fixture.SeasonID, fixture.ScheduledDate)
+	if err != nil {
+		log.Printf("Failed to resolve availability for fixture %d: %v", fixture.ID, err)
+		return result
 	}
+	for id, availability := range resolved {
+		result[id] = PlayerAvailabilityInfo{Status: availability.Status, Notes: availability.Notes}
+	}
+	return result
+}
 
-	// 3. Check general day-of-week availability
-	// First get the current season - we'll need to implement this
-	// For now, we'll assume season ID 1 or get it from the fixture
-	fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
+// fixtureTeams loads a fixture's home and away teams in one query
+func (s *Service) fixtureTeams(ctx context.Context, fixture *models.Fixture) (*models.Team, *models.Team, error) {
+	teams, err := s.teamRepository.FindByIDs(ctx, []uint{fixture.HomeTeamID, fixture.AwayTeamID})
 	if err != nil {
-		return PlayerAvailabilityInfo{Status: models.Unknown}
+		return nil, nil, err
+	}
+	homeTeam, awayTeam := teams[fixture.HomeTeamID], teams[fixture.AwayTeamID]
+	if homeTeam == nil || awayTeam == nil {
+		return nil, nil, fmt.Errorf("teams for fixture %d not found", fixture.ID)
 	}
+	return homeTeam, awayTeam, nil
+}
 
-	dayOfWeek := fixtureDate.Weekday().String()
-	if generalAvails, err := s.availabilityRepository.GetPlayerGeneralAvailability(ctx, playerID, fixture.SeasonID); err == nil {
-		for _, avail := range generalAvails {
-			if avail.DayOfWeek == dayOfWeek {
-				return PlayerAvailabilityInfo{
-					Status: avail.Status,
-					Notes:  avail.Notes,
-				}
-			}
+// findPlayersInOrder loads players in one query, returned in the order of
+// ids. IDs with no matching player are skipped.
+func (s *Service) findPlayersInOrder(ctx context.Context, ids []string) ([]models.Player, error) {
+	found, err := s.playerRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	players := make([]models.Player, 0, len(found))
+	for _, id := range ids {
+		if player, ok := found[id]; ok {
+			players = append(players, *player)
 		}
 	}
-
-	// 4. Default to Unknown if nothing is specified
-	return PlayerAvailabilityInfo{Status: models.Unknown}
+	return players, nil
 }
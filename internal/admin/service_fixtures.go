@@ -303,44 +303,48 @@ func (s *Service) GetHomeClubTodaysFixtures() (*models.Club, []FixtureWithRelati
 	return homeClub, fixturesWithRelations, nil
 }
 
-// buildFixturesWithRelations is a helper method to build FixtureWithRelations from fixtures
+// buildFixturesWithRelations is a helper method to build FixtureWithRelations from fixtures.
+// Related teams, weeks, divisions and seasons are loaded in one batch each.
 func (s *Service) buildFixturesWithRelations(ctx context.Context, fixtures []models.Fixture, homeClub *models.Club) []FixtureWithRelations {
 	var fixturesWithRelations []FixtureWithRelations
 
+	var teamIDs, weekIDs, divisionIDs []uint
+	seasons := make(map[uint]*models.Season)
 	for _, fixture := range fixtures {
-		fixtureWithRelations := FixtureWithRelations{
-			Fixture: fixture,
-		}
-
-		// Declare team variables for later use
-		var homeTeam, awayTeam *models.Team
-
-		// Get home team
-		if team, err := s.teamRepository.FindByID(ctx, fixture.HomeTeamID); err == nil {
-			homeTeam = team
-			fixtureWithRelations.HomeTeam = homeTeam
-		}
-
-		// Get away team
-		if team, err := s.teamRepository.FindByID(ctx, fixture.AwayTeamID); err == nil {
-			awayTeam = team
-			fixtureWithRelations.AwayTeam = awayTeam
-		}
-
-		// Get week
-		if week, err := s.weekRepository.FindByID(ctx, fixture.WeekID); err == nil {
-			fixtureWithRelations.Week = week
-		}
-
-		// Get division
-		if division, err := s.getDivisionByID(ctx, fixture.DivisionID); err == nil {
-			fixtureWithRelations.Division = division
+		teamIDs = append(teamIDs, fixture.HomeTeamID, fixture.AwayTeamID)
+		weekIDs = append(weekIDs, fixture.WeekID)
+		divisionIDs = append(divisionIDs, fixture.DivisionID)
+		seasons[fixture.SeasonID] = nil
+	}
+	teams, err := s.teamRepository.FindByIDs(ctx, teamIDs)
+	if err != nil {
+		log.Printf("Failed to load teams for fixtures: %v", err)
+	}
+	weeks, err := s.weekRepository.FindByIDs(ctx, weekIDs)
+	if err != nil {
+		log.Printf("Failed to load weeks for fixtures: %v", err)
+	}
+	divisions, err := s.divisionRepository.FindByIDs(ctx, divisionIDs)
+	if err != nil {
+		log.Printf("Failed to load divisions for fixtures: %v", err)
+	}
+	// Fixtures almost always share one season, so there is no batch lookup
+	for seasonID := range seasons {
+		if season, err := s.getSeasonByID(ctx, seasonID); err == nil {
+			seasons[seasonID] = season
 		}
+	}
 
-		// Get season
-		if season, err := s.getSeasonByID(ctx, fixture.SeasonID); err == nil {
-			fixtureWithRelations.Season = season
+	for _, fixture := range fixtures {
+		fixtureWithRelations := FixtureWithRelations{
+			Fixture:  fixture,
+			HomeTeam: teams[fixture.HomeTeamID],
+			AwayTeam: teams[fixture.AwayTeamID],
+			Week:     weeks[fixture.WeekID],
+			Division: divisions[fixture.DivisionID],
+			Season:   seasons[fixture.SeasonID],
 		}
+		homeTeam, awayTeam := fixtureWithRelations.HomeTeam, fixtureWithRelations.AwayTeam
 
 		// Determine if the home club is home or away (only if teams were loaded successfully)
 		if homeTeam != nil && homeTeam.ClubID == homeClub.ID {
@@ -475,17 +479,27 @@ func (s *Service) GetFixtureDetail(fixtureID uint) (*FixtureDetail, error) {
 
 	// Get selected players for the fixture
 	if selectedPlayers, err := s.fixtureRepository.FindSelectedPlayers(ctx, fixtureID); err == nil {
-		var selectedPlayerInfos []SelectedPlayerInfo
+		alerts := s.openSelectionAlertsByPlayer(ctx, fixtureID)
+		playerIDs := make([]string, 0, len(selectedPlayers))
 		for _, sp := range selectedPlayers {
-			if player, err := s.playerRepository.FindByID(ctx, sp.PlayerID); err == nil {
-				// Get availability information for this player and fixture
-				availability := s.determinePlayerAvailabilityForFixture(ctx, sp.PlayerID, fixtureID, fixture.ScheduledDate)
+			playerIDs = append(playerIDs, sp.PlayerID)
+		}
+		players, err := s.playerRepository.FindByIDs(ctx, playerIDs)
+		if err != nil {
+			log.Printf("Failed to load selected players for fixture %d: %v", fixtureID, err)
+		}
+		availabilities := s.resolveFixtureAvailability(ctx, fixture, playerIDs)
 
+		var selectedPlayerInfos []SelectedPlayerInfo
+		for _, sp := range selectedPlayers {
+			if player, ok := players[sp.PlayerID]; ok {
+				availability := availabilities[sp.PlayerID]
 				selectedPlayerInfos = append(selectedPlayerInfos, SelectedPlayerInfo{
 					FixturePlayer:      sp,
 					Player:             *player,
 					AvailabilityStatus: availability.Status,
 					AvailabilityNotes:  availability.Notes,
+					AvailabilityAlert:  alerts[sp.PlayerID],
 				})
 			}
 		}
@@ -715,17 +729,27 @@ func (s *Service) GetFixtureDetailWithTeamContext(fixtureID uint, managingTeamID
 
 	// Get selected players for the fixture, filtered by managing team
 	if selectedPlayers, err := s.fixtureRepository.FindSelectedPlayersByTeam(ctx, fixtureID, managingTeamID); err == nil {
-		var selectedPlayerInfos []SelectedPlayerInfo
+		alerts := s.openSelectionAlertsByPlayer(ctx, fixtureID)
+		playerIDs := make([]string, 0, len(selectedPlayers))
 		for _, sp := range selectedPlayers {
-			if player, err := s.playerRepository.FindByID(ctx, sp.PlayerID); err == nil {
-				// Get availability information for this player and fixture
-				availability := s.determinePlayerAvailabilityForFixture(ctx, sp.PlayerID, fixtureID, fixture.ScheduledDate)
+			playerIDs = append(playerIDs, sp.PlayerID)
+		}
+		players, err := s.playerRepository.FindByIDs(ctx, playerIDs)
+		if err != nil {
+			log.Printf("Failed to load selected players for fixture %d: %v", fixtureID, err)
+		}
+		availabilities := s.resolveFixtureAvailability(ctx, fixture, playerIDs)
 
+		var selectedPlayerInfos []SelectedPlayerInfo
+		for _, sp := range selectedPlayers {
+			if player, ok := players[sp.PlayerID]; ok {
+				availability := availabilities[sp.PlayerID]
 				selectedPlayerInfos = append(selectedPlayerInfos, SelectedPlayerInfo{
 					FixturePlayer:      sp,
 					Player:             *player,
 					AvailabilityStatus: availability.Status,
 					AvailabilityNotes:  availability.Notes,
+					AvailabilityAlert:  alerts[sp.PlayerID],
 				})
 			}
 		}
@@ -844,10 +868,17 @@ func (s *Service) GetHomeClubNextWeekFixturesByDivision() (map[string][]FixtureW
 	return fixturesByDivision, nil
 }
 
-// UpdateFixtureSchedule updates a fixture's scheduled date and adds the previous date to history
+// UpdateFixtureSchedule updates a fixture's scheduled date and adds the previous date to history.
+// Affected players are notified if the date actually moves.
 func (s *Service) UpdateFixtureSchedule(fixtureID uint, newScheduledDate time.Time, rescheduleReason models.RescheduledReason, notes string) error {
 	ctx := context.Background()
+	return s.withFixtureChangeNotifications(ctx, []uint{fixtureID}, func() error {
+		return s.updateFixtureSchedule(ctx, fixtureID, newScheduledDate, rescheduleReason, notes)
+	})
+}
 
+// updateFixtureSchedule does the work of UpdateFixtureSchedule without notifying anyone
+func (s *Service) updateFixtureSchedule(ctx context.Context, fixtureID uint, newScheduledDate time.Time, rescheduleReason models.RescheduledReason, notes string) error {
 	// Get the current fixture to retrieve the current scheduled date
 	currentFixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
 	if err != nil {
@@ -0,0 +1,20 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+
+	"jim-dot-tennis/internal/services"
+)
+
+// GetTeamHeadToHead returns the record between two teams across every
+// season they have met
+func (s *Service) GetTeamHeadToHead(ctx context.Context, teamAID, teamBID uint) (*services.HeadToHead, error) {
+	return s.headToHeadService.Teams(ctx, teamAID, teamBID)
+}
+
+// GetClubRivalries returns a club's record against each club it has played
+func (s *Service) GetClubRivalries(ctx context.Context, clubID uint) ([]services.HeadToHead, error) {
+	return s.headToHeadService.ClubRivalries(ctx, clubID)
+}
@@ -346,10 +346,28 @@ func (s *Service) AddPlayerToMatchup(matchupID uint, playerID string, fixtureID
 	return nil
 }
 
-// SaveMatchupResults saves scores for all matchups in a fixture
-func (s *Service) SaveMatchupResults(fixtureID uint, entries []MatchupScoreEntry) error {
-	ctx := context.Background()
+// SaveFixtureResults saves the scores for a fixture's matchups, mirrors them
+// onto the other team's slate for a derby, and marks the fixture completed.
+// All of it is one transaction, so a failure part way leaves the fixture as
+// it was.
+func (s *Service) SaveFixtureResults(ctx context.Context, fixtureID uint, entries []MatchupScoreEntry, isDerby bool, managingTeamID uint) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.saveMatchupResults(ctx, entries); err != nil {
+			return err
+		}
+		// Players are NOT mirrored — each slate keeps its own roster (the
+		// whole reason dual slates exist)
+		if isDerby {
+			if err := s.mirrorDerbyResults(ctx, fixtureID, managingTeamID, entries); err != nil {
+				return fmt.Errorf("failed to mirror derby results: %w", err)
+			}
+		}
+		return s.completeFixtureWithResults(ctx, fixtureID)
+	})
+}
 
+// saveMatchupResults saves scores for all matchups in a fixture
+func (s *Service) saveMatchupResults(ctx context.Context, entries []MatchupScoreEntry) error {
 	for _, entry := range entries {
 		matchup, err := s.matchupRepository.FindByID(ctx, entry.MatchupID)
 		if err != nil {
@@ -479,13 +497,11 @@ func (s *Service) MergeDerbyOpponentPlayers(fixtureID uint, activeManagingTeamID
 	return matchups, nil
 }
 
-// MirrorDerbyResults copies score/concession/retirement fields from each entry's
+// mirrorDerbyResults copies score/concession/retirement fields from each entry's
 // matchup onto the same-type matchup belonging to the OTHER managing team in the
 // same fixture. Players are not mirrored — each slate keeps its own roster.
 // activeManagingTeamID is the slate the user just edited; we mirror onto the rest.
-func (s *Service) MirrorDerbyResults(fixtureID uint, activeManagingTeamID uint, entries []MatchupScoreEntry) error {
-	ctx := context.Background()
-
+func (s *Service) mirrorDerbyResults(ctx context.Context, fixtureID uint, activeManagingTeamID uint, entries []MatchupScoreEntry) error {
 	allMatchups, err := s.matchupRepository.FindByFixture(ctx, fixtureID)
 	if err != nil {
 		return fmt.Errorf("failed to load matchups for fixture %d: %w", fixtureID, err)
@@ -524,10 +540,8 @@ func (s *Service) MirrorDerbyResults(fixtureID uint, activeManagingTeamID uint,
 	return nil
 }
 
-// CompleteFixtureWithResults marks a fixture as completed
-func (s *Service) CompleteFixtureWithResults(fixtureID uint) error {
-	ctx := context.Background()
-
+// completeFixtureWithResults marks a fixture as completed
+func (s *Service) completeFixtureWithResults(ctx context.Context, fixtureID uint) error {
 	fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
 	if err != nil {
 		return fmt.Errorf("fixture not found: %w", err)
@@ -0,0 +1,344 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// PartnershipFilter narrows partnership stats to a season and/or division;
+// zero values mean all
+type PartnershipFilter struct {
+	SeasonID   uint
+	DivisionID uint
+}
+
+// Partnership is how two of our players have done playing together
+type Partnership struct {
+	Player1ID   string
+	Player1Name string
+	Player2ID   string
+	Player2Name string
+
+	Played     int
+	Won        int
+	Drawn      int
+	Lost       int
+	WinPercent float64
+	SetsWon    int
+	SetsLost   int
+	GamesWon   int
+	GamesLost  int
+	ByType     []PartnershipTypeRecord
+}
+
+// PartnershipTypeRecord is a pair's record in one matchup type
+type PartnershipTypeRecord struct {
+	Type   models.MatchupType `json:"type"`
+	Played int                `json:"played"`
+	Won    int                `json:"won"`
+	Drawn  int                `json:"drawn"`
+	Lost   int                `json:"lost"`
+}
+
+// PartnershipSummary is a pair's record in the shape the team selection
+// page's script reads
+type PartnershipSummary struct {
+	Played     int                     `json:"played"`
+	Won        int                     `json:"won"`
+	Drawn      int                     `json:"drawn"`
+	Lost       int                     `json:"lost"`
+	WinPercent float64                 `json:"winPercent"`
+	SetsRatio  float64                 `json:"setsRatio"`
+	GamesRatio float64                 `json:"gamesRatio"`
+	ByType     []PartnershipTypeRecord `json:"byType"`
+}
+
+// PartnershipMatrix lays partnerships out player by player; Cells[i][j] is
+// nil when Players[i] and Players[j] have never played together
+type PartnershipMatrix struct {
+	Players []PartnershipPlayer
+	Cells   [][]*Partnership
+}
+
+// PartnershipPlayer is one row and column of the matrix
+type PartnershipPlayer struct {
+	ID     string
+	Name   string
+	Played int
+}
+
+// SetsRatio is sets won per set lost, or sets won when none were lost
+func (p Partnership) SetsRatio() float64 {
+	return ratio(p.SetsWon, p.SetsLost)
+}
+
+// GamesRatio is games won per game lost, or games won when none were lost
+func (p Partnership) GamesRatio() float64 {
+	return ratio(p.GamesWon, p.GamesLost)
+}
+
+// ChemistryLevel buckets the win rate for shading the matrix
+func (p Partnership) ChemistryLevel() string {
+	switch {
+	case p.WinPercent >= 75:
+		return "strong"
+	case p.WinPercent >= 50:
+		return "good"
+	case p.WinPercent >= 25:
+		return "mixed"
+	default:
+		return "poor"
+	}
+}
+
+func ratio(won, lost int) float64 {
+	if lost == 0 {
+		return float64(won)
+	}
+	return float64(won) / float64(lost)
+}
+
+// PartnershipKey identifies a pair regardless of order
+func PartnershipKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+// partnershipRubber is one finished rubber two of our players played together
+type partnershipRubber struct {
+	Player1ID   string             `db:"player1_id"`
+	Player1Name string             `db:"player1_name"`
+	Player2ID   string             `db:"player2_id"`
+	Player2Name string             `db:"player2_name"`
+	Type        models.MatchupType `db:"type"`
+	IsHome      bool               `db:"is_home"`
+	HomeScore   int                `db:"home_score"`
+	AwayScore   int                `db:"away_score"`
+	HomeSet1    *int               `db:"home_set1"`
+	AwaySet1    *int               `db:"away_set1"`
+	HomeSet2    *int               `db:"home_set2"`
+	AwaySet2    *int               `db:"away_set2"`
+	HomeSet3    *int               `db:"home_set3"`
+	AwaySet3    *int               `db:"away_set3"`
+}
+
+// GetPartnerships returns every pairing of our club's players that has
+// finished a rubber together, most played first
+func (s *Service) GetPartnerships(ctx context.Context, filter PartnershipFilter) ([]Partnership, error) {
+	query := `
+		SELECT
+			mp1.player_id AS player1_id,
+			p1.first_name || ' ' || p1.last_name AS player1_name,
+			mp2.player_id AS player2_id,
+			p2.first_name || ' ' || p2.last_name AS player2_name,
+			m.type, mp1.is_home, m.home_score, m.away_score,
+			m.home_set1, m.away_set1, m.home_set2, m.away_set2, m.home_set3, m.away_set3
+		FROM matchup_players mp1
+		JOIN matchup_players mp2 ON mp1.matchup_id = mp2.matchup_id
+			AND mp1.is_home = mp2.is_home
+			AND mp1.player_id < mp2.player_id
+		JOIN matchups m ON mp1.matchup_id = m.id
+		JOIN fixtures f ON m.fixture_id = f.id
+		JOIN players p1 ON mp1.player_id = p1.id
+		JOIN players p2 ON mp2.player_id = p2.id
+		WHERE m.status = 'Finished' AND p1.club_id = ? AND p2.club_id = ?`
+	args := []interface{}{s.homeClubID, s.homeClubID}
+	if filter.SeasonID != 0 {
+		query += ` AND f.season_id = ?`
+		args = append(args, filter.SeasonID)
+	}
+	if filter.DivisionID != 0 {
+		query += ` AND f.division_id = ?`
+		args = append(args, filter.DivisionID)
+	}
+
+	var rubbers []partnershipRubber
+	if err := s.db.SelectContext(ctx, &rubbers, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to load partnerships: %w", err)
+	}
+
+	byPair := make(map[string]*Partnership)
+	var order []string
+	for _, r := range rubbers {
+		key := PartnershipKey(r.Player1ID, r.Player2ID)
+		p, ok := byPair[key]
+		if !ok {
+			p = &Partnership{Player1ID: r.Player1ID, Player1Name: r.Player1Name, Player2ID: r.Player2ID, Player2Name: r.Player2Name}
+			byPair[key] = p
+			order = append(order, key)
+		}
+		p.add(r)
+	}
+
+	partnerships := make([]Partnership, 0, len(order))
+	for _, key := range order {
+		p := byPair[key]
+		p.WinPercent = 100 * float64(p.Won) / float64(p.Played)
+		sort.Slice(p.ByType, func(i, j int) bool { return matchupTypeOrder(p.ByType[i].Type) < matchupTypeOrder(p.ByType[j].Type) })
+		partnerships = append(partnerships, *p)
+	}
+	sort.SliceStable(partnerships, func(i, j int) bool {
+		if partnerships[i].Played != partnerships[j].Played {
+			return partnerships[i].Played > partnerships[j].Played
+		}
+		if partnerships[i].WinPercent != partnerships[j].WinPercent {
+			return partnerships[i].WinPercent > partnerships[j].WinPercent
+		}
+		return partnerships[i].Player1Name+partnerships[i].Player2Name < partnerships[j].Player1Name+partnerships[j].Player2Name
+	})
+	return partnerships, nil
+}
+
+// add counts a rubber from the pair's side of the net
+func (p *Partnership) add(r partnershipRubber) {
+	ours, theirs := r.HomeScore, r.AwayScore
+	sets := [][2]*int{{r.HomeSet1, r.AwaySet1}, {r.HomeSet2, r.AwaySet2}, {r.HomeSet3, r.AwaySet3}}
+	if !r.IsHome {
+		ours, theirs = theirs, ours
+		for i := range sets {
+			sets[i][0], sets[i][1] = sets[i][1], sets[i][0]
+		}
+	}
+
+	var record *PartnershipTypeRecord
+	for i := range p.ByType {
+		if p.ByType[i].Type == r.Type {
+			record = &p.ByType[i]
+		}
+	}
+	if record == nil {
+		p.ByType = append(p.ByType, PartnershipTypeRecord{Type: r.Type})
+		record = &p.ByType[len(p.ByType)-1]
+	}
+
+	p.Played++
+	record.Played++
+	switch {
+	case ours > theirs:
+		p.Won++
+		record.Won++
+	case ours < theirs:
+		p.Lost++
+		record.Lost++
+	default:
+		p.Drawn++
+		record.Drawn++
+	}
+
+	for _, set := range sets {
+		if set[0] == nil || set[1] == nil {
+			continue
+		}
+		p.GamesWon += *set[0]
+		p.GamesLost += *set[1]
+		switch {
+		case *set[0] > *set[1]:
+			p.SetsWon++
+		case *set[0] < *set[1]:
+			p.SetsLost++
+		}
+	}
+}
+
+// PartnershipChemistry keys each pair's summary by PartnershipKey
+func PartnershipChemistry(partnerships []Partnership) map[string]PartnershipSummary {
+	chemistry := make(map[string]PartnershipSummary, len(partnerships))
+	for _, p := range partnerships {
+		chemistry[PartnershipKey(p.Player1ID, p.Player2ID)] = PartnershipSummary{
+			Played:     p.Played,
+			Won:        p.Won,
+			Drawn:      p.Drawn,
+			Lost:       p.Lost,
+			WinPercent: p.WinPercent,
+			SetsRatio:  p.SetsRatio(),
+			GamesRatio: p.GamesRatio(),
+			ByType:     p.ByType,
+		}
+	}
+	return chemistry
+}
+
+// PartnershipsAmong returns the partnerships between the given players,
+// best win rate first, for weighing up pairings from a selected squad
+func PartnershipsAmong(partnerships []Partnership, playerIDs []string) []Partnership {
+	ids := make(map[string]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		ids[id] = true
+	}
+	var among []Partnership
+	for _, p := range partnerships {
+		if ids[p.Player1ID] && ids[p.Player2ID] {
+			among = append(among, p)
+		}
+	}
+	sort.SliceStable(among, func(i, j int) bool {
+		if among[i].WinPercent != among[j].WinPercent {
+			return among[i].WinPercent > among[j].WinPercent
+		}
+		return among[i].Played > among[j].Played
+	})
+	return among
+}
+
+// BuildPartnershipMatrix puts the players who have played most doubles
+// together first
+func BuildPartnershipMatrix(partnerships []Partnership) PartnershipMatrix {
+	byID := make(map[string]*PartnershipPlayer)
+	for _, p := range partnerships {
+		for _, player := range []PartnershipPlayer{{ID: p.Player1ID, Name: p.Player1Name}, {ID: p.Player2ID, Name: p.Player2Name}} {
+			if byID[player.ID] == nil {
+				player := player
+				byID[player.ID] = &player
+			}
+			byID[player.ID].Played += p.Played
+		}
+	}
+
+	var matrix PartnershipMatrix
+	for _, player := range byID {
+		matrix.Players = append(matrix.Players, *player)
+	}
+	sort.Slice(matrix.Players, func(i, j int) bool {
+		if matrix.Players[i].Played != matrix.Players[j].Played {
+			return matrix.Players[i].Played > matrix.Players[j].Played
+		}
+		return matrix.Players[i].Name < matrix.Players[j].Name
+	})
+
+	index := make(map[string]int, len(matrix.Players))
+	for i, player := range matrix.Players {
+		index[player.ID] = i
+	}
+	matrix.Cells = make([][]*Partnership, len(matrix.Players))
+	for i := range matrix.Cells {
+		matrix.Cells[i] = make([]*Partnership, len(matrix.Players))
+	}
+	for i := range partnerships {
+		a, b := index[partnerships[i].Player1ID], index[partnerships[i].Player2ID]
+		matrix.Cells[a][b] = &partnerships[i]
+		matrix.Cells[b][a] = &partnerships[i]
+	}
+	return matrix
+}
+
+// matchupTypeOrder sorts matchup types the way a match card lists them
+func matchupTypeOrder(t models.MatchupType) int {
+	switch t {
+	case models.FirstMixed:
+		return 0
+	case models.SecondMixed:
+		return 1
+	case models.Mens:
+		return 2
+	case models.Womens:
+		return 3
+	}
+	return 4
+}
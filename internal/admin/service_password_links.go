@@ -0,0 +1,89 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/models"
+)
+
+// PasswordLinkResult describes an invitation or reset link an admin just
+// issued. Path is shown to the admin once so it can be passed on by hand
+// when it couldn't be emailed.
+type PasswordLinkResult struct {
+	Username  string
+	Path      string
+	ExpiresAt time.Time
+	Invite    bool
+	Emailed   bool
+}
+
+// InviteUser creates a user with no usable password and issues them an
+// invitation link to choose their own
+func (s *Service) InviteUser(ctx context.Context, username, email string, role models.Role, invitedBy string) (*PasswordLinkResult, error) {
+	// The account gets a random password nobody knows until the invite is used
+	placeholder := make([]byte, 32)
+	if _, err := rand.Read(placeholder); err != nil {
+		return nil, fmt.Errorf("failed to generate placeholder password: %w", err)
+	}
+	userID, err := s.CreateUser(username, email, base64.RawURLEncoding.EncodeToString(placeholder), role)
+	if err != nil {
+		return nil, err
+	}
+	return s.issuePasswordLink(ctx, userID, auth.PasswordTokenInvite, invitedBy)
+}
+
+// IssuePasswordResetLink issues a reset link for an existing user. Users
+// who haven't accepted their invitation yet get a fresh invitation instead.
+func (s *Service) IssuePasswordResetLink(ctx context.Context, userID int64, issuedBy string) (*PasswordLinkResult, error) {
+	pending, err := s.passwordTokens.PendingInvites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	purpose := auth.PasswordTokenReset
+	if _, invited := pending[userID]; invited {
+		purpose = auth.PasswordTokenInvite
+	}
+	return s.issuePasswordLink(ctx, userID, purpose, issuedBy)
+}
+
+// GetPendingInvites returns when each outstanding invitation expires, by user ID
+func (s *Service) GetPendingInvites(ctx context.Context) (map[int64]time.Time, error) {
+	return s.passwordTokens.PendingInvites(ctx)
+}
+
+// issuePasswordLink issues a token and tries to deliver it, falling back to
+// showing the link to the admin
+func (s *Service) issuePasswordLink(ctx context.Context, userID int64, purpose auth.PasswordTokenPurpose, issuedBy string) (*PasswordLinkResult, error) {
+	var user models.User
+	if err := s.db.GetContext(ctx, &user, `
+		SELECT id, username, email, password_hash, role, player_id, is_active, created_at, last_login_at
+		FROM users WHERE id = ?
+	`, userID); err != nil {
+		return nil, err
+	}
+
+	path, expires, err := s.passwordTokens.Issue(ctx, user.ID, purpose, issuedBy)
+	if err != nil {
+		return nil, err
+	}
+	emailed, err := s.passwordTokens.Deliver(ctx, user, purpose, path, expires)
+	if err != nil {
+		log.Printf("Failed to email %s link to %s, showing it to the admin instead: %v", purpose, user.Username, err)
+	}
+
+	return &PasswordLinkResult{
+		Username:  user.Username,
+		Path:      path,
+		ExpiresAt: expires,
+		Invite:    purpose == auth.PasswordTokenInvite,
+		Emailed:   emailed,
+	}, nil
+}
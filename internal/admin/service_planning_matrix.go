@@ -27,6 +27,8 @@ type MatrixColumn struct {
 	PerspectiveTeamID uint
 	TeamName          string
 	OpponentName      string
+	OpponentTeamID    uint
+	OpponentIsOurs    bool // derby: the opponent is another home-club team
 	DivisionName      string
 	IsHome            bool // is PerspectiveTeamID playing at home in Fixture?
 	Key               string
@@ -122,6 +124,8 @@ func (s *Service) ResolveAvailabilityMatrix(ctx context.Context, scope *Resolved
 				PerspectiveTeamID: f.HomeTeamID,
 				TeamName:          homeName,
 				OpponentName:      awayName,
+				OpponentTeamID:    f.AwayTeamID,
+				OpponentIsOurs:    awayInScope || (away != nil && away.ClubID == s.homeClubID),
 				DivisionName:      divName,
 				IsHome:            true,
 				Key:               fmt.Sprintf("%d-%d", f.ID, f.HomeTeamID),
@@ -133,6 +137,8 @@ func (s *Service) ResolveAvailabilityMatrix(ctx context.Context, scope *Resolved
 				PerspectiveTeamID: f.AwayTeamID,
 				TeamName:          awayName,
 				OpponentName:      homeName,
+				OpponentTeamID:    f.HomeTeamID,
+				OpponentIsOurs:    homeInScope || (home != nil && home.ClubID == s.homeClubID),
 				DivisionName:      divName,
 				IsHome:            false,
 				Key:               fmt.Sprintf("%d-%d", f.ID, f.AwayTeamID),
@@ -325,37 +331,11 @@ func (s *Service) ResolveAvailabilityMatrix(ctx context.Context, scope *Resolved
 	return matrix, nil
 }
 
-// resolveCell picks the most specific availability signal for (player, fixture):
-//  1. A fixture-specific override, if any
-//  2. A date-range exception covering the scheduled date
-//  3. The player's general availability for that day of week
-//
-// Missing → Unknown.
+// resolveCell returns a player's availability for a fixture as a matrix
+// cell, with the usual precedence (see resolveFixtureAvailability)
 func resolveCell(ctx context.Context, s *Service, playerID string, fixture *models.Fixture) MatrixCell {
-	cell := MatrixCell{FixtureID: fixture.ID, Status: models.Unknown}
-
-	if f, err := s.availabilityRepository.GetPlayerFixtureAvailability(ctx, playerID, fixture.ID); err == nil && f != nil {
-		cell.Status = f.Status
-		cell.Reason = f.Notes
-		return cell
-	}
-	if e, err := s.availabilityRepository.GetPlayerAvailabilityByDate(ctx, playerID, fixture.ScheduledDate); err == nil && e != nil {
-		cell.Status = e.Status
-		cell.Reason = e.Reason
-		return cell
-	}
-	general, err := s.availabilityRepository.GetPlayerGeneralAvailability(ctx, playerID, fixture.SeasonID)
-	if err == nil {
-		dayName := fixture.ScheduledDate.Weekday().String()
-		for _, g := range general {
-			if g.DayOfWeek == dayName {
-				cell.Status = g.Status
-				cell.Reason = g.Notes
-				return cell
-			}
-		}
-	}
-	return cell
+	info := s.resolveFixtureAvailability(ctx, fixture, []string{playerID})[playerID]
+	return MatrixCell{FixtureID: fixture.ID, Status: info.Status, Reason: info.Notes}
 }
 
 // selectionColumnKey picks the right column for a fixture_players row: in
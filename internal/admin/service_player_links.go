@@ -0,0 +1,93 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// linkGraceOptions are the grace periods offered when rotating a player's
+// link, in days. Zero revokes the old link immediately.
+var linkGraceOptions = []int{0, 1, 7}
+
+// RetiredPlayerLink is a rotated-out link shown in the player's link history
+type RetiredPlayerLink struct {
+	models.RetiredPlayerToken
+	Redirecting bool // Still within its grace period
+}
+
+// PlayerLinkDevice is one device that has opened one of a player's links
+type PlayerLinkDevice struct {
+	models.PlayerTokenAccess
+	Device  string
+	Current bool // Seen on the current link
+}
+
+// PlayerLinkActivity is the link history and access log for a player
+type PlayerLinkActivity struct {
+	Retired      []RetiredPlayerLink
+	Devices      []PlayerLinkDevice
+	GraceOptions []int
+}
+
+// GetPlayerLinkActivity loads a player's retired links and the devices that
+// have used their current and past links
+func (s *Service) GetPlayerLinkActivity(ctx context.Context, playerID, currentToken string) (*PlayerLinkActivity, error) {
+	retired, err := s.playerTokenRepository.FindRetiredByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	accesses, err := s.playerTokenRepository.FindAccessesByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	activity := &PlayerLinkActivity{GraceOptions: linkGraceOptions}
+	for _, t := range retired {
+		activity.Retired = append(activity.Retired, RetiredPlayerLink{RetiredPlayerToken: t, Redirecting: t.InGrace(now)})
+	}
+	for _, a := range accesses {
+		activity.Devices = append(activity.Devices, PlayerLinkDevice{
+			PlayerTokenAccess: a,
+			Device:            services.DescribeDevice(a.UserAgent),
+			Current:           a.Token == currentToken,
+		})
+	}
+	return activity, nil
+}
+
+// RotatePlayerLink issues a player a new link. The old link redirects to the
+// new one for graceDays, or stops working at once when graceDays is zero.
+func (s *Service) RotatePlayerLink(ctx context.Context, playerID string, graceDays int, reason, rotatedBy string) (*models.FantasyMixedDoubles, error) {
+	valid := false
+	for _, d := range linkGraceOptions {
+		valid = valid || d == graceDays
+	}
+	if !valid {
+		return nil, fmt.Errorf("unsupported grace period: %d days", graceDays)
+	}
+
+	rotator := services.NewPlayerLinkRotator(s.db, s.playerRepository, s.fantasyRepository, s.playerTokenRepository, s.pushService)
+	return rotator.Rotate(ctx, playerID, time.Duration(graceDays)*24*time.Hour, reason, rotatedBy, "")
+}
+
+// RevokeRetiredPlayerLink stops a retired link of this player redirecting
+func (s *Service) RevokeRetiredPlayerLink(ctx context.Context, playerID string, retiredID uint) error {
+	retired, err := s.playerTokenRepository.FindRetiredByPlayer(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	for _, t := range retired {
+		if t.ID == retiredID {
+			rotator := services.NewPlayerLinkRotator(s.db, s.playerRepository, s.fantasyRepository, s.playerTokenRepository, s.pushService)
+			return rotator.Revoke(ctx, retiredID)
+		}
+	}
+	return fmt.Errorf("retired link %d does not belong to player %s", retiredID, playerID)
+}
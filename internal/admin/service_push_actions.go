@@ -0,0 +1,72 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/models"
+)
+
+// notificationTypeAvailabilityReminder labels every availability reminder
+// push, with or without action buttons, for delivery tracking and metrics
+const notificationTypeAvailabilityReminder = "availability-reminder"
+
+// maxAvailabilityActionLifetime caps how long an availability action link
+// stays valid when the fixture itself is further away than this
+const maxAvailabilityActionLifetime = 14 * 24 * time.Hour
+
+// availabilityActions are the push notification buttons offered on an
+// availability reminder, in display order. Some platforms only show the
+// first two, so the most common answers come first.
+var availabilityActions = []struct {
+	Action string
+	Title  string
+	Status models.AvailabilityStatus
+}{
+	{"available", "Available", models.Available},
+	{"unavailable", "Unavailable", models.Unavailable},
+	{"if-needed", "If needed", models.IfNeeded},
+}
+
+// availabilityReminderPayload builds an availability reminder push with one
+// action button per answer. Each button carries its own signed link that
+// records exactly that answer for this player and fixture, so the player can
+// respond without opening the app. The links are bound to the player's
+// current link token, so rotating or revoking it retires them too.
+func (s *Service) availabilityReminderPayload(player models.Player, token string, fixture *models.Fixture, body string) (map[string]interface{}, error) {
+	expires := fixture.ScheduledDate
+	if limit := time.Now().Add(maxAvailabilityActionLifetime); expires.After(limit) {
+		expires = limit
+	}
+
+	data := map[string]string{
+		"type": notificationTypeAvailabilityReminder,
+		"url":  s.linkSigner.NotificationLink(player.ID, token, fmt.Sprintf("/fixture/%d", fixture.ID)),
+	}
+	actions := make([]map[string]string, 0, len(availabilityActions))
+	for _, a := range availabilityActions {
+		signed, err := s.linkSigner.Sign(auth.LinkClaims{
+			Purpose:   auth.LinkPurposeAvailabilityAction,
+			Subject:   player.ID,
+			Resource:  fmt.Sprintf("%d", fixture.ID),
+			Action:    string(a.Status),
+			Token:     auth.TokenFingerprint(token),
+			ExpiresAt: expires.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		data["action_"+a.Action] = "/push-action/availability/" + signed
+		actions = append(actions, map[string]string{"action": a.Action, "title": a.Title})
+	}
+
+	return map[string]interface{}{
+		"title":   "Availability Reminder",
+		"body":    body,
+		"actions": actions,
+		"data":    data,
+	}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/webpush"
+)
+
+// ReachabilityStatus is a one-word verdict on whether push reaches a player
+type ReachabilityStatus string
+
+const (
+	ReachabilityReachable    ReachabilityStatus = "Reachable"  // Subscribed and the last delivery got through
+	ReachabilityFailing      ReachabilityStatus = "Failing"    // Subscribed but recent deliveries are failing
+	ReachabilityUntested     ReachabilityStatus = "Untested"   // Subscribed, nothing sent in the window
+	ReachabilityUnsubscribed ReachabilityStatus = "No devices" // No push subscription at all
+)
+
+// PlayerReachability is one row of the push reachability report
+type PlayerReachability struct {
+	Player models.Player
+	Status ReachabilityStatus
+	Stats  webpush.Reachability
+}
+
+// reachabilityOrder sorts problems to the top of the report
+var reachabilityOrder = map[ReachabilityStatus]int{
+	ReachabilityFailing:      0,
+	ReachabilityUnsubscribed: 1,
+	ReachabilityUntested:     2,
+	ReachabilityReachable:    3,
+}
+
+// GetPushReachability reports, for every active player, whether push
+// notifications are reaching them based on deliveries in the last `days` days
+func (s *Service) GetPushReachability(ctx context.Context, days int) ([]PlayerReachability, error) {
+	if s.pushService == nil {
+		return nil, fmt.Errorf("push notifications not configured")
+	}
+
+	stats, err := s.pushService.GetReachability(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	players, err := s.playerRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]PlayerReachability, 0, len(players))
+	for _, player := range players {
+		row := PlayerReachability{Player: player}
+		if token := s.getPlayerFantasyToken(ctx, player); token != "" {
+			if st, ok := stats[token]; ok {
+				row.Stats = *st
+			}
+		}
+		row.Status = reachabilityStatus(row.Stats)
+		report = append(report, row)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		oi, oj := reachabilityOrder[report[i].Status], reachabilityOrder[report[j].Status]
+		if oi != oj {
+			return oi < oj
+		}
+		if report[i].Player.LastName != report[j].Player.LastName {
+			return report[i].Player.LastName < report[j].Player.LastName
+		}
+		return report[i].Player.FirstName < report[j].Player.FirstName
+	})
+	return report, nil
+}
+
+// GetPlayerPushDeliveries returns a player's most recent push delivery attempts
+func (s *Service) GetPlayerPushDeliveries(ctx context.Context, playerID string, limit int) (*models.Player, []webpush.Delivery, error) {
+	if s.pushService == nil {
+		return nil, nil, fmt.Errorf("push notifications not configured")
+	}
+
+	player, err := s.playerRepository.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	token := s.getPlayerFantasyToken(ctx, *player)
+	if token == "" {
+		return player, nil, nil
+	}
+	deliveries, err := s.pushService.GetDeliveriesByPlayerToken(token, limit)
+	return player, deliveries, err
+}
+
+// reachabilityStatus judges a player's delivery stats. A player counts as
+// failing when their most recent attempt came after their last success.
+func reachabilityStatus(st webpush.Reachability) ReachabilityStatus {
+	switch {
+	case st.Subscriptions == 0 && st.Delivered == 0:
+		return ReachabilityUnsubscribed
+	case st.Attempts == 0:
+		return ReachabilityUntested
+	case st.LastDeliveredAt == nil:
+		return ReachabilityFailing
+	case st.Subscriptions == 0:
+		// Everything they had has since been removed as dead
+		return ReachabilityUnsubscribed
+	case st.LastAttemptAt.After(*st.LastDeliveredAt) && st.Failed > 0:
+		return ReachabilityFailing
+	}
+	return ReachabilityReachable
+}
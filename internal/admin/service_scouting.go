@@ -0,0 +1,393 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// ScoutingReport is what captains know about an opposition team from the
+// match cards we've imported: who they field, who plays with whom, and how
+// we've got on against them. A team keeps its name from season to season,
+// so past meetings are found through earlier seasons' teams of the same name
+// at the same club.
+type ScoutingReport struct {
+	Team         *models.Team
+	ClubName     string
+	DivisionName string
+	SeasonName   string
+	Record       ScoutingRecord
+	Meetings     []ScoutingMeeting
+	Players      []ScoutedPlayer
+	Pairings     []ScoutedPairing
+	// CardedFixtures is how many of the team's fixtures this season have a
+	// line-up recorded; turnout is measured against it
+	CardedFixtures int
+}
+
+// ScoutingRecord is our head-to-head record against the team
+type ScoutingRecord struct {
+	Played      int
+	Won         int
+	Drawn       int
+	Lost        int
+	RubbersWon  int
+	RubbersLost int
+}
+
+// ScoutingMeeting is one fixture between one of our teams and theirs
+type ScoutingMeeting struct {
+	FixtureID    uint
+	Date         time.Time
+	SeasonName   string
+	OurTeamName  string
+	AtHome       bool // whether we were the home team
+	OurRubbers   int
+	TheirRubbers int
+	Result       string // W, D or L; empty until results are in
+}
+
+// ScoutedPlayer is one opposition player and how they've done
+type ScoutedPlayer struct {
+	PlayerID       string
+	Name           string
+	ThisSeason     int // fixtures played for the team this season
+	PastMeetings   int // fixtures played against us in earlier seasons
+	TurnoutPercent int // share of this season's carded fixtures they played in
+	Rubbers        int
+	Won            int
+	Lost           int
+	Partners       []string // most frequent first
+}
+
+// ScoutedPairing is a pair the team has put out together
+type ScoutedPairing struct {
+	Names      string
+	Types      []models.MatchupType
+	Played     int
+	Won        int
+	Lost       int
+	LastPlayed time.Time
+}
+
+// scoutingFixture is a fixture involving the scouted team
+type scoutingFixture struct {
+	ID            uint      `db:"id"`
+	SeasonID      uint      `db:"season_id"`
+	SeasonName    string    `db:"season_name"`
+	ScheduledDate time.Time `db:"scheduled_date"`
+	HomeTeamID    uint      `db:"home_team_id"`
+	AwayTeamID    uint      `db:"away_team_id"`
+	HomeTeamName  string    `db:"home_team_name"`
+	AwayTeamName  string    `db:"away_team_name"`
+	HomeClubID    uint      `db:"home_club_id"`
+	AwayClubID    uint      `db:"away_club_id"`
+}
+
+type scoutingMatchup struct {
+	ID        uint               `db:"id"`
+	FixtureID uint               `db:"fixture_id"`
+	Type      models.MatchupType `db:"type"`
+	HomeScore int                `db:"home_score"`
+	AwayScore int                `db:"away_score"`
+}
+
+type scoutingLineup struct {
+	MatchupID uint   `db:"matchup_id"`
+	PlayerID  string `db:"player_id"`
+	IsHome    bool   `db:"is_home"`
+	Name      string `db:"name"`
+}
+
+// GetScoutingReport builds the scouting report for an opposition team
+func (s *Service) GetScoutingReport(ctx context.Context, teamID uint) (*ScoutingReport, error) {
+	team, err := s.teamRepository.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team %d not found: %w", teamID, err)
+	}
+	if team.ClubID == s.homeClubID {
+		return nil, fmt.Errorf("%s is one of our teams; scouting reports are for the opposition", team.Name)
+	}
+
+	report := &ScoutingReport{Team: team}
+	if club, err := s.clubRepository.FindByID(ctx, team.ClubID); err == nil {
+		report.ClubName = club.Name
+	}
+	if division, err := s.divisionRepository.FindByID(ctx, team.DivisionID); err == nil {
+		report.DivisionName = division.Name
+	}
+	if season, err := s.seasonRepository.FindByID(ctx, team.SeasonID); err == nil {
+		report.SeasonName = season.Name
+	}
+
+	// This season's fixtures show who they field; earlier seasons only count
+	// where they played us
+	var fixtures []scoutingFixture
+	err = s.db.SelectContext(ctx, &fixtures, `
+		SELECT f.id, f.season_id, s.name AS season_name, f.scheduled_date,
+			f.home_team_id, f.away_team_id, ht.name AS home_team_name, at.name AS away_team_name,
+			ht.club_id AS home_club_id, at.club_id AS away_club_id
+		FROM fixtures f
+		JOIN seasons s ON s.id = f.season_id
+		JOIN teams ht ON ht.id = f.home_team_id
+		JOIN teams at ON at.id = f.away_team_id
+		WHERE ((ht.name = ? AND ht.club_id = ?) OR (at.name = ? AND at.club_id = ?))
+		AND (f.season_id = ? OR ht.club_id = ? OR at.club_id = ?)
+		ORDER BY f.scheduled_date DESC, f.id DESC
+	`, team.Name, team.ClubID, team.Name, team.ClubID, team.SeasonID, s.homeClubID, s.homeClubID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	if len(fixtures) == 0 {
+		return report, nil
+	}
+
+	fixtureIDs := make([]uint, len(fixtures))
+	for i, f := range fixtures {
+		fixtureIDs[i] = f.ID
+	}
+	query, args, err := sqlx.In(`
+		SELECT id, fixture_id, type, home_score, away_score
+		FROM matchups
+		WHERE fixture_id IN (?) AND status = 'Finished'
+	`, fixtureIDs)
+	if err != nil {
+		return nil, err
+	}
+	var matchups []scoutingMatchup
+	if err := s.db.SelectContext(ctx, &matchups, s.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to load matchups: %w", err)
+	}
+
+	lineups := map[uint][]scoutingLineup{}
+	if len(matchups) > 0 {
+		matchupIDs := make([]uint, len(matchups))
+		for i, m := range matchups {
+			matchupIDs[i] = m.ID
+		}
+		query, args, err := sqlx.In(`
+			SELECT mp.matchup_id, mp.player_id, mp.is_home,
+				p.first_name || ' ' || p.last_name AS name
+			FROM matchup_players mp
+			JOIN players p ON p.id = mp.player_id
+			WHERE mp.matchup_id IN (?)
+			ORDER BY name ASC
+		`, matchupIDs)
+		if err != nil {
+			return nil, err
+		}
+		var rows []scoutingLineup
+		if err := s.db.SelectContext(ctx, &rows, s.db.Rebind(query), args...); err != nil {
+			return nil, fmt.Errorf("failed to load line-ups: %w", err)
+		}
+		for _, row := range rows {
+			lineups[row.MatchupID] = append(lineups[row.MatchupID], row)
+		}
+	}
+
+	matchupsByFixture := map[uint][]scoutingMatchup{}
+	for _, m := range matchups {
+		matchupsByFixture[m.FixtureID] = append(matchupsByFixture[m.FixtureID], m)
+	}
+	newScoutingTally(report).add(fixtures, matchupsByFixture, lineups, team, s.homeClubID)
+	return report, nil
+}
+
+// scoutingTally accumulates a report's players and pairings
+type scoutingTally struct {
+	report   *ScoutingReport
+	players  map[string]*ScoutedPlayer
+	partners map[string]map[string]int
+	pairings map[string]*ScoutedPairing
+}
+
+func newScoutingTally(report *ScoutingReport) *scoutingTally {
+	return &scoutingTally{
+		report:   report,
+		players:  map[string]*ScoutedPlayer{},
+		partners: map[string]map[string]int{},
+		pairings: map[string]*ScoutedPairing{},
+	}
+}
+
+func (t *scoutingTally) add(fixtures []scoutingFixture, matchups map[uint][]scoutingMatchup, lineups map[uint][]scoutingLineup, team *models.Team, homeClubID uint) {
+	for _, f := range fixtures {
+		// theirHome is whether the scouted team was at home
+		theirHome := f.HomeTeamName == team.Name && f.HomeClubID == team.ClubID
+		againstUs := (theirHome && f.AwayClubID == homeClubID) || (!theirHome && f.HomeClubID == homeClubID)
+		thisSeason := f.SeasonID == team.SeasonID
+
+		theirRubbers, ourRubbers, theirPoints, ourPoints := 0, 0, 0, 0
+		played := map[string]bool{}
+		for _, m := range matchups[f.ID] {
+			theirScore, otherScore := m.HomeScore, m.AwayScore
+			if !theirHome {
+				theirScore, otherScore = otherScore, theirScore
+			}
+			theirPoints += theirScore
+			ourPoints += otherScore
+			if theirScore > otherScore {
+				theirRubbers++
+			} else if otherScore > theirScore {
+				ourRubbers++
+			}
+
+			var pair []scoutingLineup
+			for _, p := range lineups[m.ID] {
+				if p.IsHome == theirHome {
+					pair = append(pair, p)
+				}
+			}
+			for _, p := range pair {
+				played[p.PlayerID] = true
+				player := t.player(p)
+				player.Rubbers++
+				if theirScore > otherScore {
+					player.Won++
+				} else if otherScore > theirScore {
+					player.Lost++
+				}
+			}
+			if len(pair) == 2 {
+				t.addPairing(pair, m.Type, f.ScheduledDate, theirScore, otherScore)
+			}
+		}
+
+		if thisSeason && len(played) > 0 {
+			t.report.CardedFixtures++
+		}
+		for playerID := range played {
+			if thisSeason {
+				t.players[playerID].ThisSeason++
+			} else {
+				t.players[playerID].PastMeetings++
+			}
+		}
+
+		if !againstUs {
+			continue
+		}
+		meeting := ScoutingMeeting{
+			FixtureID:    f.ID,
+			Date:         f.ScheduledDate,
+			SeasonName:   f.SeasonName,
+			OurTeamName:  f.HomeTeamName,
+			AtHome:       !theirHome,
+			OurRubbers:   ourRubbers,
+			TheirRubbers: theirRubbers,
+		}
+		if theirHome {
+			meeting.OurTeamName = f.AwayTeamName
+		}
+		if len(matchups[f.ID]) > 0 {
+			record := &t.report.Record
+			record.Played++
+			record.RubbersWon += ourRubbers
+			record.RubbersLost += theirRubbers
+			switch {
+			case ourPoints > theirPoints:
+				meeting.Result = "W"
+				record.Won++
+			case ourPoints < theirPoints:
+				meeting.Result = "L"
+				record.Lost++
+			default:
+				meeting.Result = "D"
+				record.Drawn++
+			}
+		}
+		t.report.Meetings = append(t.report.Meetings, meeting)
+	}
+	t.finish()
+}
+
+func (t *scoutingTally) player(p scoutingLineup) *ScoutedPlayer {
+	player, ok := t.players[p.PlayerID]
+	if !ok {
+		player = &ScoutedPlayer{PlayerID: p.PlayerID, Name: p.Name}
+		t.players[p.PlayerID] = player
+		t.partners[p.PlayerID] = map[string]int{}
+	}
+	return player
+}
+
+func (t *scoutingTally) addPairing(pair []scoutingLineup, matchupType models.MatchupType, date time.Time, theirScore, otherScore int) {
+	t.partners[pair[0].PlayerID][pair[1].Name]++
+	t.partners[pair[1].PlayerID][pair[0].Name]++
+
+	key := pair[0].PlayerID + "|" + pair[1].PlayerID
+	if pair[1].PlayerID < pair[0].PlayerID {
+		key = pair[1].PlayerID + "|" + pair[0].PlayerID
+	}
+	pairing, ok := t.pairings[key]
+	if !ok {
+		pairing = &ScoutedPairing{Names: pair[0].Name + " & " + pair[1].Name}
+		t.pairings[key] = pairing
+	}
+	pairing.Played++
+	if theirScore > otherScore {
+		pairing.Won++
+	} else if otherScore > theirScore {
+		pairing.Lost++
+	}
+	if date.After(pairing.LastPlayed) {
+		pairing.LastPlayed = date
+	}
+	for _, existing := range pairing.Types {
+		if existing == matchupType {
+			return
+		}
+	}
+	pairing.Types = append(pairing.Types, matchupType)
+}
+
+// finish orders the players by how often they turn out and the pairings by
+// how often they play together
+func (t *scoutingTally) finish() {
+	for id, player := range t.players {
+		if t.report.CardedFixtures > 0 {
+			player.TurnoutPercent = player.ThisSeason * 100 / t.report.CardedFixtures
+		}
+		partners := t.partners[id]
+		for name := range partners {
+			player.Partners = append(player.Partners, name)
+		}
+		sort.Slice(player.Partners, func(i, j int) bool {
+			a, b := player.Partners[i], player.Partners[j]
+			if partners[a] != partners[b] {
+				return partners[a] > partners[b]
+			}
+			return a < b
+		})
+		t.report.Players = append(t.report.Players, *player)
+	}
+	sort.Slice(t.report.Players, func(i, j int) bool {
+		a, b := t.report.Players[i], t.report.Players[j]
+		if a.ThisSeason != b.ThisSeason {
+			return a.ThisSeason > b.ThisSeason
+		}
+		if a.PastMeetings != b.PastMeetings {
+			return a.PastMeetings > b.PastMeetings
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+
+	for _, pairing := range t.pairings {
+		t.report.Pairings = append(t.report.Pairings, *pairing)
+	}
+	sort.Slice(t.report.Pairings, func(i, j int) bool {
+		a, b := t.report.Pairings[i], t.report.Pairings[j]
+		if a.Played != b.Played {
+			return a.Played > b.Played
+		}
+		return a.LastPlayed.After(b.LastPlayed)
+	})
+}
@@ -57,79 +57,86 @@ func (s *Service) GetActiveSeason() (*models.Season, error) {
 	return s.seasonRepository.FindActive(ctx)
 }
 
-// CreateSeasonWithWeeks creates a season and automatically generates weeks for it
+// CreateSeasonWithWeeks creates a season and automatically generates weeks for it.
+// The season and its weeks are created together or not at all.
 func (s *Service) CreateSeasonWithWeeks(season *models.Season, numWeeks int) error {
-	ctx := context.Background()
+	err := s.db.WithTx(context.Background(), func(ctx context.Context) error {
+		// Create the season first
+		if err := s.seasonRepository.Create(ctx, season); err != nil {
+			return fmt.Errorf("failed to create season: %w", err)
+		}
 
-	// Create the season first
-	if err := s.seasonRepository.Create(ctx, season); err != nil {
-		return fmt.Errorf("failed to create season: %w", err)
-	}
+		// Calculate the duration of each week
+		totalDays := season.EndDate.Sub(season.StartDate).Hours() / 24
+		daysPerWeek := totalDays / float64(numWeeks)
 
-	// Calculate the duration of each week
-	totalDays := season.EndDate.Sub(season.StartDate).Hours() / 24
-	daysPerWeek := totalDays / float64(numWeeks)
+		// Create weeks
+		for i := 1; i <= numWeeks; i++ {
+			weekStart := season.StartDate.AddDate(0, 0, int(float64(i-1)*daysPerWeek))
+			weekEnd := season.StartDate.AddDate(0, 0, int(float64(i)*daysPerWeek)-1)
 
-	// Create weeks
-	for i := 1; i <= numWeeks; i++ {
-		weekStart := season.StartDate.AddDate(0, 0, int(float64(i-1)*daysPerWeek))
-		weekEnd := season.StartDate.AddDate(0, 0, int(float64(i)*daysPerWeek)-1)
+			// For the last week, use the season end date
+			if i == numWeeks {
+				weekEnd = season.EndDate
+			}
 
-		// For the last week, use the season end date
-		if i == numWeeks {
-			weekEnd = season.EndDate
-		}
+			week := &models.Week{
+				WeekNumber: i,
+				SeasonID:   season.ID,
+				StartDate:  weekStart,
+				EndDate:    weekEnd,
+				Name:       fmt.Sprintf("Week %d", i),
+				IsActive:   false,
+			}
 
-		week := &models.Week{
-			WeekNumber: i,
-			SeasonID:   season.ID,
-			StartDate:  weekStart,
-			EndDate:    weekEnd,
-			Name:       fmt.Sprintf("Week %d", i),
-			IsActive:   false,
+			if err := s.weekRepository.Create(ctx, week); err != nil {
+				log.Printf("Failed to create week %d for season %d: %v", i, season.ID, err)
+				return fmt.Errorf("failed to create week %d: %w", i, err)
+			}
 		}
 
-		if err := s.weekRepository.Create(ctx, week); err != nil {
-			log.Printf("Failed to create week %d for season %d: %v", i, season.ID, err)
-			return fmt.Errorf("failed to create week %d: %w", i, err)
-		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	log.Printf("Successfully created season '%s' with %d weeks", season.Name, numWeeks)
 	return nil
 }
 
-// SetActiveSeason sets a season as active and deactivates all others
+// SetActiveSeason sets a season as active and deactivates all others, in one
+// transaction so there is never a moment with no active season
 func (s *Service) SetActiveSeason(seasonID uint) error {
-	ctx := context.Background()
-
-	// Deactivate all seasons first
-	seasons, err := s.seasonRepository.FindAll(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get all seasons: %w", err)
-	}
+	return s.db.WithTx(context.Background(), func(ctx context.Context) error {
+		// Deactivate all seasons first
+		seasons, err := s.seasonRepository.FindAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get all seasons: %w", err)
+		}
 
-	for _, season := range seasons {
-		if season.IsActive {
-			season.IsActive = false
-			if err := s.seasonRepository.Update(ctx, &season); err != nil {
-				return fmt.Errorf("failed to deactivate season %d: %w", season.ID, err)
+		for _, season := range seasons {
+			if season.IsActive {
+				season.IsActive = false
+				if err := s.seasonRepository.Update(ctx, &season); err != nil {
+					return fmt.Errorf("failed to deactivate season %d: %w", season.ID, err)
+				}
 			}
 		}
-	}
 
-	// Activate the specified season
-	season, err := s.seasonRepository.FindByID(ctx, seasonID)
-	if err != nil {
-		return fmt.Errorf("failed to find season %d: %w", seasonID, err)
-	}
+		// Activate the specified season
+		season, err := s.seasonRepository.FindByID(ctx, seasonID)
+		if err != nil {
+			return fmt.Errorf("failed to find season %d: %w", seasonID, err)
+		}
 
-	season.IsActive = true
-	if err := s.seasonRepository.Update(ctx, season); err != nil {
-		return fmt.Errorf("failed to activate season %d: %w", seasonID, err)
-	}
+		season.IsActive = true
+		if err := s.seasonRepository.Update(ctx, season); err != nil {
+			return fmt.Errorf("failed to activate season %d: %w", seasonID, err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetWeeksBySeason retrieves weeks for a specific season
@@ -301,133 +308,134 @@ func (s *Service) MoveTeamToDivision(teamID uint, targetDivisionID uint) error {
 	return s.teamRepository.UpdateDivision(ctx, teamID, targetDivisionID)
 }
 
-// CopyFromPreviousSeason copies divisions and/or teams from the previous season to the target season
+// CopyFromPreviousSeason copies divisions and/or teams from the previous season to the target season.
+// If any division or team fails to copy, nothing is copied.
 func (s *Service) CopyFromPreviousSeason(targetSeasonID uint, copyDivisions, copyTeams bool) error {
-	ctx := context.Background()
-
-	// Get the target season
-	targetSeason, err := s.seasonRepository.FindByID(ctx, targetSeasonID)
-	if err != nil {
-		return fmt.Errorf("failed to find target season: %w", err)
-	}
-
-	// Find the previous season (by year)
-	previousYear := targetSeason.Year - 1
-	previousSeasons, err := s.seasonRepository.FindByYear(ctx, previousYear)
-	if err != nil || len(previousSeasons) == 0 {
-		return fmt.Errorf("no season found for year %d", previousYear)
-	}
-	previousSeason := previousSeasons[0]
-
-	// Map to track old division ID -> new division ID
-	divisionIDMap := make(map[uint]uint)
-
-	// Copy divisions if requested
-	if copyDivisions {
-		oldDivisions, err := s.divisionRepository.FindBySeason(ctx, previousSeason.ID)
+	return s.db.WithTx(context.Background(), func(ctx context.Context) error {
+		// Get the target season
+		targetSeason, err := s.seasonRepository.FindByID(ctx, targetSeasonID)
 		if err != nil {
-			return fmt.Errorf("failed to find divisions from previous season: %w", err)
+			return fmt.Errorf("failed to find target season: %w", err)
 		}
 
-		for _, oldDiv := range oldDivisions {
-			newDiv := &models.Division{
-				Name:            oldDiv.Name,
-				Level:           oldDiv.Level,
-				PlayDay:         oldDiv.PlayDay,
-				LeagueID:        oldDiv.LeagueID,
-				SeasonID:        targetSeasonID,
-				MaxTeamsPerClub: oldDiv.MaxTeamsPerClub,
-			}
-
-			if err := s.divisionRepository.Create(ctx, newDiv); err != nil {
-				return fmt.Errorf("failed to create division %s: %w", oldDiv.Name, err)
-			}
-
-			divisionIDMap[oldDiv.ID] = newDiv.ID
+		// Find the previous season (by year)
+		previousYear := targetSeason.Year - 1
+		previousSeasons, err := s.seasonRepository.FindByYear(ctx, previousYear)
+		if err != nil || len(previousSeasons) == 0 {
+			return fmt.Errorf("no season found for year %d", previousYear)
 		}
-	}
+		previousSeason := previousSeasons[0]
+
+		// Map to track old division ID -> new division ID
+		divisionIDMap := make(map[uint]uint)
 
-	// Copy teams if requested
-	if copyTeams {
-		// If divisions weren't copied, we need to build the division map
-		if !copyDivisions {
+		// Copy divisions if requested
+		if copyDivisions {
 			oldDivisions, err := s.divisionRepository.FindBySeason(ctx, previousSeason.ID)
 			if err != nil {
 				return fmt.Errorf("failed to find divisions from previous season: %w", err)
 			}
 
-			newDivisions, err := s.divisionRepository.FindBySeason(ctx, targetSeasonID)
-			if err != nil {
-				return fmt.Errorf("failed to find divisions in target season: %w", err)
-			}
-
-			// Map by name (assuming division names match)
-			newDivsByName := make(map[string]uint)
-			for _, div := range newDivisions {
-				newDivsByName[div.Name] = div.ID
-			}
-
 			for _, oldDiv := range oldDivisions {
-				if newDivID, ok := newDivsByName[oldDiv.Name]; ok {
-					divisionIDMap[oldDiv.ID] = newDivID
+				newDiv := &models.Division{
+					Name:            oldDiv.Name,
+					Level:           oldDiv.Level,
+					PlayDay:         oldDiv.PlayDay,
+					LeagueID:        oldDiv.LeagueID,
+					SeasonID:        targetSeasonID,
+					MaxTeamsPerClub: oldDiv.MaxTeamsPerClub,
+				}
+
+				if err := s.divisionRepository.Create(ctx, newDiv); err != nil {
+					return fmt.Errorf("failed to create division %s: %w", oldDiv.Name, err)
 				}
+
+				divisionIDMap[oldDiv.ID] = newDiv.ID
 			}
 		}
 
-		oldTeams, err := s.teamRepository.FindBySeason(ctx, previousSeason.ID)
-		if err != nil {
-			return fmt.Errorf("failed to find teams from previous season: %w", err)
-		}
+		// Copy teams if requested
+		if copyTeams {
+			// If divisions weren't copied, we need to build the division map
+			if !copyDivisions {
+				oldDivisions, err := s.divisionRepository.FindBySeason(ctx, previousSeason.ID)
+				if err != nil {
+					return fmt.Errorf("failed to find divisions from previous season: %w", err)
+				}
 
-		for _, oldTeam := range oldTeams {
-			newDivisionID, ok := divisionIDMap[oldTeam.DivisionID]
-			if !ok {
-				// Skip teams whose division doesn't have a match in the new season
-				continue
-			}
+				newDivisions, err := s.divisionRepository.FindBySeason(ctx, targetSeasonID)
+				if err != nil {
+					return fmt.Errorf("failed to find divisions in target season: %w", err)
+				}
 
-			newTeam := &models.Team{
-				Name:       oldTeam.Name,
-				ClubID:     oldTeam.ClubID,
-				DivisionID: newDivisionID,
-				SeasonID:   targetSeasonID,
-			}
+				// Map by name (assuming division names match)
+				newDivsByName := make(map[string]uint)
+				for _, div := range newDivisions {
+					newDivsByName[div.Name] = div.ID
+				}
 
-			if err := s.teamRepository.Create(ctx, newTeam); err != nil {
-				return fmt.Errorf("failed to create team %s: %w", oldTeam.Name, err)
+				for _, oldDiv := range oldDivisions {
+					if newDivID, ok := newDivsByName[oldDiv.Name]; ok {
+						divisionIDMap[oldDiv.ID] = newDivID
+					}
+				}
 			}
 
-			// Copy players to the new team (skip inactive players)
-			oldPlayers, err := s.teamRepository.FindPlayersInTeam(ctx, oldTeam.ID, previousSeason.ID)
+			oldTeams, err := s.teamRepository.FindBySeason(ctx, previousSeason.ID)
 			if err != nil {
-				continue // Skip if can't get players
+				return fmt.Errorf("failed to find teams from previous season: %w", err)
 			}
 
-			for _, playerTeam := range oldPlayers {
-				player, err := s.playerRepository.FindByID(ctx, playerTeam.PlayerID)
-				if err != nil || !player.IsActive {
-					continue // Skip inactive or missing players
+			for _, oldTeam := range oldTeams {
+				newDivisionID, ok := divisionIDMap[oldTeam.DivisionID]
+				if !ok {
+					// Skip teams whose division doesn't have a match in the new season
+					continue
 				}
-				_ = s.teamRepository.AddPlayer(ctx, newTeam.ID, playerTeam.PlayerID, targetSeasonID)
-			}
 
-			// Copy captains to the new team (skip inactive players)
-			oldCaptains, err := s.teamRepository.FindCaptainsInTeam(ctx, oldTeam.ID, previousSeason.ID)
-			if err != nil {
-				continue // Skip if can't get captains
-			}
+				newTeam := &models.Team{
+					Name:       oldTeam.Name,
+					ClubID:     oldTeam.ClubID,
+					DivisionID: newDivisionID,
+					SeasonID:   targetSeasonID,
+				}
+
+				if err := s.teamRepository.Create(ctx, newTeam); err != nil {
+					return fmt.Errorf("failed to create team %s: %w", oldTeam.Name, err)
+				}
 
-			for _, captain := range oldCaptains {
-				player, err := s.playerRepository.FindByID(ctx, captain.PlayerID)
-				if err != nil || !player.IsActive {
-					continue // Skip inactive or missing players
+				// Copy players to the new team (skip inactive players)
+				oldPlayers, err := s.teamRepository.FindPlayersInTeam(ctx, oldTeam.ID, previousSeason.ID)
+				if err != nil {
+					continue // Skip if can't get players
+				}
+
+				for _, playerTeam := range oldPlayers {
+					player, err := s.playerRepository.FindByID(ctx, playerTeam.PlayerID)
+					if err != nil || !player.IsActive {
+						continue // Skip inactive or missing players
+					}
+					_ = s.teamRepository.AddPlayer(ctx, newTeam.ID, playerTeam.PlayerID, targetSeasonID)
+				}
+
+				// Copy captains to the new team (skip inactive players)
+				oldCaptains, err := s.teamRepository.FindCaptainsInTeam(ctx, oldTeam.ID, previousSeason.ID)
+				if err != nil {
+					continue // Skip if can't get captains
+				}
+
+				for _, captain := range oldCaptains {
+					player, err := s.playerRepository.FindByID(ctx, captain.PlayerID)
+					if err != nil || !player.IsActive {
+						continue // Skip inactive or missing players
+					}
+					_ = s.teamRepository.AddCaptain(ctx, newTeam.ID, captain.PlayerID, captain.Role, targetSeasonID)
 				}
-				_ = s.teamRepository.AddCaptain(ctx, newTeam.ID, captain.PlayerID, captain.Role, targetSeasonID)
 			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // ImportSummary holds counts of what was created during a season import
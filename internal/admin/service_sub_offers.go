@@ -0,0 +1,254 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// SubCandidate is a player from another home-club team who could fill in for
+// a short team. Status is their resolved availability for the fixture; a
+// player who hasn't said Available only qualifies if they've told us they're
+// open to filling in.
+type SubCandidate struct {
+	Player       models.Player
+	FromTeamName string
+	Status       models.AvailabilityStatus
+	OpenToFillIn bool
+}
+
+// SubOfferResult summarises a 'find a sub' broadcast for the HTMX response.
+type SubOfferResult struct {
+	Offer          *models.SubOffer
+	Candidates     int
+	Notified       int
+	NoSubscription int
+}
+
+// FindSubCandidates lists players who could fill in for teamID in fixtureID.
+// Candidates come from the home club's other teams in the same season, must
+// be Available (or not Unavailable and OpenToFillIn), must not already be
+// selected for the fixture, and must pass the eligibility lock-in rules for
+// the short team. gender narrows the list when only one side is short.
+func (s *Service) FindSubCandidates(ctx context.Context, fixtureID, teamID uint, gender *models.PlayerGender) ([]SubCandidate, error) {
+	fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	shortTeamRoster, err := s.teamRepository.FindPlayersInTeam(ctx, teamID, fixture.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[string]bool, len(shortTeamRoster))
+	for _, pt := range shortTeamRoster {
+		excluded[pt.PlayerID] = true
+	}
+	selected, err := s.fixtureRepository.FindSelectedPlayers(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fp := range selected {
+		excluded[fp.PlayerID] = true
+	}
+
+	clubTeams, err := s.teamRepository.FindByClubAndSeason(ctx, s.homeClubID, fixture.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []SubCandidate
+	for _, team := range clubTeams {
+		if team.ID == teamID {
+			continue
+		}
+		roster, err := s.teamRepository.FindPlayersInTeam(ctx, team.ID, fixture.SeasonID)
+		if err != nil {
+			continue
+		}
+		for _, pt := range roster {
+			if !pt.IsActive || excluded[pt.PlayerID] {
+				continue
+			}
+			// A player can sit on more than one roster; only consider them once.
+			excluded[pt.PlayerID] = true
+
+			player, err := s.playerRepository.FindByID(ctx, pt.PlayerID)
+			if err != nil || player == nil || !player.IsActive {
+				continue
+			}
+			if gender != nil && player.Gender != *gender {
+				continue
+			}
+
+			cell := resolveCell(ctx, s, player.ID, fixture)
+			if cell.Status == models.Unavailable {
+				continue
+			}
+			openToFillIn := false
+			if prefs, err := s.tennisPreferenceRepository.FindByPlayerID(ctx, player.ID); err == nil && prefs != nil && prefs.OpenToFillIn != nil {
+				openToFillIn = *prefs.OpenToFillIn
+			}
+			if cell.Status != models.Available && !openToFillIn {
+				continue
+			}
+
+			eligibility, err := s.teamEligibilityService.GetPlayerEligibilityForTeam(ctx, player.ID, teamID, fixtureID)
+			if err != nil || eligibility == nil || !eligibility.CanPlay {
+				continue
+			}
+
+			candidates = append(candidates, SubCandidate{
+				Player:       *player,
+				FromTeamName: team.Name,
+				Status:       cell.Status,
+				OpenToFillIn: openToFillIn,
+			})
+		}
+	}
+
+	// Players who've explicitly said Available go to the top of the list.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ai := candidates[i].Status == models.Available
+		aj := candidates[j].Status == models.Available
+		return ai && !aj
+	})
+
+	return candidates, nil
+}
+
+// BroadcastSubOffer opens a sub offer for a short team and pushes it to every
+// candidate. Any offer still open for the same team is cancelled first so
+// there is only ever one live call-out per fixture and team.
+func (s *Service) BroadcastSubOffer(ctx context.Context, fixtureID, teamID uint, gender *models.PlayerGender, createdByUserID *int64) (*SubOfferResult, error) {
+	if s.pushService == nil {
+		return nil, fmt.Errorf("push notifications not configured")
+	}
+
+	fixture, err := s.fixtureRepository.FindByID(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	if fixture.HomeTeamID != teamID && fixture.AwayTeamID != teamID {
+		return nil, fmt.Errorf("team %d is not playing in fixture %d", teamID, fixtureID)
+	}
+	team, err := s.teamRepository.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.FindSubCandidates(ctx, fixtureID, teamID, gender)
+	if err != nil {
+		return nil, err
+	}
+	result := &SubOfferResult{Candidates: len(candidates)}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	offer, err := s.subOfferRepository.FindOpenByFixtureAndTeam(ctx, fixtureID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if offer != nil {
+		// Re-issue rather than extend so the recipient list reflects
+		// current availability.
+		if err := s.subOfferRepository.Cancel(ctx, offer.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	recipientIDs := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		recipientIDs = append(recipientIDs, c.Player.ID)
+	}
+	offer = &models.SubOffer{
+		FixtureID:       fixtureID,
+		TeamID:          teamID,
+		Gender:          gender,
+		CreatedByUserID: createdByUserID,
+	}
+	if err := s.subOfferRepository.Create(ctx, offer, recipientIDs); err != nil {
+		return nil, err
+	}
+	result.Offer = offer
+
+	fixtureDate := fixture.ScheduledDate.Format("Monday 2 January")
+	for _, c := range candidates {
+		token := s.getPlayerFantasyToken(ctx, c.Player)
+		if token == "" {
+			result.NoSubscription++
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"title": "Can you fill in?",
+			"body":  fmt.Sprintf("%s are short for %s. First to accept gets the spot.", team.Name, fixtureDate),
+			"data": map[string]string{
+				"type": "sub-offer",
+				"url":  s.linkSigner.NotificationLink(c.Player.ID, token, fmt.Sprintf("/sub-offer/%d", offer.ID)),
+			},
+		}
+
+		sent, err := s.pushService.SendToPlayer(token, payload)
+		if err != nil {
+			log.Printf("Failed to send sub offer to player %s: %v", c.Player.ID, err)
+		}
+		if sent == 0 {
+			result.NoSubscription++
+			continue
+		}
+		result.Notified++
+		if err := s.subOfferRepository.MarkNotified(ctx, offer.ID, c.Player.ID); err != nil {
+			log.Printf("Failed to mark sub offer %d notified for player %s: %v", offer.ID, c.Player.ID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// shortGenderForTeam works out which side of the team is short for the
+// fixture so the offer can be targeted. Returns nil when both sides (or
+// neither) are short, meaning the offer goes to everyone.
+func (s *Service) shortGenderForTeam(ctx context.Context, fixture *models.Fixture, teamID uint) *models.PlayerGender {
+	roster, err := s.teamRepository.FindPlayersInTeam(ctx, teamID, fixture.SeasonID)
+	if err != nil {
+		return nil
+	}
+	var men, women int
+	for _, pt := range roster {
+		if !pt.IsActive {
+			continue
+		}
+		player, err := s.playerRepository.FindByID(ctx, pt.PlayerID)
+		if err != nil || player == nil || !player.IsActive {
+			continue
+		}
+		if resolveCell(ctx, s, player.ID, fixture).Status != models.Available {
+			continue
+		}
+		switch player.Gender {
+		case models.PlayerGenderMen:
+			men++
+		case models.PlayerGenderWomen:
+			women++
+		}
+	}
+
+	shortMen := men < requiredMenPerFixture
+	shortWomen := women < requiredWomenPerFixture
+	switch {
+	case shortMen && !shortWomen:
+		g := models.PlayerGenderMen
+		return &g
+	case shortWomen && !shortMen:
+		g := models.PlayerGenderWomen
+		return &g
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// CourtHive public viewer API types. These are the endpoints courthive-public
+// reads draws from; only the parts needed for draws and results are decoded.
+
+type courthiveTournamentInfoResponse struct {
+	Success        bool                    `json:"success"`
+	TournamentInfo courthiveTournamentInfo `json:"tournamentInfo"`
+}
+
+type courthiveTournamentInfo struct {
+	TournamentID string `json:"tournamentId"`
+	StartDate    string `json:"startDate"`
+	EventInfo    []struct {
+		EventID string `json:"eventId"`
+	} `json:"eventInfo"`
+}
+
+type courthiveEventDataResponse struct {
+	Success   bool               `json:"success"`
+	EventData courthiveEventData `json:"eventData"`
+}
+
+type courthiveEventData struct {
+	EventInfo struct {
+		EventID   string `json:"eventId"`
+		EventName string `json:"eventName"`
+		EventType string `json:"eventType"`
+	} `json:"eventInfo"`
+	DrawsData []courthiveDrawData `json:"drawsData"`
+}
+
+type courthiveDrawData struct {
+	DrawID     string               `json:"drawId"`
+	DrawName   string               `json:"drawName"`
+	DrawType   string               `json:"drawType"`
+	Structures []courthiveStructure `json:"structures"`
+}
+
+// courthiveStructure holds a structure's matches keyed by round number
+type courthiveStructure struct {
+	StructureID   string                        `json:"structureId"`
+	RoundMatchUps map[string][]courthiveMatchUp `json:"roundMatchUps"`
+}
+
+type courthiveMatchUp struct {
+	MatchUpID     string `json:"matchUpId"`
+	RoundNumber   int    `json:"roundNumber"`
+	RoundPosition int    `json:"roundPosition"`
+	RoundName     string `json:"roundName"`
+	MatchUpStatus string `json:"matchUpStatus"`
+	WinningSide   int    `json:"winningSide"`
+	Sides         []struct {
+		SideNumber  int                  `json:"sideNumber"`
+		Participant courthiveParticipant `json:"participant"`
+	} `json:"sides"`
+	Score struct {
+		ScoreStringSide1 string `json:"scoreStringSide1"`
+		ScoreStringSide2 string `json:"scoreStringSide2"`
+	} `json:"score"`
+	Schedule struct {
+		ScheduledDate string `json:"scheduledDate"`
+	} `json:"schedule"`
+}
+
+// courthiveParticipant is a side's participant: an individual, or a pair
+// with its individuals inlined
+type courthiveParticipant struct {
+	ParticipantName        string                 `json:"participantName"`
+	ParticipantType        string                 `json:"participantType"` // INDIVIDUAL or PAIR
+	IndividualParticipants []courthiveParticipant `json:"individualParticipants"`
+	Person                 struct {
+		StandardGivenName  string `json:"standardGivenName"`
+		StandardFamilyName string `json:"standardFamilyName"`
+	} `json:"person"`
+}
+
+// GetTournamentDraws returns a tournament's synced draws with their matches
+func (s *Service) GetTournamentDraws(ctx context.Context, tournamentID uint) ([]models.TournamentDraw, error) {
+	return s.tournamentDrawRepository.FindByTournament(ctx, tournamentID)
+}
+
+// syncCourtHiveDraws replaces the stored draws of each of the provider's
+// visible tournaments with those in CourtHive. Participants are matched to
+// our players by name. Each tournament is written in its own transaction,
+// and one that fails is recorded in the result without stopping the rest.
+func (s *Service) syncCourtHiveDraws(ctx context.Context, providerID uint, result *SyncResult) error {
+	tournaments, err := s.tournamentRepository.FindByProviderID(ctx, providerID)
+	if err != nil {
+		return fmt.Errorf("finding tournaments: %w", err)
+	}
+
+	matcher := newParticipantMatcher(services.NewPlayerMatcher(s.playerRepository))
+	for _, tournament := range tournaments {
+		if !tournament.IsVisible || tournament.CourthiveTournamentID == "" {
+			continue
+		}
+
+		info, events, err := s.fetchCourtHiveEvents(tournament.CourthiveTournamentID)
+		if err != nil {
+			result.DrawErrors = append(result.DrawErrors, fmt.Sprintf("fetching CourtHive draws for %q: %v", tournament.Name, err))
+			continue
+		}
+		startDate := tournament.StartDate
+		if info.StartDate != "" {
+			startDate = info.StartDate
+		}
+		draws := matcher.draws(ctx, events, startDate)
+
+		err = s.db.WithTx(ctx, func(ctx context.Context) error {
+			return s.tournamentDrawRepository.ReplaceForTournament(ctx, tournament.ID, draws)
+		})
+		if err != nil {
+			result.DrawErrors = append(result.DrawErrors, fmt.Sprintf("saving draws for %q: %v", tournament.Name, err))
+			continue
+		}
+
+		result.Draws += len(draws)
+		for _, draw := range draws {
+			result.Matches += len(draw.Matches)
+		}
+	}
+
+	result.MatchedPlayers = len(matcher.matched)
+	result.UnmatchedParticipants = matcher.unmatched
+	return nil
+}
+
+// fetchCourtHiveEvents fetches a tournament's info and the draws of each of
+// its events
+func (s *Service) fetchCourtHiveEvents(courthiveTournamentID string) (*courthiveTournamentInfo, []courthiveEventData, error) {
+	var infoResp courthiveTournamentInfoResponse
+	if err := s.postCourtHive("/factory/tournamentinfo", map[string]string{"tournamentId": courthiveTournamentID}, &infoResp); err != nil {
+		return nil, nil, err
+	}
+	if !infoResp.Success {
+		return nil, nil, fmt.Errorf("CourtHive returned success=false for tournament %s", courthiveTournamentID)
+	}
+
+	events := make([]courthiveEventData, 0, len(infoResp.TournamentInfo.EventInfo))
+	for _, event := range infoResp.TournamentInfo.EventInfo {
+		var eventResp courthiveEventDataResponse
+		body := map[string]string{"tournamentId": courthiveTournamentID, "eventId": event.EventID}
+		if err := s.postCourtHive("/factory/eventdata", body, &eventResp); err != nil {
+			return nil, nil, err
+		}
+		if !eventResp.Success {
+			return nil, nil, fmt.Errorf("CourtHive returned success=false for event %s", event.EventID)
+		}
+		events = append(events, eventResp.EventData)
+	}
+	return &infoResp.TournamentInfo, events, nil
+}
+
+// postCourtHive posts a JSON body to a CourtHive endpoint and decodes the reply into out
+func (s *Service) postCourtHive(path string, body interface{}, out interface{}) error {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := s.courthiveAPIURL + path
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Writes such as entry exports need a CourtHive provider admin's token;
+	// the public viewer endpoints ignore it
+	if token := os.Getenv("COURTHIVE_API_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("CourtHive returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// participantMatcher maps CourtHive participant names to our players,
+// remembering each name's answer for the rest of the sync
+type participantMatcher struct {
+	matcher   *services.PlayerMatcher
+	byName    map[string]*string
+	matched   map[string]bool
+	unmatched []string
+}
+
+func newParticipantMatcher(matcher *services.PlayerMatcher) *participantMatcher {
+	return &participantMatcher{
+		matcher: matcher,
+		byName:  make(map[string]*string),
+		matched: make(map[string]bool),
+	}
+}
+
+// playerID returns the ID of the player called name, or nil if none matches
+func (m *participantMatcher) playerID(ctx context.Context, name string) *string {
+	if id, seen := m.byName[name]; seen {
+		return id
+	}
+	var id *string
+	if playerID, err := m.matcher.MatchPlayer(ctx, name); err == nil {
+		id = &playerID
+		m.matched[playerID] = true
+	} else {
+		m.unmatched = append(m.unmatched, name)
+	}
+	m.byName[name] = id
+	return id
+}
+
+// draws converts CourtHive event draws to ours. Matches with no date of their
+// own are dated to the start of the tournament.
+func (m *participantMatcher) draws(ctx context.Context, events []courthiveEventData, tournamentStart string) []models.TournamentDraw {
+	defaultDate := parseCourtHiveDate(tournamentStart)
+
+	var draws []models.TournamentDraw
+	for _, event := range events {
+		for _, drawData := range event.DrawsData {
+			draw := models.TournamentDraw{
+				CourthiveDrawID: drawData.DrawID,
+				Name:            drawData.DrawName,
+				DrawType:        drawData.DrawType,
+				EventName:       event.EventInfo.EventName,
+				EventType:       event.EventInfo.EventType,
+			}
+			for _, structure := range drawData.Structures {
+				for _, matchUps := range structure.RoundMatchUps {
+					for _, matchUp := range matchUps {
+						draw.Matches = append(draw.Matches, m.match(ctx, matchUp, defaultDate))
+					}
+				}
+			}
+			// roundMatchUps is a map, so put the matches back in draw order
+			sort.Slice(draw.Matches, func(i, j int) bool {
+				a, b := draw.Matches[i], draw.Matches[j]
+				if a.RoundNumber != b.RoundNumber {
+					return a.RoundNumber < b.RoundNumber
+				}
+				return a.RoundPosition < b.RoundPosition
+			})
+			draws = append(draws, draw)
+		}
+	}
+	return draws
+}
+
+// match converts one CourtHive match, matching the individuals on each side
+// to our players
+func (m *participantMatcher) match(ctx context.Context, matchUp courthiveMatchUp, defaultDate *time.Time) models.TournamentMatch {
+	match := models.TournamentMatch{
+		CourthiveMatchUpID: matchUp.MatchUpID,
+		RoundNumber:        matchUp.RoundNumber,
+		RoundPosition:      matchUp.RoundPosition,
+		RoundName:          matchUp.RoundName,
+		Score:              matchUp.Score.ScoreStringSide1,
+		ScoreSide2:         matchUp.Score.ScoreStringSide2,
+		Status:             matchUp.MatchUpStatus,
+		PlayedDate:         defaultDate,
+	}
+	if date := parseCourtHiveDate(matchUp.Schedule.ScheduledDate); date != nil {
+		match.PlayedDate = date
+	}
+	if matchUp.WinningSide == 1 || matchUp.WinningSide == 2 {
+		winningSide := matchUp.WinningSide
+		match.WinningSide = &winningSide
+	}
+
+	for _, side := range matchUp.Sides {
+		if side.SideNumber != 1 && side.SideNumber != 2 {
+			continue
+		}
+		sideName := courthiveParticipantName(side.Participant)
+		if sideName == "" {
+			continue
+		}
+		if side.SideNumber == 1 {
+			match.Side1Name = sideName
+		} else {
+			match.Side2Name = sideName
+		}
+
+		// A pair's individuals, or the participant themselves
+		individuals := []courthiveParticipant{side.Participant}
+		if side.Participant.ParticipantType == "PAIR" {
+			individuals = side.Participant.IndividualParticipants
+		}
+		for _, individual := range individuals {
+			name := courthiveParticipantName(individual)
+			if name == "" {
+				continue
+			}
+			match.Players = append(match.Players, models.TournamentMatchPlayer{
+				Side:            side.SideNumber,
+				ParticipantName: name,
+				PlayerID:        m.playerID(ctx, name),
+			})
+		}
+	}
+	return match
+}
+
+// courthiveParticipantName is the name CourtHive shows for a participant,
+// built from the person's names when the record has no display name
+func courthiveParticipantName(p courthiveParticipant) string {
+	if name := strings.TrimSpace(p.ParticipantName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(p.Person.StandardGivenName + " " + p.Person.StandardFamilyName)
+}
+
+// parseCourtHiveDate parses the date part of a CourtHive date or timestamp,
+// returning nil when there is none
+func parseCourtHiveDate(value string) *time.Time {
+	if len(value) < len("2006-01-02") {
+		return nil
+	}
+	date, err := time.Parse("2006-01-02", value[:len("2006-01-02")])
+	if err != nil {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// EntryExportResult counts the outcome of exporting approved entries to CourtHive
+type EntryExportResult struct {
+	Synced  int
+	Removed int
+	Failed  int
+}
+
+// courthiveExecutionQueue is the body of a CourtHive /factory/executionqueue
+// request: tournament mutations applied in order, all or nothing
+type courthiveExecutionQueue struct {
+	TournamentID   string                  `json:"tournamentId"`
+	ExecutionQueue []courthiveQueuedMethod `json:"executionQueue"`
+}
+
+type courthiveQueuedMethod struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+// courthiveMutationResponse is CourtHive's reply to a mutation. error may be
+// a message or an object, so it is kept as raw JSON for the sync status.
+type courthiveMutationResponse struct {
+	Success bool            `json:"success"`
+	Error   json.RawMessage `json:"error"`
+}
+
+// courthiveNewParticipant is a participant to add to a CourtHive tournament:
+// an individual with their person, or a pair of individuals
+type courthiveNewParticipant struct {
+	ParticipantID            string           `json:"participantId"`
+	ParticipantType          string           `json:"participantType"`
+	ParticipantRole          string           `json:"participantRole"`
+	ParticipantName          string           `json:"participantName"`
+	Person                   *courthivePerson `json:"person,omitempty"`
+	IndividualParticipantIDs []string         `json:"individualParticipantIds,omitempty"`
+}
+
+type courthivePerson struct {
+	PersonID           string `json:"personId"`
+	StandardGivenName  string `json:"standardGivenName"`
+	StandardFamilyName string `json:"standardFamilyName"`
+	Sex                string `json:"sex,omitempty"`
+}
+
+// GetTournamentEvents returns the events members can enter for a tournament
+func (s *Service) GetTournamentEvents(ctx context.Context, tournamentID uint) ([]models.TournamentEvent, error) {
+	return s.tournamentEntryRepository.FindEventsByTournament(ctx, tournamentID)
+}
+
+// GetTournamentEntries returns all entries to a tournament's events
+func (s *Service) GetTournamentEntries(ctx context.Context, tournamentID uint) ([]models.TournamentEntry, error) {
+	return s.tournamentEntryRepository.FindByTournament(ctx, tournamentID)
+}
+
+// CreateTournamentEvent opens a new event for entries
+func (s *Service) CreateTournamentEvent(ctx context.Context, event *models.TournamentEvent) error {
+	return s.tournamentEntryRepository.CreateEvent(ctx, event)
+}
+
+// DeleteTournamentEvent removes one of a tournament's events and its entries
+func (s *Service) DeleteTournamentEvent(ctx context.Context, tournamentID, eventID uint) error {
+	event, err := s.tournamentEntryRepository.FindEventByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.TournamentID != tournamentID {
+		return fmt.Errorf("event %d is not part of tournament %d", eventID, tournamentID)
+	}
+	return s.tournamentEntryRepository.DeleteEvent(ctx, eventID)
+}
+
+// SetTournamentEntryStatus approves or rejects one of a tournament's entries.
+// Changing an entry's status marks it for export again, except that an entry
+// already in CourtHive stays synced so a rejection is removed by the next
+// export.
+func (s *Service) SetTournamentEntryStatus(ctx context.Context, tournamentID, entryID uint, status models.TournamentEntryStatus) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		entry, err := s.tournamentEntryRepository.FindByID(ctx, entryID)
+		if err != nil {
+			return err
+		}
+		event, err := s.tournamentEntryRepository.FindEventByID(ctx, entry.EventID)
+		if err != nil {
+			return err
+		}
+		if event.TournamentID != tournamentID {
+			return fmt.Errorf("entry %d is not part of tournament %d", entryID, tournamentID)
+		}
+		if err := s.tournamentEntryRepository.UpdateStatus(ctx, entryID, status); err != nil {
+			return err
+		}
+		if entry.SyncStatus == models.EntrySynced {
+			return nil
+		}
+		return s.tournamentEntryRepository.UpdateSyncStatus(ctx, entryID, models.EntryNotSynced, "")
+	})
+}
+
+// ExportEntriesToCourtHive adds each approved entry not yet in CourtHive to
+// its event there and removes entries withdrawn or rejected since they were
+// exported, recording whether each one succeeded. Entries are sent one at a
+// time so one bad entry doesn't hold up the rest.
+func (s *Service) ExportEntriesToCourtHive(ctx context.Context, tournamentID uint) (*EntryExportResult, error) {
+	tournament, err := s.tournamentRepository.FindByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.CourthiveTournamentID == "" {
+		return nil, fmt.Errorf("tournament %q has no CourtHive tournament ID", tournament.Name)
+	}
+
+	events, err := s.tournamentEntryRepository.FindEventsByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	eventsByID := make(map[uint]models.TournamentEvent, len(events))
+	for _, event := range events {
+		eventsByID[event.ID] = event
+	}
+
+	entries, players, err := s.approvedEntries(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EntryExportResult{}
+	for _, entry := range entries {
+		if entry.SyncStatus == models.EntrySynced {
+			continue
+		}
+		syncErr := s.exportEntry(tournament.CourthiveTournamentID, eventsByID[entry.EventID], entry, players)
+		if syncErr != nil {
+			result.Failed++
+			err = s.tournamentEntryRepository.UpdateSyncStatus(ctx, entry.ID, models.EntrySyncFailed, syncErr.Error())
+		} else {
+			result.Synced++
+			err = s.tournamentEntryRepository.UpdateSyncStatus(ctx, entry.ID, models.EntrySynced, "")
+		}
+		if err != nil {
+			return result, fmt.Errorf("recording sync status for entry %d: %w", entry.ID, err)
+		}
+	}
+
+	all, err := s.tournamentEntryRepository.FindByTournament(ctx, tournamentID)
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range all {
+		if !entry.StaleInCourtHive() {
+			continue
+		}
+		// A failed removal leaves the entry synced, with the error, so it
+		// is retried next time
+		syncErr := s.removeExportedEntry(tournament.CourthiveTournamentID, eventsByID[entry.EventID], entry)
+		if syncErr != nil {
+			result.Failed++
+			err = s.tournamentEntryRepository.UpdateSyncStatus(ctx, entry.ID, models.EntrySynced, syncErr.Error())
+		} else {
+			result.Removed++
+			err = s.tournamentEntryRepository.UpdateSyncStatus(ctx, entry.ID, models.EntryNotSynced, "")
+		}
+		if err != nil {
+			return result, fmt.Errorf("recording sync status for entry %d: %w", entry.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// exportEntry adds an entry's players to the CourtHive tournament and
+// enters them into the event
+func (s *Service) exportEntry(courthiveTournamentID string, event models.TournamentEvent, entry models.TournamentEntry, players map[string]*models.Player) error {
+	if event.CourthiveEventID == "" {
+		return fmt.Errorf("event %q has no CourtHive event ID", event.Name)
+	}
+
+	participants := []courthiveNewParticipant{courthiveIndividual(players[entry.PlayerID])}
+	entrantID := participants[0].ParticipantID
+	if entry.PartnerID != nil {
+		partner := courthiveIndividual(players[*entry.PartnerID])
+		pair := courthiveNewParticipant{
+			ParticipantID:            courthivePairID(entry),
+			ParticipantType:          "PAIR",
+			ParticipantRole:          "COMPETITOR",
+			ParticipantName:          participants[0].Person.StandardFamilyName + "/" + partner.Person.StandardFamilyName,
+			IndividualParticipantIDs: []string{entrantID, partner.ParticipantID},
+		}
+		participants = append(participants, partner, pair)
+		entrantID = pair.ParticipantID
+	}
+
+	return s.executeCourtHiveQueue(courthiveTournamentID, []courthiveQueuedMethod{
+		{Method: "addParticipants", Params: map[string]interface{}{"participants": participants}},
+		{Method: "addEventEntries", Params: map[string]interface{}{
+			"eventId":        event.CourthiveEventID,
+			"participantIds": []string{entrantID},
+		}},
+	})
+}
+
+// removeExportedEntry takes an exported entry out of its CourtHive event.
+// A doubles pair is only ever used for one entry, so it is deleted too; the
+// individuals may have other entries and are left in the tournament.
+func (s *Service) removeExportedEntry(courthiveTournamentID string, event models.TournamentEvent, entry models.TournamentEntry) error {
+	if event.CourthiveEventID == "" {
+		return fmt.Errorf("event %q has no CourtHive event ID", event.Name)
+	}
+
+	entrantID := "jt-" + entry.PlayerID
+	if entry.PartnerID != nil {
+		entrantID = courthivePairID(entry)
+	}
+	queue := []courthiveQueuedMethod{
+		{Method: "removeEventEntries", Params: map[string]interface{}{
+			"eventId":        event.CourthiveEventID,
+			"participantIds": []string{entrantID},
+		}},
+	}
+	if entry.PartnerID != nil {
+		queue = append(queue, courthiveQueuedMethod{Method: "deleteParticipants", Params: map[string]interface{}{
+			"participantIds": []string{entrantID},
+		}})
+	}
+	return s.executeCourtHiveQueue(courthiveTournamentID, queue)
+}
+
+// executeCourtHiveQueue applies mutations to a CourtHive tournament
+func (s *Service) executeCourtHiveQueue(courthiveTournamentID string, queue []courthiveQueuedMethod) error {
+	body := courthiveExecutionQueue{TournamentID: courthiveTournamentID, ExecutionQueue: queue}
+	var resp courthiveMutationResponse
+	if err := s.postCourtHive("/factory/executionqueue", body, &resp); err != nil {
+		return err
+	}
+	if !resp.Success {
+		if len(resp.Error) > 0 {
+			return fmt.Errorf("CourtHive rejected the entry: %s", string(resp.Error))
+		}
+		return fmt.Errorf("CourtHive rejected the entry")
+	}
+	return nil
+}
+
+// courthivePairID is the CourtHive participant ID of a doubles entry's pair
+func courthivePairID(entry models.TournamentEntry) string {
+	return "jt-entry-" + strconv.FormatUint(uint64(entry.ID), 10)
+}
+
+// courthiveIndividual describes a player as a CourtHive individual
+// participant. IDs are derived from the player ID so re-exports refer to the
+// same participant.
+func courthiveIndividual(player *models.Player) courthiveNewParticipant {
+	sex := ""
+	switch player.Gender {
+	case models.PlayerGenderMen:
+		sex = "MALE"
+	case models.PlayerGenderWomen:
+		sex = "FEMALE"
+	}
+	return courthiveNewParticipant{
+		ParticipantID:   "jt-" + player.ID,
+		ParticipantType: "INDIVIDUAL",
+		ParticipantRole: "COMPETITOR",
+		ParticipantName: player.FirstName + " " + player.LastName,
+		Person: &courthivePerson{
+			PersonID:           player.ID,
+			StandardGivenName:  player.FirstName,
+			StandardFamilyName: player.LastName,
+			Sex:                sex,
+		},
+	}
+}
+
+// WriteTournamentEntriesCSV writes a tournament's approved entries as CSV,
+// one row per entry, for importing into CourtHive by hand
+func (s *Service) WriteTournamentEntriesCSV(ctx context.Context, w io.Writer, tournamentID uint) error {
+	events, err := s.tournamentEntryRepository.FindEventsByTournament(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	courthiveEventIDs := make(map[uint]string, len(events))
+	for _, event := range events {
+		courthiveEventIDs[event.ID] = event.CourthiveEventID
+	}
+
+	entries, players, err := s.approvedEntries(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"event", "courthive_event_id", "first_name", "last_name", "gender", "partner_first_name", "partner_last_name", "partner_gender"})
+	for _, entry := range entries {
+		player := players[entry.PlayerID]
+		row := []string{entry.EventName, courthiveEventIDs[entry.EventID], player.FirstName, player.LastName, string(player.Gender), "", "", ""}
+		if entry.PartnerID != nil {
+			partner := players[*entry.PartnerID]
+			row[5], row[6], row[7] = partner.FirstName, partner.LastName, string(partner.Gender)
+		}
+		out.Write(row)
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// approvedEntries returns a tournament's approved entries with every player
+// on them, keyed by ID
+func (s *Service) approvedEntries(ctx context.Context, tournamentID uint) ([]models.TournamentEntry, map[string]*models.Player, error) {
+	all, err := s.tournamentEntryRepository.FindByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entries []models.TournamentEntry
+	var playerIDs []string
+	for _, entry := range all {
+		if entry.Status != models.EntryApproved {
+			continue
+		}
+		entries = append(entries, entry)
+		playerIDs = append(playerIDs, entry.PlayerID)
+		if entry.PartnerID != nil {
+			playerIDs = append(playerIDs, *entry.PartnerID)
+		}
+	}
+	if len(playerIDs) == 0 {
+		return entries, map[string]*models.Player{}, nil
+	}
+	players, err := s.playerRepository.FindByIDs(ctx, playerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, players, nil
+}
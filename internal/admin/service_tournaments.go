@@ -41,6 +41,15 @@ type SyncResult struct {
 	New       int
 	Updated   int
 	Unchanged int
+
+	// Draws and results of the provider's visible tournaments
+	Draws                 int
+	Matches               int
+	MatchedPlayers        int
+	UnmatchedParticipants []string
+	// DrawErrors records each tournament whose draws couldn't be fetched or
+	// saved; the others are still synced
+	DrawErrors []string
 }
 
 // --- Tournament Provider methods ---
@@ -119,9 +128,9 @@ func (s *Service) ToggleTournamentVisibility(id uint) (*models.Tournament, error
 
 // --- CourtHive sync ---
 
-func (s *Service) SyncFromCourtHive(providerID uint) (*SyncResult, error) {
-	ctx := context.Background()
-
+// SyncFromCourtHive pulls a provider's tournament calendar from CourtHive,
+// then the draws and results of those tournaments shown on the public site
+func (s *Service) SyncFromCourtHive(ctx context.Context, providerID uint) (*SyncResult, error) {
 	provider, err := s.tournamentProviderRepository.FindByID(ctx, providerID)
 	if err != nil {
 		return nil, fmt.Errorf("finding provider: %w", err)
@@ -179,6 +188,10 @@ func (s *Service) SyncFromCourtHive(providerID uint) (*SyncResult, error) {
 		}
 	}
 
+	if err := s.syncCourtHiveDraws(ctx, provider.ID, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -3,8 +3,10 @@
 package admin
 
 import (
+	"context"
 	"fmt"
 
+	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/models"
 
 	"golang.org/x/crypto/bcrypt"
@@ -20,7 +22,8 @@ type SessionWithUser struct {
 func (s *Service) GetAllUsers() ([]models.User, error) {
 	var users []models.User
 	err := s.db.Select(&users, `
-		SELECT id, username, password_hash, role, player_id, is_active, created_at, last_login_at
+		SELECT id, username, email, password_hash, role, player_id, is_active, created_at, last_login_at,
+		       totp_enabled_at
 		FROM users
 		ORDER BY username ASC
 	`)
@@ -28,16 +31,16 @@ func (s *Service) GetAllUsers() ([]models.User, error) {
 }
 
 // CreateUser creates a new user with a hashed password
-func (s *Service) CreateUser(username, password string, role models.Role) (int64, error) {
+func (s *Service) CreateUser(username, email, password string, role models.Role) (int64, error) {
 	hashedPassword, err := hashPassword(password)
 	if err != nil {
 		return 0, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	result, err := s.db.Exec(`
-		INSERT INTO users (username, password_hash, role, is_active, created_at, last_login_at)
-		VALUES (?, ?, ?, true, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, username, hashedPassword, role)
+		INSERT INTO users (username, email, password_hash, role, is_active, created_at, last_login_at)
+		VALUES (?, ?, ?, ?, true, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, username, email, hashedPassword, role)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -57,14 +60,26 @@ func (s *Service) ToggleUserActive(id int64) error {
 	return err
 }
 
-// ResetUserPassword resets a user's password
+// UpdateUserEmail changes the address invitation and reset links are sent to
+func (s *Service) UpdateUserEmail(ctx context.Context, id int64, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE users SET email = ? WHERE id = ?`, email, id)
+	return err
+}
+
+// ResetUserPassword resets a user's password and forgets their remembered
+// devices, so whoever knew the old password can't skip two-factor on them
 func (s *Service) ResetUserPassword(id int64, newPassword string) error {
 	hashedPassword, err := hashPassword(newPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
-	_, err = s.db.Exec(`UPDATE users SET password_hash = ? WHERE id = ?`, hashedPassword, id)
-	return err
+	return s.db.WithTx(context.Background(), func(ctx context.Context) error {
+		if _, err := s.db.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ?`, hashedPassword, id); err != nil {
+			return err
+		}
+		_, err := s.db.ExecContext(ctx, `DELETE FROM trusted_devices WHERE user_id = ?`, id)
+		return err
+	})
 }
 
 // GetActiveSessions retrieves all valid sessions with usernames
@@ -82,18 +97,32 @@ func (s *Service) GetActiveSessions() ([]SessionWithUser, error) {
 	return sessions, err
 }
 
-// InvalidateSession marks a session as invalid
+// InvalidateSession marks a session as invalid and forgets the device that
+// signed it in, so the device has to pass two-factor again
 func (s *Service) InvalidateSession(sessionID string) error {
-	_, err := s.db.Exec(`UPDATE sessions SET is_valid = false WHERE id = ?`, sessionID)
+	if _, err := s.db.Exec(`UPDATE sessions SET is_valid = false WHERE id = ?`, sessionID); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`DELETE FROM trusted_devices WHERE session_id = ?`, sessionID)
 	return err
 }
 
-// InvalidateAllUserSessions invalidates all sessions for a user
+// InvalidateAllUserSessions invalidates all sessions for a user and forgets
+// their remembered devices
 func (s *Service) InvalidateAllUserSessions(userID int64) error {
-	_, err := s.db.Exec(`UPDATE sessions SET is_valid = false WHERE user_id = ?`, userID)
+	if _, err := s.db.Exec(`UPDATE sessions SET is_valid = false WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`DELETE FROM trusted_devices WHERE user_id = ?`, userID)
 	return err
 }
 
+// ResetUserTwoFactor turns off a user's two-factor authentication, for when
+// they have lost both their phone and their recovery codes
+func (s *Service) ResetUserTwoFactor(ctx context.Context, id int64) error {
+	return auth.ResetTwoFactor(ctx, s.db, id)
+}
+
 // CleanupExpiredSessions marks all expired sessions as invalid
 func (s *Service) CleanupExpiredSessions() error {
 	_, err := s.db.Exec(`UPDATE sessions SET is_valid = false WHERE expires_at < CURRENT_TIMESTAMP`)
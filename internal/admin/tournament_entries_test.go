@@ -0,0 +1,204 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/players"
+	"jim-dot-tennis/internal/services"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestTournamentEntriesAreCheckedApprovedAndExported(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "entries.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPathAdmin(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES (1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', '')`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id, gender, is_active) VALUES
+		('alice', 'Alice', 'Archer', 1, 'Women', 1), ('bob', 'Bob', 'Baker', 1, 'Men', 1),
+		('cara', 'Cara', 'Cole', 1, 'Women', 1), ('dan', 'Dan', 'Drake', 2, 'Men', 1)`)
+	exec(`INSERT INTO tournament_providers (id, name, provider_abbr) VALUES (1, 'Parks League Cup', 'PLC')`)
+	exec(`INSERT INTO tournaments (id, name, courthive_tournament_id, provider_id, is_visible) VALUES (1, 'Parks Cup', 'cup-2026', 1, 1)`)
+
+	var queues []courthiveExecutionQueue
+	var auth []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/factory/executionqueue", func(w http.ResponseWriter, r *http.Request) {
+		var queue courthiveExecutionQueue
+		if err := json.NewDecoder(r.Body).Decode(&queue); err != nil {
+			t.Errorf("decode execution queue: %v", err)
+		}
+		queues = append(queues, queue)
+		auth = append(auth, r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	t.Setenv("COURTHIVE_API_TOKEN", "provider-token")
+
+	svc := NewService(db, server.URL, 1, "")
+	deadline := time.Now().Add(7 * 24 * time.Hour)
+	mixed := &models.TournamentEvent{TournamentID: 1, Name: "Mixed Doubles", EventType: models.TournamentDoubles,
+		Gender: models.TournamentEventMixed, EntryDeadline: deadline, CourthiveEventID: "e1"}
+	singles := &models.TournamentEvent{TournamentID: 1, Name: "Ladies' Singles", EventType: models.TournamentSingles,
+		Gender: models.TournamentEventWomen, EntryDeadline: deadline}
+	for _, event := range []*models.TournamentEvent{mixed, singles} {
+		if err := svc.CreateTournamentEvent(ctx, event); err != nil {
+			t.Fatalf("create event: %v", err)
+		}
+	}
+
+	// Players enter from their token page; ineligible entries are refused
+	// with a reason they can read
+	playerService := players.NewService(db, 1)
+	for _, tc := range []struct {
+		name      string
+		playerID  string
+		eventID   uint
+		partnerID string
+		refused   string
+	}{
+		{"two women in mixed", "alice", mixed.ID, "cara", "one man and one woman"},
+		{"partner from another club", "alice", mixed.ID, "dan", "member of your club"},
+		{"man in ladies' singles", "bob", singles.ID, "", "Women's event"},
+		{"mixed pair", "alice", mixed.ID, "bob", ""},
+		{"partner already entered", "cara", mixed.ID, "bob", "Bob is already entered"},
+		{"singles", "cara", singles.ID, "", ""},
+	} {
+		_, err := playerService.EnterTournamentEvent(ctx, tc.playerID, tc.eventID, tc.partnerID)
+		var entryErr *services.EntryError
+		switch {
+		case tc.refused == "" && err != nil:
+			t.Errorf("%s: %v", tc.name, err)
+		case tc.refused != "" && (!errors.As(err, &entryErr) || !strings.Contains(entryErr.Reason, tc.refused)):
+			t.Errorf("%s: err = %v; want an entry error containing %q", tc.name, err, tc.refused)
+		}
+	}
+
+	entries, err := svc.GetTournamentEntries(ctx, 1)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("entries = %+v, %v; want the mixed pair and the singles", entries, err)
+	}
+	for _, entry := range entries {
+		if entry.Status != models.EntryPending {
+			t.Errorf("%s entry status = %s; want Pending", entry.EventName, entry.Status)
+		}
+		if err := svc.SetTournamentEntryStatus(ctx, 1, entry.ID, models.EntryApproved); err != nil {
+			t.Fatalf("approve: %v", err)
+		}
+	}
+
+	// The pair reaches CourtHive; the singles event isn't linked to a
+	// CourtHive event, so that entry is marked failed
+	result, err := svc.ExportEntriesToCourtHive(ctx, 1)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if result.Synced != 1 || result.Failed != 1 || len(queues) != 1 {
+		t.Fatalf("export = %+v with %d requests; want 1 synced, 1 failed and 1 request", result, len(queues))
+	}
+	if auth[0] != "Bearer provider-token" {
+		t.Errorf("Authorization = %q; want the provider token", auth[0])
+	}
+	queue, _ := json.Marshal(queues[0])
+	for _, want := range []string{`"tournamentId":"cup-2026"`, `"method":"addParticipants"`, `"participantId":"jt-alice"`,
+		`"sex":"MALE"`, `"participantType":"PAIR"`, `"method":"addEventEntries"`, `"eventId":"e1"`} {
+		if !bytes.Contains(queue, []byte(want)) {
+			t.Errorf("execution queue %s is missing %s", queue, want)
+		}
+	}
+
+	entries, _ = svc.GetTournamentEntries(ctx, 1)
+	for _, entry := range entries {
+		switch entry.EventID {
+		case mixed.ID:
+			if entry.SyncStatus != models.EntrySynced || entry.SyncedAt == nil {
+				t.Errorf("mixed entry sync = %s at %v; want Synced with a time", entry.SyncStatus, entry.SyncedAt)
+			}
+		case singles.ID:
+			if entry.SyncStatus != models.EntrySyncFailed || !strings.Contains(entry.SyncError, "no CourtHive event ID") {
+				t.Errorf("singles entry sync = %s (%q); want Failed for the missing event ID", entry.SyncStatus, entry.SyncError)
+			}
+		}
+	}
+
+	// Exporting again only retries entries that haven't reached CourtHive
+	if result, err := svc.ExportEntriesToCourtHive(ctx, 1); err != nil || result.Synced != 0 || result.Failed != 1 || len(queues) != 1 {
+		t.Errorf("re-export = %+v, %v with %d requests; want only the failed entry retried", result, err, len(queues))
+	}
+
+	var csv bytes.Buffer
+	if err := svc.WriteTournamentEntriesCSV(ctx, &csv, 1); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if !strings.Contains(csv.String(), "Mixed Doubles,e1,Alice,Archer,Women,Bob,Baker,Men") ||
+		!strings.Contains(csv.String(), "Ladies' Singles,,Cara,Cole,Women,,,") {
+		t.Errorf("csv = %q; want a row per approved entry", csv.String())
+	}
+
+	// Alice withdraws the exported pair and the admin rejects the singles,
+	// which never reached CourtHive. Only the pair is flagged as out of date
+	// and the next export removes it there.
+	for _, entry := range entries {
+		switch entry.EventID {
+		case mixed.ID:
+			err = playerService.WithdrawTournamentEntry(ctx, "alice", entry.ID)
+		case singles.ID:
+			err = svc.SetTournamentEntryStatus(ctx, 1, entry.ID, models.EntryRejected)
+		}
+		if err != nil {
+			t.Fatalf("withdraw or reject %s: %v", entry.EventName, err)
+		}
+	}
+	entries, _ = svc.GetTournamentEntries(ctx, 1)
+	for _, entry := range entries {
+		if stale := entry.StaleInCourtHive(); stale != (entry.EventID == mixed.ID) {
+			t.Errorf("%s entry out of date in CourtHive = %t", entry.EventName, stale)
+		}
+	}
+	result, err = svc.ExportEntriesToCourtHive(ctx, 1)
+	if err != nil || result.Synced != 0 || result.Removed != 1 || result.Failed != 0 || len(queues) != 2 {
+		t.Fatalf("export after withdrawal = %+v, %v with %d requests; want the pair removed", result, err, len(queues))
+	}
+	queue, _ = json.Marshal(queues[1])
+	for _, want := range []string{`"method":"removeEventEntries"`, `"eventId":"e1"`, `"participantIds":["jt-entry-`, `"method":"deleteParticipants"`} {
+		if !bytes.Contains(queue, []byte(want)) {
+			t.Errorf("removal queue %s is missing %s", queue, want)
+		}
+	}
+	entries, _ = svc.GetTournamentEntries(ctx, 1)
+	for _, entry := range entries {
+		if entry.StaleInCourtHive() || entry.SyncStatus == models.EntrySynced {
+			t.Errorf("%s entry sync = %s after removal; want it no longer in CourtHive", entry.EventName, entry.SyncStatus)
+		}
+	}
+	if result, err := svc.ExportEntriesToCourtHive(ctx, 1); err != nil || result.Removed != 0 || len(queues) != 2 {
+		t.Errorf("re-export = %+v, %v with %d requests; want nothing left to remove", result, err, len(queues))
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/observability"
 )
 
 // TournamentsHandler handles tournament and provider management requests
@@ -397,14 +400,16 @@ func (h *TournamentsHandler) HandleToggleVisibility(w http.ResponseWriter, r *ht
 	// Return just the toggle button HTML for HTMX swap
 	visibleText := "Hidden"
 	visibleClass := "badge-hidden"
+	confirmText := "Show this tournament on the public site?"
 	if tournament.IsVisible {
 		visibleText = "Visible"
 		visibleClass = "badge-visible"
+		confirmText = "Hide this tournament from the public site?"
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `<button class="visibility-toggle %s" hx-post="/admin/league/tournaments/toggle-visibility/%d" hx-swap="outerHTML">%s</button>`,
-		visibleClass, tournament.ID, visibleText)
+	fmt.Fprintf(w, `<button class="visibility-toggle %s" hx-post="/admin/league/tournaments/toggle-visibility/%d" hx-confirm="%s" hx-swap="outerHTML">%s</button>`,
+		visibleClass, tournament.ID, confirmText, visibleText)
 }
 
 // HandleSync syncs tournaments from CourtHive for a given provider
@@ -426,13 +431,164 @@ func (h *TournamentsHandler) HandleSync(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := h.service.SyncFromCourtHive(providerID)
+	start := time.Now()
+	result, err := h.service.SyncFromCourtHive(r.Context(), providerID)
+	observability.RecordImportRun("courthive_tournaments", time.Since(start), err, 0)
 	if err != nil {
 		log.Printf("CourtHive sync failed for provider %d: %v", providerID, err)
 		http.Redirect(w, r, fmt.Sprintf("/admin/league/tournaments?error=Sync+failed:+%s", err.Error()), http.StatusSeeOther)
 		return
 	}
 
-	msg := fmt.Sprintf("Sync+complete:+%d+new,+%d+updated,+%d+unchanged", result.New, result.Updated, result.Unchanged)
+	msg := fmt.Sprintf("Sync+complete:+%d+new,+%d+updated,+%d+unchanged;+%d+draws,+%d+matches,+%d+players+matched,+%d+unmatched",
+		result.New, result.Updated, result.Unchanged, result.Draws, result.Matches, result.MatchedPlayers, len(result.UnmatchedParticipants))
+	if len(result.UnmatchedParticipants) > 0 {
+		log.Printf("CourtHive sync for provider %d: unmatched participants: %s", providerID, strings.Join(result.UnmatchedParticipants, ", "))
+	}
+	if len(result.DrawErrors) > 0 {
+		log.Printf("CourtHive sync for provider %d: draws failed: %s", providerID, strings.Join(result.DrawErrors, "; "))
+		msg += fmt.Sprintf(";+draws+failed+for+%d+tournaments", len(result.DrawErrors))
+	}
 	http.Redirect(w, r, "/admin/league/tournaments?success="+msg, http.StatusSeeOther)
 }
+
+// --- Entry routes ---
+
+// HandleEntries handles a tournament's events and entries:
+// GET shows them, GET .../export.csv downloads the approved entries, and
+// POST creates or deletes an event, approves or rejects an entry, or exports
+// approved entries to CourtHive
+func (h *TournamentsHandler) HandleEntries(w http.ResponseWriter, r *http.Request) {
+	_, err := getUserFromContext(r)
+	if err != nil {
+		logAndError(w, "Unauthorized", err, http.StatusUnauthorized)
+		return
+	}
+
+	tournamentID, err := parseIDFromPath(r.URL.Path, "/admin/league/tournaments/entries/")
+	if err != nil {
+		http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/export.csv"):
+		h.handleEntriesCSV(w, r, tournamentID)
+	case r.Method == http.MethodGet:
+		h.handleEntriesGet(w, r, tournamentID)
+	case r.Method == http.MethodPost:
+		h.handleEntriesPost(w, r, tournamentID)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *TournamentsHandler) handleEntriesGet(w http.ResponseWriter, r *http.Request, tournamentID uint) {
+	tournament, err := h.service.GetTournamentByID(tournamentID)
+	if err != nil {
+		logAndError(w, "Tournament not found", err, http.StatusNotFound)
+		return
+	}
+	events, err := h.service.GetTournamentEvents(r.Context(), tournamentID)
+	if err != nil {
+		logAndError(w, "Failed to load events", err, http.StatusInternalServerError)
+		return
+	}
+	entries, err := h.service.GetTournamentEntries(r.Context(), tournamentID)
+	if err != nil {
+		logAndError(w, "Failed to load entries", err, http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Tournament": tournament,
+		"Events":     events,
+		"Entries":    entries,
+		"Success":    r.URL.Query().Get("success"),
+		"Error":      r.URL.Query().Get("error"),
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "admin/tournament_entries.html")
+	if err != nil {
+		logAndError(w, "Failed to parse template", err, http.StatusInternalServerError)
+		return
+	}
+	if err := renderTemplate(w, tmpl, data); err != nil {
+		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TournamentsHandler) handleEntriesPost(w http.ResponseWriter, r *http.Request, tournamentID uint) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	redirect := fmt.Sprintf("/admin/league/tournaments/entries/%d", tournamentID)
+
+	switch r.FormValue("action") {
+	case "create_event":
+		deadline, err := time.ParseInLocation("2006-01-02T15:04", r.FormValue("entry_deadline"), time.Local)
+		name := strings.TrimSpace(r.FormValue("name"))
+		if err != nil || name == "" {
+			http.Redirect(w, r, redirect+"?error=Name+and+entry+deadline+are+required", http.StatusSeeOther)
+			return
+		}
+		event := &models.TournamentEvent{
+			TournamentID:     tournamentID,
+			Name:             name,
+			EventType:        models.TournamentEventType(r.FormValue("event_type")),
+			Gender:           models.TournamentEventGender(r.FormValue("gender")),
+			EntryDeadline:    deadline,
+			CourthiveEventID: strings.TrimSpace(r.FormValue("courthive_event_id")),
+		}
+		if err := h.service.CreateTournamentEvent(r.Context(), event); err != nil {
+			log.Printf("Failed to create tournament event: %v", err)
+			http.Redirect(w, r, redirect+"?error=Failed+to+create+event", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, redirect+"?success=Event+created", http.StatusSeeOther)
+
+	case "delete_event":
+		eventID, _ := strconv.ParseUint(r.FormValue("event_id"), 10, 32)
+		if err := h.service.DeleteTournamentEvent(r.Context(), tournamentID, uint(eventID)); err != nil {
+			log.Printf("Failed to delete tournament event %d: %v", eventID, err)
+			http.Redirect(w, r, redirect+"?error=Failed+to+delete+event", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, redirect+"?success=Event+deleted", http.StatusSeeOther)
+
+	case "approve", "reject":
+		status := models.EntryApproved
+		if r.FormValue("action") == "reject" {
+			status = models.EntryRejected
+		}
+		entryID, _ := strconv.ParseUint(r.FormValue("entry_id"), 10, 32)
+		if err := h.service.SetTournamentEntryStatus(r.Context(), tournamentID, uint(entryID), status); err != nil {
+			log.Printf("Failed to update tournament entry %d: %v", entryID, err)
+			http.Redirect(w, r, redirect+"?error=Failed+to+update+entry", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, redirect+"?success=Entry+"+strings.ToLower(string(status)), http.StatusSeeOther)
+
+	case "export":
+		result, err := h.service.ExportEntriesToCourtHive(r.Context(), tournamentID)
+		if err != nil {
+			log.Printf("CourtHive entry export failed for tournament %d: %v", tournamentID, err)
+			http.Redirect(w, r, redirect+"?error="+url.QueryEscape("Export failed: "+err.Error()), http.StatusSeeOther)
+			return
+		}
+		msg := fmt.Sprintf("Export complete: %d synced, %d removed, %d failed", result.Synced, result.Removed, result.Failed)
+		http.Redirect(w, r, redirect+"?success="+url.QueryEscape(msg), http.StatusSeeOther)
+
+	default:
+		http.Error(w, "Invalid action", http.StatusBadRequest)
+	}
+}
+
+func (h *TournamentsHandler) handleEntriesCSV(w http.ResponseWriter, r *http.Request, tournamentID uint) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tournament-%d-entries.csv"`, tournamentID))
+	if err := h.service.WriteTournamentEntriesCSV(r.Context(), w, tournamentID); err != nil {
+		logAndError(w, "Failed to export entries", err, http.StatusInternalServerError)
+	}
+}
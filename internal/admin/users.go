@@ -55,18 +55,32 @@ func (h *UsersHandler) HandleUsers(w http.ResponseWriter, r *http.Request) {
 
 // handleUsersGet renders the user management page
 func (h *UsersHandler) handleUsersGet(w http.ResponseWriter, r *http.Request, user *models.User) {
+	h.renderUsers(w, r, user, nil)
+}
+
+// renderUsers renders the user management page. issued, when set, is an
+// invitation or reset link to show the admin once; it is rendered directly
+// rather than via a redirect so the token never lands in a URL.
+func (h *UsersHandler) renderUsers(w http.ResponseWriter, r *http.Request, user *models.User, issued *PasswordLinkResult) {
 	users, err := h.service.GetAllUsers()
 	if err != nil {
 		logAndError(w, "Failed to load users", err, http.StatusInternalServerError)
 		return
 	}
 
+	pendingInvites, err := h.service.GetPendingInvites(r.Context())
+	if err != nil {
+		log.Printf("Failed to load pending invitations: %v", err)
+	}
+
 	data := map[string]interface{}{
-		"User":       user,
-		"Users":      users,
-		"Roles":      []string{string(models.RoleAdmin), string(models.RoleCaptain), string(models.RolePlayer)},
-		"SuccessMsg": r.URL.Query().Get("success"),
-		"ErrorMsg":   r.URL.Query().Get("error"),
+		"User":           user,
+		"Users":          users,
+		"Roles":          []string{string(models.RoleAdmin), string(models.RoleCaptain), string(models.RolePlayer)},
+		"PendingInvites": pendingInvites,
+		"IssuedLink":     issued,
+		"SuccessMsg":     r.URL.Query().Get("success"),
+		"ErrorMsg":       r.URL.Query().Get("error"),
 	}
 
 	tmpl, err := parseTemplate(h.templateDir, "admin/users.html")
@@ -75,6 +89,7 @@ func (h *UsersHandler) handleUsersGet(w http.ResponseWriter, r *http.Request, us
 		return
 	}
 
+	w.Header().Set("Cache-Control", "no-store")
 	if err := renderTemplate(w, tmpl, data); err != nil {
 		logAndError(w, "Failed to render template", err, http.StatusInternalServerError)
 	}
@@ -88,11 +103,12 @@ func (h *UsersHandler) handleUsersPost(w http.ResponseWriter, r *http.Request, u
 	}
 
 	username := strings.TrimSpace(r.FormValue("username"))
+	email := strings.TrimSpace(r.FormValue("email"))
 	password := r.FormValue("password")
 	role := models.Role(r.FormValue("role"))
 
-	if username == "" || password == "" {
-		http.Redirect(w, r, "/admin/league/users?error=Username+and+password+are+required", http.StatusSeeOther)
+	if username == "" {
+		http.Redirect(w, r, "/admin/league/users?error=Username+is+required", http.StatusSeeOther)
 		return
 	}
 
@@ -101,7 +117,19 @@ func (h *UsersHandler) handleUsersPost(w http.ResponseWriter, r *http.Request, u
 		return
 	}
 
-	_, err := h.service.CreateUser(username, password, role)
+	// Without a password the new user is invited to choose their own
+	if password == "" {
+		issued, err := h.service.InviteUser(r.Context(), username, email, role, user.Username)
+		if err != nil {
+			log.Printf("Failed to invite user: %v", err)
+			http.Redirect(w, r, "/admin/league/users?error=Failed+to+create+user", http.StatusSeeOther)
+			return
+		}
+		h.renderUsers(w, r, user, issued)
+		return
+	}
+
+	_, err := h.service.CreateUser(username, email, password, role)
 	if err != nil {
 		log.Printf("Failed to create user: %v", err)
 		http.Redirect(w, r, "/admin/league/users?error=Failed+to+create+user", http.StatusSeeOther)
@@ -164,6 +192,41 @@ func (h *UsersHandler) handleUserAction(w http.ResponseWriter, r *http.Request,
 		}
 		http.Redirect(w, r, "/admin/league/users?success=Role+updated+successfully", http.StatusSeeOther)
 
+	case "email":
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		if err := h.service.UpdateUserEmail(r.Context(), targetID, strings.TrimSpace(r.FormValue("email"))); err != nil {
+			log.Printf("Failed to update user email: %v", err)
+			http.Redirect(w, r, "/admin/league/users?error=Failed+to+update+email", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, "/admin/league/users?success=Email+updated", http.StatusSeeOther)
+
+	case "reset-link":
+		issued, err := h.service.IssuePasswordResetLink(r.Context(), targetID, currentUser.Username)
+		if err != nil {
+			log.Printf("Failed to issue reset link: %v", err)
+			http.Redirect(w, r, "/admin/league/users?error=Failed+to+create+reset+link", http.StatusSeeOther)
+			return
+		}
+		h.renderUsers(w, r, currentUser, issued)
+
+	case "reset-2fa":
+		// Admins manage their own 2FA from the account page
+		if targetID == currentUser.ID {
+			http.Redirect(w, r, "/admin/league/users?error=Use+your+two-factor+settings+page+to+change+your+own+2FA", http.StatusSeeOther)
+			return
+		}
+		if err := h.service.ResetUserTwoFactor(r.Context(), targetID); err != nil {
+			log.Printf("Failed to reset two-factor authentication: %v", err)
+			http.Redirect(w, r, "/admin/league/users?error=Failed+to+reset+2FA", http.StatusSeeOther)
+			return
+		}
+		log.Printf("User %s reset two-factor authentication for user %d", currentUser.Username, targetID)
+		http.Redirect(w, r, "/admin/league/users?success=Two-factor+authentication+reset", http.StatusSeeOther)
+
 	case "reset-password":
 		if err := r.ParseForm(); err != nil {
 			http.Error(w, "Invalid form data", http.StatusBadRequest)
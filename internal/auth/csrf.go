@@ -0,0 +1,205 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	// CSRFCookieName holds the current CSRF token. It is readable by page
+	// scripts (static/csrf.js), which copy it into forms and request headers.
+	CSRFCookieName = "csrf_token"
+	// CSRFHeaderName carries the token on HTMX and fetch requests
+	CSRFHeaderName = "X-CSRF-Token"
+	// CSRFFormField carries the token on ordinary form posts
+	CSRFFormField = "csrf_token"
+
+	// csrfSeedCookieName binds the token for visitors without a login
+	// session, such as players using their private link
+	csrfSeedCookieName = "csrf_seed"
+	// csrfMaxFormMemory matches net/http's default for multipart forms
+	csrfMaxFormMemory = 32 << 20
+)
+
+// CSRF protects every state-changing request with a token tied to the
+// caller's session. Logged-in users get a token derived from their session
+// ID; everyone else gets one derived from a random per-browser seed cookie.
+// Either way the token is an HMAC under the link signing key, so it can't be
+// forged or carried over from another session.
+type CSRF struct {
+	service *Service
+	signer  *LinkSigner
+	exempt  []string
+}
+
+// NewCSRF creates the CSRF middleware. Requests whose path starts with one of
+// exemptPrefixes are not checked; use it only for endpoints that carry their
+// own proof, such as signed links, and can't include a token.
+func NewCSRF(service *Service, signer *LinkSigner, exemptPrefixes ...string) *CSRF {
+	return &CSRF{service: service, signer: signer, exempt: exemptPrefixes}
+}
+
+// Protect wraps a handler so unsafe methods must present a valid token, and
+// keeps the token cookie in step with the caller's session
+func (c *CSRF) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seed := ""
+		if cookie, err := r.Cookie(csrfSeedCookieName); err == nil {
+			seed = cookie.Value
+		}
+		if seed == "" {
+			var err error
+			if seed, err = generateSecureToken(32); err != nil {
+				log.Printf("Failed to generate CSRF seed: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			c.setCookie(w, csrfSeedCookieName, seed, true)
+		}
+
+		binding := seed
+		if cookie, err := r.Cookie(c.service.config.CookieName); err == nil && cookie.Value != "" {
+			binding = cookie.Value
+		}
+
+		if !isSafeMethod(r.Method) && !c.isExempt(r.URL.Path) {
+			expected, err := c.token(binding)
+			if err != nil {
+				log.Printf("Failed to compute CSRF token: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			if !hmac.Equal([]byte(submittedCSRFToken(r)), []byte(expected)) {
+				log.Printf("Rejected %s %s: missing or invalid CSRF token", r.Method, r.URL.Path)
+				http.Error(w, "This form has expired. Please reload the page and try again.", http.StatusForbidden)
+				return
+			}
+		}
+
+		current := ""
+		if cookie, err := r.Cookie(CSRFCookieName); err == nil {
+			current = cookie.Value
+		}
+		rw := &csrfResponseWriter{ResponseWriter: w, csrf: c, seed: seed, binding: binding, current: current}
+		next.ServeHTTP(rw, r)
+		if !rw.written {
+			rw.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+// token derives the CSRF token for a session ID or seed
+func (c *CSRF) token(binding string) (string, error) {
+	key, err := c.signer.signingKey()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(linkMAC(key, "csrf|"+binding)), nil
+}
+
+// isExempt reports whether a path is excused from CSRF checks
+func (c *CSRF) isExempt(path string) bool {
+	for _, prefix := range c.exempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// setCookie writes one of the CSRF cookies. The seed is HttpOnly; the token
+// must be readable by page scripts.
+func (c *CSRF) setCookie(w http.ResponseWriter, name, value string, httpOnly bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: httpOnly,
+		Secure:   c.service.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// isSafeMethod reports whether a method must not change state
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// submittedCSRFToken reads the token from the header, falling back to the form
+func submittedCSRFToken(r *http.Request) string {
+	if token := r.Header.Get(CSRFHeaderName); token != "" {
+		return token
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(csrfMaxFormMemory); err != nil {
+			return ""
+		}
+	}
+	return r.PostFormValue(CSRFFormField)
+}
+
+// csrfResponseWriter refreshes the token cookie just before the response is
+// sent. Waiting until then means a handler that logs the user in or out in
+// this response gets a token for the session it leaves behind.
+type csrfResponseWriter struct {
+	http.ResponseWriter
+	csrf    *CSRF
+	seed    string
+	binding string
+	current string
+	written bool
+}
+
+func (w *csrfResponseWriter) WriteHeader(status int) {
+	if !w.written {
+		w.written = true
+		w.refreshToken()
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *csrfResponseWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *csrfResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// refreshToken sets the token cookie if the session it is bound to has changed
+func (w *csrfResponseWriter) refreshToken() {
+	binding := w.binding
+	response := http.Response{Header: w.Header()}
+	for _, cookie := range response.Cookies() {
+		if cookie.Name != w.csrf.service.config.CookieName {
+			continue
+		}
+		if cookie.Value == "" || cookie.MaxAge < 0 {
+			binding = w.seed
+		} else {
+			binding = cookie.Value
+		}
+	}
+
+	token, err := w.csrf.token(binding)
+	if err != nil {
+		log.Printf("Failed to compute CSRF token: %v", err)
+		return
+	}
+	if token != w.current {
+		w.csrf.setCookie(w.ResponseWriter, CSRFCookieName, token, false)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Unsafe requests need the token for the caller's session, sent as a form
+// field or header; logging in within a response re-issues the token for the
+// new session, so the old one stops working.
+func TestCSRFProtect(t *testing.T) {
+	t.Setenv("LINK_SIGNING_SECRET", "test-secret")
+	s := newTestService(t, DefaultConfig())
+	csrf := NewCSRF(s, NewLinkSigner(s.db), "/push-action/")
+
+	handler := csrf.Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" && r.Method == http.MethodPost {
+			http.SetCookie(w, &http.Cookie{Name: s.config.CookieName, Value: "new-session"})
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(req *http.Request, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	cookieFrom := func(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+		for _, cookie := range rec.Result().Cookies() {
+			if cookie.Name == name {
+				return cookie
+			}
+		}
+		return nil
+	}
+	form := func(path, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(url.Values{CSRFFormField: {token}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	first := serve(httptest.NewRequest(http.MethodGet, "/login", nil))
+	seed, token := cookieFrom(first, csrfSeedCookieName), cookieFrom(first, CSRFCookieName)
+	if seed == nil || !seed.HttpOnly || token == nil || token.HttpOnly {
+		t.Fatalf("first visit cookies: seed %+v, token %+v", seed, token)
+	}
+
+	if rec := serve(form("/login", ""), seed); rec.Code != http.StatusForbidden {
+		t.Errorf("post without token: status %d, want 403", rec.Code)
+	}
+	if rec := serve(form("/login", "forged"), seed); rec.Code != http.StatusForbidden {
+		t.Errorf("post with forged token: status %d, want 403", rec.Code)
+	}
+	if rec := serve(httptest.NewRequest(http.MethodPost, "/push-action/availability/x", nil)); rec.Code != http.StatusOK {
+		t.Errorf("exempt path: status %d, want 200", rec.Code)
+	}
+
+	login := serve(form("/login", token.Value), seed)
+	if login.Code != http.StatusOK {
+		t.Fatalf("post with token: status %d, want 200", login.Code)
+	}
+	sessionToken := cookieFrom(login, CSRFCookieName)
+	if sessionToken == nil || sessionToken.Value == token.Value {
+		t.Fatalf("login did not re-issue the token: %+v", sessionToken)
+	}
+
+	session := &http.Cookie{Name: s.config.CookieName, Value: "new-session"}
+	if rec := serve(form("/admin/league/seasons/delete", token.Value), seed, session); rec.Code != http.StatusForbidden {
+		t.Errorf("pre-login token after login: status %d, want 403", rec.Code)
+	}
+	htmx := httptest.NewRequest(http.MethodPost, "/admin/league/tournaments/toggle-visibility/1", nil)
+	htmx.Header.Set(CSRFHeaderName, sessionToken.Value)
+	if rec := serve(htmx, seed, session); rec.Code != http.StatusOK {
+		t.Errorf("HTMX post with header token: status %d, want 200", rec.Code)
+	}
+}
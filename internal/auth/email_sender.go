@@ -0,0 +1,87 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+// SMTPLinkSender emails invitation and reset links. Users with no email
+// address on file are left for an admin to contact by hand.
+type SMTPLinkSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	// BaseURL is prefixed to link paths. It comes from configuration rather
+	// than the request so a forged Host header can't redirect reset links.
+	BaseURL string
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewPasswordLinkSenderFromEnv returns an SMTP sender when SMTP_HOST,
+// SMTP_FROM and APP_BASE_URL are all set, otherwise a ManualLinkSender
+func NewPasswordLinkSenderFromEnv() PasswordLinkSender {
+	host, from, baseURL := os.Getenv("SMTP_HOST"), os.Getenv("SMTP_FROM"), os.Getenv("APP_BASE_URL")
+	if host == "" || from == "" || baseURL == "" {
+		return ManualLinkSender{}
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return &SMTPLinkSender{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		sendMail: smtp.SendMail,
+	}
+}
+
+// SendPasswordLink implements PasswordLinkSender
+func (s *SMTPLinkSender) SendPasswordLink(ctx context.Context, msg PasswordLinkMessage) (bool, error) {
+	if msg.Email == "" {
+		return false, nil
+	}
+	if strings.ContainsAny(msg.Email, "\r\n") {
+		return false, fmt.Errorf("invalid email address for %s", msg.Username)
+	}
+
+	subject := "Reset your Jim.Tennis password"
+	intro := "Someone (hopefully you) asked to reset the password for your Jim.Tennis account."
+	if msg.Purpose == PasswordTokenInvite {
+		subject = "You've been invited to Jim.Tennis"
+		intro = "An admin has created a Jim.Tennis account for you."
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", s.From)
+	fmt.Fprintf(&body, "To: %s\r\n", msg.Email)
+	fmt.Fprintf(&body, "Subject: %s\r\n", subject)
+	body.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&body, "Hi %s,\r\n\r\n%s\r\n\r\n", msg.Username, intro)
+	fmt.Fprintf(&body, "Choose your password here:\r\n%s%s\r\n\r\n", s.BaseURL, msg.Path)
+	fmt.Fprintf(&body, "This link works once and expires at %s.\r\n", msg.ExpiresAt.Local().Format("15:04 on Monday 2 January"))
+	if msg.Purpose == PasswordTokenReset {
+		body.WriteString("If you didn't ask for this, you can ignore this email.\r\n")
+	}
+
+	var smtpAuth smtp.Auth
+	if s.Username != "" {
+		smtpAuth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	if err := s.sendMail(net.JoinHostPort(s.Host, s.Port), smtpAuth, s.From, []string{msg.Email}, []byte(body.String())); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -3,11 +3,8 @@
 package auth
 
 import (
-	"html/template"
 	"log"
 	"net/http"
-	"path/filepath"
-	"time"
 )
 
 // Handler provides HTTP handlers for auth-related routes
@@ -29,8 +26,10 @@ func NewHandler(service *Service, templateDir string, redirectPath string) *Hand
 // LoginHandler handles the login page and form submission
 func (h *Handler) LoginHandler() http.HandlerFunc {
 	type loginData struct {
-		Error    string
-		Username string
+		Error          string
+		Notice         string
+		Username       string
+		ForgotPassword bool
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -41,7 +40,11 @@ func (h *Handler) LoginHandler() http.HandlerFunc {
 		if err == nil {
 			log.Printf("Found existing cookie: %s", redactToken(cookie.Value))
 			// Validate the session
-			_, err := h.service.ValidateSession(cookie.Value, r)
+			session, err := h.service.ValidateSession(cookie.Value, r)
+			if err == nil && session.MFAPending {
+				http.Redirect(w, r, twoFactorLoginPath, http.StatusSeeOther)
+				return
+			}
 			if err == nil {
 				log.Printf("Valid session found, redirecting to: %s", h.redirectPath)
 				// Valid session, redirect to the target page
@@ -58,28 +61,11 @@ func (h *Handler) LoginHandler() http.HandlerFunc {
 		// GET request - show login form
 		if r.Method == http.MethodGet {
 			log.Printf("Processing GET request for login page")
-			// Create template with functions
-			funcMap := template.FuncMap{
-				"currentYear": func() int {
-					return time.Now().Year()
-				},
-			}
-
-			tmpl, err := template.New("").Funcs(funcMap).ParseFiles(
-				filepath.Join(h.templateDir, "layout.html"),
-				filepath.Join(h.templateDir, "login.html"),
-			)
-			if err != nil {
-				log.Printf("Error parsing template: %v", err)
-				http.Error(w, "Internal server error", http.StatusInternalServerError)
-				return
-			}
-
-			data := loginData{}
-			if err := tmpl.ExecuteTemplate(w, "login.html", data); err != nil {
-				log.Printf("Error executing template: %v", err)
-				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			data := loginData{ForgotPassword: h.service.passwordTokens.SelfServiceEnabled()}
+			if r.URL.Query().Get("password") == "set" {
+				data.Notice = "Your password has been set. Please log in."
 			}
+			h.renderPage(w, "login.html", data)
 			return
 		}
 
@@ -101,31 +87,11 @@ func (h *Handler) LoginHandler() http.HandlerFunc {
 				log.Printf("Login failed: %v", err)
 
 				data := loginData{
-					Error:    err.Error(),
-					Username: username,
-				}
-
-				// Create template with functions
-				funcMap := template.FuncMap{
-					"currentYear": func() int {
-						return time.Now().Year()
-					},
-				}
-
-				tmpl, err := template.New("").Funcs(funcMap).ParseFiles(
-					filepath.Join(h.templateDir, "layout.html"),
-					filepath.Join(h.templateDir, "login.html"),
-				)
-				if err != nil {
-					log.Printf("Error parsing template: %v", err)
-					http.Error(w, "Internal server error", http.StatusInternalServerError)
-					return
-				}
-
-				if err := tmpl.ExecuteTemplate(w, "login.html", data); err != nil {
-					log.Printf("Error executing template: %v", err)
-					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					Error:          err.Error(),
+					Username:       username,
+					ForgotPassword: h.service.passwordTokens.SelfServiceEnabled(),
 				}
+				h.renderPage(w, "login.html", data)
 				return
 			}
 
@@ -138,9 +104,12 @@ func (h *Handler) LoginHandler() http.HandlerFunc {
 
 			// Check for redirect parameter from URL
 			redirectTo := r.URL.Query().Get("redirect")
-			if redirectTo == "" {
-				redirectTo = h.redirectPath
+			if session.MFAPending {
+				log.Printf("Password accepted for user: %s, asking for two-factor code", username)
+				http.Redirect(w, r, withRedirect(twoFactorLoginPath, redirectTo), http.StatusSeeOther)
+				return
 			}
+			redirectTo = localRedirect(redirectTo, h.redirectPath)
 			if redirectTo == "" {
 				log.Printf("WARNING: redirectPath is empty, defaulting to /admin/league")
 				redirectTo = "/admin/league"
@@ -182,4 +151,12 @@ func (h *Handler) LogoutHandler() http.HandlerFunc {
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/login", h.LoginHandler())
 	mux.HandleFunc("/logout", h.LogoutHandler())
+	mux.HandleFunc("/forgot-password", h.ForgotPasswordHandler())
+	mux.HandleFunc(passwordTokenPathPrefix, h.SetPasswordHandler())
+	mux.HandleFunc(twoFactorLoginPath, h.TwoFactorLoginHandler())
+}
+
+// RegisterProtectedRoutes registers auth routes that need a logged-in user
+func (h *Handler) RegisterProtectedRoutes(mux *http.ServeMux, middleware *Middleware) {
+	mux.Handle(twoFactorAccountPath, middleware.RequireAuth(h.TwoFactorAccountHandler()))
 }
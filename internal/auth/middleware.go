@@ -8,8 +8,10 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/ratelimit"
 	"jim-dot-tennis/internal/repository"
 )
 
@@ -30,14 +32,16 @@ type Middleware struct {
 	service          *Service
 	playerRepo       repository.PlayerRepository
 	fantasyMatchRepo repository.FantasyMixedDoublesRepository
+	playerTokenRepo  repository.PlayerTokenRepository
 }
 
 // NewMiddleware creates a new auth middleware
-func NewMiddleware(service *Service, playerRepo repository.PlayerRepository, fantasyMatchRepo repository.FantasyMixedDoublesRepository) *Middleware {
+func NewMiddleware(service *Service, playerRepo repository.PlayerRepository, fantasyMatchRepo repository.FantasyMixedDoublesRepository, playerTokenRepo repository.PlayerTokenRepository) *Middleware {
 	return &Middleware{
 		service:          service,
 		playerRepo:       playerRepo,
 		fantasyMatchRepo: fantasyMatchRepo,
+		playerTokenRepo:  playerTokenRepo,
 	}
 }
 
@@ -51,8 +55,7 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 		if err != nil {
 			log.Printf("No session cookie found: %v", err)
 			// Redirect to login with the original URL as a redirect parameter
-			loginURL := "/login?redirect=" + r.URL.Path
-			http.Redirect(w, r, loginURL, http.StatusSeeOther)
+			http.Redirect(w, r, withRedirect("/login", r.URL.Path), http.StatusSeeOther)
 			return
 		}
 		log.Printf("Found session cookie: %s", redactToken(cookie.Value))
@@ -65,12 +68,17 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 			// Clear cookie if session is invalid or expired
 			m.service.ClearSessionCookie(w)
 			// Redirect to login with the original URL as a redirect parameter
-			loginURL := "/login?redirect=" + r.URL.Path
-			http.Redirect(w, r, loginURL, http.StatusSeeOther)
+			http.Redirect(w, r, withRedirect("/login", r.URL.Path), http.StatusSeeOther)
 			return
 		}
 		log.Printf("Session validated: %s (User ID: %d, Role: %s)", redactToken(session.ID), session.UserID, session.Role)
 
+		// A password alone only gets as far as the two-factor prompt
+		if session.MFAPending {
+			http.Redirect(w, r, withRedirect(twoFactorLoginPath, r.URL.Path), http.StatusSeeOther)
+			return
+		}
+
 		// Get user details
 		var user models.User
 		err = m.service.db.Get(&user, `
@@ -86,6 +94,13 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 		}
 		log.Printf("User details retrieved: ID=%d, Username=%s, Role=%s", user.ID, user.Username, user.Role)
 
+		// Users whose role requires 2FA must enrol before going anywhere else
+		if m.service.TwoFactorRequired(user) && !user.TwoFactorEnabled() && r.URL.Path != twoFactorAccountPath {
+			log.Printf("User %s must enrol in two-factor authentication", user.Username)
+			http.Redirect(w, r, twoFactorAccountPath+"?required=1", http.StatusSeeOther)
+			return
+		}
+
 		// Add user and role to request context
 		ctx := context.WithValue(r.Context(), UserContextKey, user)
 		ctx = context.WithValue(ctx, RoleContextKey, user.Role)
@@ -166,14 +181,23 @@ func (m *Middleware) RequireFantasyTokenAuth(next http.Handler) http.Handler {
 		// Find the fantasy match by auth token
 		ctx := r.Context()
 		fantasyMatch, err := m.fantasyMatchRepo.FindByAuthToken(ctx, authToken)
+		if err != nil || !fantasyMatch.IsActive {
+			// A rotated link either redirects to the player's new one or is refused
+			if retired, retiredErr := m.playerTokenRepo.FindRetired(ctx, authToken); retiredErr == nil {
+				m.handleRetiredToken(w, r, retired, authToken)
+				return
+			}
+		}
 		if err != nil {
 			log.Printf("Fantasy match not found for token %s: %v", authToken, err)
+			ratelimit.ReportFailure(ctx)
 			http.Error(w, "Invalid fantasy match token", http.StatusNotFound)
 			return
 		}
 
 		if !fantasyMatch.IsActive {
 			log.Printf("Fantasy match is inactive for token %s", authToken)
+			ratelimit.ReportFailure(ctx)
 			http.Error(w, "Fantasy match is not active", http.StatusForbidden)
 			return
 		}
@@ -183,10 +207,12 @@ func (m *Middleware) RequireFantasyTokenAuth(next http.Handler) http.Handler {
 		player, err := m.playerRepo.FindByFantasyMatchID(ctx, fantasyMatch.ID)
 		if err != nil {
 			log.Printf("No player found for fantasy match ID %d: %v", fantasyMatch.ID, err)
+			ratelimit.ReportFailure(ctx)
 			http.Error(w, "No player assigned to this fantasy match", http.StatusNotFound)
 			return
 		}
 		log.Printf("Found assigned player: %s %s (ID: %s)", player.FirstName, player.LastName, player.ID)
+		m.recordTokenAccess(r, player.ID, authToken)
 
 		// Add player to request context
 		ctx = context.WithValue(ctx, PlayerContextKey, *player)
@@ -197,6 +223,51 @@ func (m *Middleware) RequireFantasyTokenAuth(next http.Handler) http.Handler {
 	})
 }
 
+// replacedLinkMessage is shown when a rotated-out link is used after its grace period
+const replacedLinkMessage = "This link has been replaced. Please ask your captain for your new link."
+
+// handleRetiredToken answers a request made with a rotated-out link. Within
+// the grace period it redirects to the same page under the player's current
+// link; afterwards the link is gone for good.
+func (m *Middleware) handleRetiredToken(w http.ResponseWriter, r *http.Request, retired *models.RetiredPlayerToken, authToken string) {
+	ctx := r.Context()
+	if !retired.InGrace(time.Now()) {
+		log.Printf("Refused retired fantasy token %s for player %s", authToken, retired.PlayerID)
+		ratelimit.ReportFailure(ctx)
+		http.Error(w, replacedLinkMessage, http.StatusGone)
+		return
+	}
+
+	player, err := m.playerRepo.FindByID(ctx, retired.PlayerID)
+	if err != nil || player.FantasyMatchID == nil {
+		ratelimit.ReportFailure(ctx)
+		http.Error(w, replacedLinkMessage, http.StatusGone)
+		return
+	}
+	current, err := m.fantasyMatchRepo.FindByID(ctx, *player.FantasyMatchID)
+	if err != nil || !current.IsActive {
+		ratelimit.ReportFailure(ctx)
+		http.Error(w, replacedLinkMessage, http.StatusGone)
+		return
+	}
+
+	m.recordTokenAccess(r, player.ID, authToken)
+
+	target := *r.URL
+	target.Path = strings.Replace(r.URL.Path, "/"+authToken, "/"+current.AuthToken, 1)
+	target.RawPath = ""
+	log.Printf("Redirecting retired fantasy token for player %s to current link", player.ID)
+	http.Redirect(w, r, target.RequestURI(), http.StatusFound)
+}
+
+// recordTokenAccess notes which device used a player link; failures are
+// logged rather than blocking the player
+func (m *Middleware) recordTokenAccess(r *http.Request, playerID, authToken string) {
+	if err := m.playerTokenRepo.RecordAccess(r.Context(), playerID, authToken, r.UserAgent(), r.RemoteAddr); err != nil {
+		log.Printf("Failed to record link access for player %s: %v", playerID, err)
+	}
+}
+
 // extractFantasyTokenFromPath extracts the fantasy auth token from URL paths
 // Expected format: /my-availability/Sabalenka_Djokovic_Gauff_Sinner or /my-profile/Sabalenka_Djokovic_Gauff_Sinner
 func extractFantasyTokenFromPath(path string) string {
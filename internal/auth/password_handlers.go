@@ -0,0 +1,149 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/templates"
+)
+
+// authTemplates caches the standalone auth pages, each parsed together with
+// layout.html
+var authTemplates = templates.NewSet("auth", parseAuthPage,
+	"login.html", "login_2fa.html", "account_2fa.html", "forgot_password.html", "set_password.html")
+
+// parseAuthPage parses one auth page inside layout.html
+func parseAuthPage(templateDir, name string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"currentYear": func() int {
+			return time.Now().Year()
+		},
+	}
+
+	return template.New("").Funcs(funcMap).ParseFiles(
+		filepath.Join(templateDir, "layout.html"),
+		filepath.Join(templateDir, name),
+	)
+}
+
+// renderPage renders one of the standalone auth pages inside layout.html
+func (h *Handler) renderPage(w http.ResponseWriter, name string, data interface{}) {
+	tmpl, err := authTemplates.Get(h.templateDir, name)
+	if err != nil {
+		log.Printf("Error parsing template %s: %v", name, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := tmpl.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("Error executing template %s: %v", name, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ForgotPasswordHandler handles GET/POST /forgot-password. The response is
+// the same whether or not the account exists. When no email sender is
+// configured the page only explains how to get a link from an admin.
+func (h *Handler) ForgotPasswordHandler() http.HandlerFunc {
+	type forgotData struct {
+		Sent     bool
+		Disabled bool
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.service.passwordTokens.SelfServiceEnabled() && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			h.renderPage(w, "forgot_password.html", forgotData{Disabled: true})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			h.renderPage(w, "forgot_password.html", forgotData{})
+		case http.MethodPost:
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+			if err := h.service.passwordTokens.RequestReset(r.Context(), r.FormValue("identifier")); err != nil {
+				log.Printf("Password reset request failed: %v", err)
+			}
+			h.renderPage(w, "forgot_password.html", forgotData{Sent: true})
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// SetPasswordHandler handles GET/POST /password/set/{token}, the landing page
+// for both invitation and reset links
+func (h *Handler) SetPasswordHandler() http.HandlerFunc {
+	type setPasswordData struct {
+		Token          string
+		Username       string
+		Invite         bool
+		Error          string
+		MinLength      int
+		ForgotPassword bool
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.URL.Path, passwordTokenPathPrefix)
+		// Keep the token out of any Referer sent by the page
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		stored, user, err := h.service.passwordTokens.Lookup(r.Context(), token)
+		if err != nil {
+			if !errors.Is(err, ErrPasswordTokenInvalid) && !errors.Is(err, ErrPasswordTokenExpired) {
+				log.Printf("Failed to look up password token: %v", err)
+				err = ErrPasswordTokenInvalid
+			}
+			w.WriteHeader(http.StatusGone)
+			h.renderPage(w, "set_password.html", setPasswordData{
+				Error:          err.Error(),
+				ForgotPassword: h.service.passwordTokens.SelfServiceEnabled(),
+			})
+			return
+		}
+		data := setPasswordData{
+			Token:     token,
+			Username:  user.Username,
+			Invite:    stored.Purpose == PasswordTokenInvite,
+			MinLength: MinPasswordLength,
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			h.renderPage(w, "set_password.html", data)
+		case http.MethodPost:
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+			password := r.FormValue("password")
+			if password != r.FormValue("confirm_password") {
+				data.Error = "The passwords don't match"
+				h.renderPage(w, "set_password.html", data)
+				return
+			}
+			if _, err := h.service.passwordTokens.Redeem(r.Context(), token, password); err != nil {
+				if !errors.Is(err, ErrPasswordTooShort) && !errors.Is(err, ErrPasswordTokenInvalid) && !errors.Is(err, ErrPasswordTokenExpired) {
+					log.Printf("Failed to set password for %s: %v", user.Username, err)
+					err = errors.New("failed to set password, please try again")
+				}
+				data.Error = err.Error()
+				h.renderPage(w, "set_password.html", data)
+				return
+			}
+			log.Printf("User %s set their password via %s link", user.Username, stored.Purpose)
+			http.Redirect(w, r, "/login?password=set", http.StatusSeeOther)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordTokenPurpose says what a password token lets its holder do
+type PasswordTokenPurpose string
+
+const (
+	// PasswordTokenInvite lets a newly created user choose their first password
+	PasswordTokenInvite PasswordTokenPurpose = "invite"
+	// PasswordTokenReset lets an existing user replace a forgotten password
+	PasswordTokenReset PasswordTokenPurpose = "reset"
+)
+
+const (
+	// InviteTokenLifetime is how long an invitation link stays valid
+	InviteTokenLifetime = 7 * 24 * time.Hour
+	// ResetTokenLifetime is how long a password reset link stays valid
+	ResetTokenLifetime = time.Hour
+	// MinPasswordLength is the shortest password a user may choose for themselves
+	MinPasswordLength = 10
+	// PasswordTokenCreatedBySelf marks a reset requested from the login page
+	PasswordTokenCreatedBySelf = "self"
+
+	passwordTokenPathPrefix = "/password/set/"
+
+	// userColumns are the users columns loaded into a models.User
+	userColumns = `id, username, email, password_hash, role, player_id, is_active, created_at, last_login_at,
+		totp_secret, totp_enabled_at, totp_last_step`
+)
+
+var (
+	ErrPasswordTokenInvalid = errors.New("this link is invalid or has already been used")
+	ErrPasswordTokenExpired = errors.New("this link has expired")
+	ErrPasswordTooShort     = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+)
+
+// PasswordToken is a stored invitation or reset token. Only its hash is kept.
+type PasswordToken struct {
+	ID        int64                `db:"id"`
+	UserID    int64                `db:"user_id"`
+	Purpose   PasswordTokenPurpose `db:"purpose"`
+	TokenHash string               `db:"token_hash"`
+	CreatedBy string               `db:"created_by"`
+	ExpiresAt time.Time            `db:"expires_at"`
+	UsedAt    *time.Time           `db:"used_at"`
+	CreatedAt time.Time            `db:"created_at"`
+}
+
+// PasswordLinkMessage is an invitation or reset link ready to be delivered
+type PasswordLinkMessage struct {
+	Username  string
+	Email     string
+	Purpose   PasswordTokenPurpose
+	Path      string // e.g. /password/set/{token}; senders add their own base URL
+	ExpiresAt time.Time
+}
+
+// PasswordLinkSender delivers invitation and reset links. It reports whether
+// the link actually reached the user; when it didn't, the caller must show
+// the link to an admin to pass on by hand.
+type PasswordLinkSender interface {
+	SendPasswordLink(ctx context.Context, msg PasswordLinkMessage) (delivered bool, err error)
+}
+
+// ManualLinkSender delivers nothing: links are shown to the admin for copying
+type ManualLinkSender struct{}
+
+// SendPasswordLink implements PasswordLinkSender
+func (ManualLinkSender) SendPasswordLink(ctx context.Context, msg PasswordLinkMessage) (bool, error) {
+	return false, nil
+}
+
+// PasswordTokens issues and redeems single-use, time-limited password tokens
+// for the invitation and forgot-password flows
+type PasswordTokens struct {
+	db     *database.DB
+	sender PasswordLinkSender
+	now    func() time.Time
+}
+
+// NewPasswordTokens creates a password token store delivering links through
+// sender. A nil sender means links are only ever shown to admins.
+func NewPasswordTokens(db *database.DB, sender PasswordLinkSender) *PasswordTokens {
+	if sender == nil {
+		sender = ManualLinkSender{}
+	}
+	return &PasswordTokens{db: db, sender: sender, now: time.Now}
+}
+
+// SelfServiceEnabled reports whether users can be sent a reset link they ask
+// for themselves. Without a sender that delivers, a self-requested link could
+// never reach anyone, so forgot-password is switched off.
+func (p *PasswordTokens) SelfServiceEnabled() bool {
+	_, manual := p.sender.(ManualLinkSender)
+	return !manual
+}
+
+// Issue creates a new token for the user, replacing any unused token they
+// already hold for the same purpose, and returns the link path carrying it.
+// A self-requested reset only replaces earlier self-requested ones, so
+// nobody can cancel a link an admin has sent by asking for a reset in the
+// user's name.
+func (p *PasswordTokens) Issue(ctx context.Context, userID int64, purpose PasswordTokenPurpose, createdBy string) (string, time.Time, error) {
+	lifetime := ResetTokenLifetime
+	if purpose == PasswordTokenInvite {
+		lifetime = InviteTokenLifetime
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	now := p.now().UTC()
+	expires := now.Add(lifetime)
+
+	replace := `UPDATE password_tokens SET used_at = ? WHERE user_id = ? AND purpose = ? AND used_at IS NULL`
+	args := []interface{}{now, userID, purpose}
+	if createdBy == PasswordTokenCreatedBySelf {
+		replace += ` AND created_by = ?`
+		args = append(args, createdBy)
+	}
+	// Retiring the old tokens and storing the new one happen together, so a
+	// failed insert never leaves the user without a working link
+	err := p.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := p.db.ExecContext(ctx, replace, args...); err != nil {
+			return err
+		}
+		if _, err := p.db.ExecContext(ctx, `
+			INSERT INTO password_tokens (user_id, purpose, token_hash, created_by, expires_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, userID, purpose, hashPasswordToken(token), createdBy, expires, now); err != nil {
+			return fmt.Errorf("failed to store token: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return passwordTokenPathPrefix + token, expires, nil
+}
+
+// Deliver hands a link to the configured sender
+func (p *PasswordTokens) Deliver(ctx context.Context, user models.User, purpose PasswordTokenPurpose, path string, expires time.Time) (bool, error) {
+	return p.sender.SendPasswordLink(ctx, PasswordLinkMessage{
+		Username:  user.Username,
+		Email:     user.Email,
+		Purpose:   purpose,
+		Path:      path,
+		ExpiresAt: expires,
+	})
+}
+
+// Lookup returns the token and its user if the token can still be redeemed
+func (p *PasswordTokens) Lookup(ctx context.Context, token string) (*PasswordToken, *models.User, error) {
+	var stored PasswordToken
+	err := p.db.GetContext(ctx, &stored, `
+		SELECT id, user_id, purpose, token_hash, created_by, expires_at, used_at, created_at
+		FROM password_tokens
+		WHERE token_hash = ?
+	`, hashPasswordToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrPasswordTokenInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if stored.UsedAt != nil {
+		return nil, nil, ErrPasswordTokenInvalid
+	}
+	if !p.now().Before(stored.ExpiresAt) {
+		return nil, nil, ErrPasswordTokenExpired
+	}
+
+	var user models.User
+	if err := p.db.GetContext(ctx, &user, `SELECT `+userColumns+` FROM users WHERE id = ? AND is_active = true`, stored.UserID); err != nil {
+		return nil, nil, ErrPasswordTokenInvalid
+	}
+	return &stored, &user, nil
+}
+
+// Redeem sets the user's password from a token and burns the token. A reset
+// also signs the user out everywhere and forgets their remembered devices, in
+// case the old password was stolen.
+func (p *PasswordTokens) Redeem(ctx context.Context, token, newPassword string) (*models.User, error) {
+	if len(newPassword) < MinPasswordLength {
+		return nil, ErrPasswordTooShort
+	}
+	stored, user, err := p.Lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Claim the token first so two concurrent submissions can't both succeed
+	result, err := tx.ExecContext(ctx, `
+		UPDATE password_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL
+	`, p.now().UTC(), stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := result.RowsAffected(); n != 1 {
+		return nil, ErrPasswordTokenInvalid
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ?`, string(hashed), user.ID); err != nil {
+		return nil, err
+	}
+	if stored.Purpose == PasswordTokenReset {
+		if _, err := tx.ExecContext(ctx, `UPDATE sessions SET is_valid = false WHERE user_id = ?`, user.ID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM trusted_devices WHERE user_id = ?`, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RequestReset starts the forgot-password flow for a username or email. It
+// never reports whether an account matched, so the login page can't be used
+// to discover usernames. Users without a deliverable address need an admin to
+// send them a link, so no token is issued for them.
+func (p *PasswordTokens) RequestReset(ctx context.Context, identifier string) error {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" || !p.SelfServiceEnabled() {
+		return nil
+	}
+
+	var user models.User
+	err := p.db.GetContext(ctx, &user, `
+		SELECT `+userColumns+` FROM users
+		WHERE is_active = true AND (username = ? OR (email != '' AND lower(email) = lower(?)))
+		LIMIT 1
+	`, identifier, identifier)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Password reset requested for unknown account %q", identifier)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		log.Printf("Password reset requested for %s, who has no email address; an admin must issue a link", user.Username)
+		return nil
+	}
+
+	path, expires, err := p.Issue(ctx, user.ID, PasswordTokenReset, PasswordTokenCreatedBySelf)
+	if err != nil {
+		return err
+	}
+	delivered, err := p.Deliver(ctx, user, PasswordTokenReset, path, expires)
+	if err != nil {
+		return fmt.Errorf("failed to send reset link: %w", err)
+	}
+	if !delivered {
+		log.Printf("Password reset requested for %s but no email could be sent; an admin must issue a link", user.Username)
+	}
+	return nil
+}
+
+// PendingInvites returns, per user ID, when their outstanding invitation expires
+func (p *PasswordTokens) PendingInvites(ctx context.Context) (map[int64]time.Time, error) {
+	var tokens []PasswordToken
+	err := p.db.SelectContext(ctx, &tokens, `
+		SELECT id, user_id, purpose, token_hash, created_by, expires_at, used_at, created_at
+		FROM password_tokens
+		WHERE purpose = ? AND used_at IS NULL AND expires_at > ?
+	`, PasswordTokenInvite, p.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[int64]time.Time, len(tokens))
+	for _, t := range tokens {
+		pending[t.UserID] = t.ExpiresAt
+	}
+	return pending, nil
+}
+
+// hashPasswordToken returns the stored form of a raw token
+func hashPasswordToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// recordingSender captures links instead of delivering them
+type recordingSender struct {
+	sent []PasswordLinkMessage
+}
+
+func (r *recordingSender) SendPasswordLink(ctx context.Context, msg PasswordLinkMessage) (bool, error) {
+	r.sent = append(r.sent, msg)
+	return msg.Email != "", nil
+}
+
+// A reset link must work exactly once, sign the user out everywhere, and
+// expire on schedule; the forgot-password form must not reveal whether an
+// account exists, cancel a link an admin sent, or issue links nobody can
+// receive.
+func TestPasswordResetTokenLifecycle(t *testing.T) {
+	s := newTestService(t, DefaultConfig())
+	ctx := context.Background()
+	if _, err := s.db.Exec(`UPDATE users SET email = 'captain@example.com' WHERE id = 1`); err != nil {
+		t.Fatalf("set email: %v", err)
+	}
+	insertSession(t, s, "existing-session", time.Now(), time.Now().Add(time.Hour))
+
+	sender := &recordingSender{}
+	tokens := NewPasswordTokens(s.db, sender)
+
+	if err := tokens.RequestReset(ctx, "nobody-here"); err != nil {
+		t.Fatalf("unknown account: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("unknown account sent %d links", len(sender.sent))
+	}
+
+	if err := tokens.RequestReset(ctx, "CAPTAIN@example.com"); err != nil {
+		t.Fatalf("request reset: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Purpose != PasswordTokenReset {
+		t.Fatalf("sent = %+v, want one reset link", sender.sent)
+	}
+	token := strings.TrimPrefix(sender.sent[0].Path, passwordTokenPathPrefix)
+
+	var stored string
+	if err := s.db.Get(&stored, `SELECT token_hash FROM password_tokens WHERE user_id = 1`); err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	if stored == token || strings.Contains(stored, token) {
+		t.Errorf("raw token stored in the database")
+	}
+
+	if _, err := tokens.Redeem(ctx, token, "short"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("short password: err = %v, want ErrPasswordTooShort", err)
+	}
+	if _, err := tokens.Redeem(ctx, token, "a much longer password"); err != nil {
+		t.Fatalf("redeem: %v", err)
+	}
+	var hash string
+	if err := s.db.Get(&hash, `SELECT password_hash FROM users WHERE id = 1`); err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte("a much longer password")) != nil {
+		t.Errorf("password was not updated")
+	}
+	var valid bool
+	if err := s.db.Get(&valid, `SELECT is_valid FROM sessions WHERE id = 'existing-session'`); err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	if valid {
+		t.Errorf("existing session survived a password reset")
+	}
+	if _, err := tokens.Redeem(ctx, token, "another long password"); !errors.Is(err, ErrPasswordTokenInvalid) {
+		t.Errorf("second redeem: err = %v, want ErrPasswordTokenInvalid", err)
+	}
+
+	adminPath, _, err := tokens.Issue(ctx, 1, PasswordTokenReset, "admin")
+	if err != nil {
+		t.Fatalf("issue admin reset: %v", err)
+	}
+	if err := tokens.RequestReset(ctx, "captain@example.com"); err != nil {
+		t.Fatalf("request reset over admin link: %v", err)
+	}
+	if _, _, err := tokens.Lookup(ctx, strings.TrimPrefix(adminPath, passwordTokenPathPrefix)); err != nil {
+		t.Errorf("admin-issued link after a self-service request: %v", err)
+	}
+
+	manual := NewPasswordTokens(s.db, nil)
+	if manual.SelfServiceEnabled() {
+		t.Errorf("forgot-password enabled without a mail sender")
+	}
+	var before, after int
+	s.db.Get(&before, `SELECT COUNT(*) FROM password_tokens`)
+	if err := manual.RequestReset(ctx, "captain@example.com"); err != nil {
+		t.Fatalf("request reset without sender: %v", err)
+	}
+	s.db.Get(&after, `SELECT COUNT(*) FROM password_tokens`)
+	if after != before {
+		t.Errorf("reset without a mail sender issued %d tokens", after-before)
+	}
+
+	path, _, err := tokens.Issue(ctx, 1, PasswordTokenInvite, "admin")
+	if err != nil {
+		t.Fatalf("issue invite: %v", err)
+	}
+	tokens.now = func() time.Time { return time.Now().Add(InviteTokenLifetime + time.Minute) }
+	if _, err := tokens.Redeem(ctx, strings.TrimPrefix(path, passwordTokenPathPrefix), "a much longer password"); !errors.Is(err, ErrPasswordTokenExpired) {
+		t.Errorf("expired invite: err = %v, want ErrPasswordTokenExpired", err)
+	}
+}
+
+// A replacement token that can't be stored must leave the user's existing
+// link working
+func TestPasswordTokenIssueKeepsOldTokenOnFailure(t *testing.T) {
+	s := newTestService(t, DefaultConfig())
+	ctx := context.Background()
+	tokens := NewPasswordTokens(s.db, nil)
+
+	path, _, err := tokens.Issue(ctx, 1, PasswordTokenInvite, "admin")
+	if err != nil {
+		t.Fatalf("issue invite: %v", err)
+	}
+	if _, err := s.db.Exec(`
+		CREATE TRIGGER fail_token_insert BEFORE INSERT ON password_tokens
+		WHEN NEW.created_by = 'broken'
+		BEGIN SELECT RAISE(ABORT, 'insert refused'); END
+	`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	if _, _, err := tokens.Issue(ctx, 1, PasswordTokenInvite, "broken"); err == nil {
+		t.Fatalf("issue with a failing insert succeeded")
+	}
+	if _, _, err := tokens.Lookup(ctx, strings.TrimPrefix(path, passwordTokenPathPrefix)); err != nil {
+		t.Errorf("earlier invite after a failed reissue: %v", err)
+	}
+}
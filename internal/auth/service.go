@@ -3,6 +3,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -56,6 +57,9 @@ type Config struct {
 	CookiePath              string
 	MaxLoginAttempts        int
 	LoginAttemptWindow      time.Duration
+	// RequireAdminMFA makes admins enrol in two-factor authentication
+	// before they can use the admin area, and stops them turning it off
+	RequireAdminMFA bool
 }
 
 // DefaultConfig returns the default configuration
@@ -78,6 +82,7 @@ type Service struct {
 	db                     *database.DB
 	config                 Config
 	loginAttemptRepository repository.LoginAttemptRepository
+	passwordTokens         *PasswordTokens
 }
 
 // NewService creates a new auth service
@@ -86,6 +91,7 @@ func NewService(db *database.DB, config Config) *Service {
 		db:                     db,
 		config:                 config,
 		loginAttemptRepository: repository.NewLoginAttemptRepository(db),
+		passwordTokens:         NewPasswordTokens(db, NewPasswordLinkSenderFromEnv()),
 	}
 }
 
@@ -123,25 +129,52 @@ func (s *Service) Login(username, password string, r *http.Request) (*models.Ses
 	}
 	log.Printf("Password verification successful for user %s", username)
 
+	// With 2FA on, the session stays pending until CompleteTwoFactor unless
+	// this browser was remembered after an earlier code
+	if user.TwoFactorEnabled() {
+		device := s.trustedDeviceFor(r, user.ID)
+		if device == nil {
+			log.Printf("Two-factor code required for user %s", username)
+			return s.createSession(user, r, true)
+		}
+		log.Printf("Trusted device %d skips two-factor for user %s", device.ID, username)
+		s.recordLoginAttempt(username, r, true)
+		s.touchLastLogin(user.ID)
+		session, err := s.createSession(user, r, false)
+		if err != nil {
+			return nil, err
+		}
+		s.bindTrustedDevice(device.ID, session.ID)
+		return session, nil
+	}
+
 	// Record successful login attempt
 	s.recordLoginAttempt(username, r, true)
+	s.touchLastLogin(user.ID)
+
+	return s.createSession(user, r, false)
+}
 
-	// Update last login time
+// touchLastLogin records when the user last completed a login
+func (s *Service) touchLastLogin(userID int64) {
 	if _, err := s.db.Exec(`
 		UPDATE users 
 		SET last_login_at = ? 
 		WHERE id = ?
-	`, time.Now(), user.ID); err != nil {
+	`, time.Now(), userID); err != nil {
 		log.Printf("Failed to update last login time: %v", err)
 	}
+}
 
-	// Create new session
+// createSession stores a new session for the user. A pending session only
+// lets its holder enter a two-factor code and expires after MFAPendingDuration.
+func (s *Service) createSession(user models.User, r *http.Request, mfaPending bool) (*models.Session, error) {
 	sessionID, err := generateSecureToken(32)
 	if err != nil {
 		log.Printf("Failed to generate session token: %v", err)
 		return nil, fmt.Errorf("failed to generate session token: %w", err)
 	}
-	log.Printf("Generated new session token for user %s", username)
+	log.Printf("Generated new session token for user %s", user.Username)
 
 	// Extract device info
 	deviceInfo := extractDeviceInfo(r)
@@ -157,23 +190,27 @@ func (s *Service) Login(username, password string, r *http.Request) (*models.Ses
 		UserAgent:      r.UserAgent(),
 		DeviceInfo:     deviceInfo,
 		IsValid:        true,
+		MFAPending:     mfaPending,
+	}
+	if mfaPending {
+		session.ExpiresAt = time.Now().Add(MFAPendingDuration)
 	}
 
 	// Save session to database
 	_, err = s.db.NamedExec(`
 		INSERT INTO sessions (
 			id, user_id, role, created_at, expires_at, 
-			last_activity_at, ip, user_agent, device_info, is_valid
+			last_activity_at, ip, user_agent, device_info, is_valid, mfa_pending
 		) VALUES (
 			:id, :user_id, :role, :created_at, :expires_at, 
-			:last_activity_at, :ip, :user_agent, :device_info, :is_valid
+			:last_activity_at, :ip, :user_agent, :device_info, :is_valid, :mfa_pending
 		)
 	`, session)
 	if err != nil {
 		log.Printf("Failed to create session in database: %v", err)
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
-	log.Printf("Successfully created session in database for user %s", username)
+	log.Printf("Successfully created session in database for user %s", user.Username)
 
 	return session, nil
 }
@@ -222,6 +259,11 @@ func (s *Service) ValidateSession(sessionID string, r *http.Request) (*models.Se
 		}
 	*/
 
+	// A pending session keeps its short expiry until the second factor is in
+	if session.MFAPending {
+		return &session, nil
+	}
+
 	// Update last activity time
 	if _, err := s.db.Exec(`
 		UPDATE sessions 
@@ -247,6 +289,17 @@ func (s *Service) InvalidateSession(sessionID string) error {
 	return err
 }
 
+// CountActiveSessions returns how many signed-in sessions are still valid,
+// not counting logins waiting for a second factor
+func (s *Service) CountActiveSessions(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM sessions
+		WHERE is_valid = true AND mfa_pending = false AND expires_at > CURRENT_TIMESTAMP
+	`)
+	return count, err
+}
+
 // SetSessionCookie sets the session cookie in the response
 func (s *Service) SetSessionCookie(w http.ResponseWriter, session *models.Session) {
 	log.Printf("Setting session cookie: name=%s, value=%s, expires=%v, secure=%v, httpOnly=%v, path=%s",
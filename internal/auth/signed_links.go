@@ -0,0 +1,202 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+)
+
+// Link purposes. A signed link is only accepted by the endpoint for its own
+// purpose, so a link minted for one action can't be replayed against another.
+const (
+	// LinkPurposeAvailabilityAction records one availability answer for one
+	// fixture, from a push notification action button
+	LinkPurposeAvailabilityAction = "availability-action"
+
+	// LinkPurposeDeepLink opens a page in a player's area from a
+	// notification without putting their permanent link in the payload
+	LinkPurposeDeepLink = "deep-link"
+
+	// LinkPurposeWrappedCard shows one season's Wrapped card, the club's or
+	// a player's, to whoever the link is shared with
+	LinkPurposeWrappedCard = "wrapped-card"
+)
+
+// DeepLinkLifetime is how long a notification deep link keeps working
+const DeepLinkLifetime = 72 * time.Hour
+
+var (
+	ErrLinkInvalid = errors.New("invalid signed link")
+	ErrLinkExpired = errors.New("signed link has expired")
+)
+
+// linkSigningSecretName is the app_secrets row (and the upper-cased env var
+// LINK_SIGNING_SECRET) holding the HMAC key for signed links
+const linkSigningSecretName = "link_signing_secret"
+
+// LinkClaims is what a signed link asserts: that Subject may perform Action
+// on Resource, for Purpose, until ExpiresAt
+type LinkClaims struct {
+	Purpose   string `json:"p"`
+	Subject   string `json:"s"`           // Usually a player ID
+	Resource  string `json:"r,omitempty"` // e.g. a fixture ID
+	Action    string `json:"a,omitempty"` // e.g. the availability status to record
+	Token     string `json:"t,omitempty"` // TokenFingerprint of the player link the claims are bound to
+	ExpiresAt int64  `json:"e"`           // Unix seconds
+}
+
+// LinkSigner mints and verifies compact HMAC-signed tokens for links that act
+// without a session. The key comes from LINK_SIGNING_SECRET if set, otherwise
+// it is generated once and stored in app_secrets so every process shares it.
+type LinkSigner struct {
+	db  *database.DB
+	now func() time.Time
+
+	mu  sync.Mutex
+	key []byte
+}
+
+// NewLinkSigner creates a link signer backed by the given database
+func NewLinkSigner(db *database.DB) *LinkSigner {
+	return &LinkSigner{db: db, now: time.Now}
+}
+
+// Sign returns a URL-safe token carrying the claims
+func (s *LinkSigner) Sign(claims LinkClaims) (string, error) {
+	key, err := s.signingKey()
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(linkMAC(key, encoded)), nil
+}
+
+// Verify checks a token's signature, purpose and expiry and returns its claims
+func (s *LinkSigner) Verify(token, purpose string) (*LinkClaims, error) {
+	key, err := s.signingKey()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrLinkInvalid
+	}
+	gotMAC, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMAC, linkMAC(key, encoded)) {
+		return nil, ErrLinkInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrLinkInvalid
+	}
+
+	var claims LinkClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrLinkInvalid
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrLinkInvalid
+	}
+	if s.now().Unix() > claims.ExpiresAt {
+		return nil, ErrLinkExpired
+	}
+	return &claims, nil
+}
+
+// PlayerDeepLink returns a short-lived /go/ link that opens path (relative to
+// the player's /my-availability/ area, e.g. "/fixture/42") under whatever the
+// player's link is when it is followed. The link is bound to a fingerprint of
+// the token current now, so it dies with that token if the link is revoked.
+func (s *LinkSigner) PlayerDeepLink(playerID, token, path string) (string, error) {
+	signed, err := s.Sign(LinkClaims{
+		Purpose:   LinkPurposeDeepLink,
+		Subject:   playerID,
+		Resource:  path,
+		Action:    TokenFingerprint(token),
+		ExpiresAt: s.now().Add(DeepLinkLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return "/go/" + signed, nil
+}
+
+// NotificationLink returns PlayerDeepLink for use in notifications. If
+// signing fails the permanent link is used rather than sending nothing.
+func (s *LinkSigner) NotificationLink(playerID, token, path string) string {
+	link, err := s.PlayerDeepLink(playerID, token, path)
+	if err != nil {
+		log.Printf("Failed to sign deep link for player %s: %v", playerID, err)
+		return "/my-availability/" + token + path
+	}
+	return link
+}
+
+// TokenFingerprint identifies a player token inside signed links without
+// revealing it; the signed payload is encoded, not encrypted
+func TokenFingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// linkMAC computes the HMAC-SHA256 of the encoded payload
+func linkMAC(key []byte, encoded string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// signingKey loads the HMAC key, creating and storing one on first use
+func (s *LinkSigner) signingKey() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key != nil {
+		return s.key, nil
+	}
+	if env := os.Getenv(strings.ToUpper(linkSigningSecretName)); env != "" {
+		s.key = []byte(env)
+		return s.key, nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate link signing secret: %w", err)
+	}
+	// INSERT OR IGNORE then read back, so concurrent first uses agree on one key
+	if _, err := s.db.Exec(
+		"INSERT OR IGNORE INTO app_secrets (name, value) VALUES ($1, $2)",
+		linkSigningSecretName, base64.StdEncoding.EncodeToString(secret),
+	); err != nil {
+		return nil, fmt.Errorf("failed to store link signing secret: %w", err)
+	}
+
+	var stored string
+	if err := s.db.QueryRow("SELECT value FROM app_secrets WHERE name = $1", linkSigningSecretName).Scan(&stored); err != nil {
+		return nil, fmt.Errorf("failed to load link signing secret: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return nil, fmt.Errorf("stored link signing secret is corrupt: %w", err)
+	}
+	s.key = key
+	return s.key, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Signed links must survive a round trip, be rejected when tampered with or
+// presented to the wrong endpoint, and stop working once expired. Two signers
+// on the same database must share the generated key.
+func TestLinkSignerRoundTripTamperAndExpiry(t *testing.T) {
+	t.Setenv("LINK_SIGNING_SECRET", "")
+	db := newTestService(t, DefaultConfig()).db
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	signer := NewLinkSigner(db)
+	signer.now = func() time.Time { return now }
+
+	claims := LinkClaims{
+		Purpose:   LinkPurposeAvailabilityAction,
+		Subject:   "player-1",
+		Resource:  "42",
+		Action:    "Available",
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	}
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	other := NewLinkSigner(db)
+	other.now = signer.now
+	got, err := other.Verify(token, LinkPurposeAvailabilityAction)
+	if err != nil {
+		t.Fatalf("verify with second signer: %v", err)
+	}
+	if *got != claims {
+		t.Errorf("claims = %+v, want %+v", *got, claims)
+	}
+
+	if _, err := signer.Verify(token, "some-other-purpose"); !errors.Is(err, ErrLinkInvalid) {
+		t.Errorf("wrong purpose: err = %v, want ErrLinkInvalid", err)
+	}
+
+	// Swap in a payload claiming a different answer but keep the signature
+	forged := claims
+	forged.Action = "Unavailable"
+	forgedToken, _ := signer.Sign(forged)
+	tampered := strings.SplitN(forgedToken, ".", 2)[0] + "." + strings.SplitN(token, ".", 2)[1]
+	if _, err := signer.Verify(tampered, LinkPurposeAvailabilityAction); !errors.Is(err, ErrLinkInvalid) {
+		t.Errorf("tampered payload: err = %v, want ErrLinkInvalid", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := signer.Verify(token, LinkPurposeAvailabilityAction); !errors.Is(err, ErrLinkExpired) {
+		t.Errorf("expired: err = %v, want ErrLinkExpired", err)
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+const (
+	// MFAPendingDuration is how long a user has to enter their code after
+	// the password check before they have to log in again
+	MFAPendingDuration = 10 * time.Minute
+	// TrustedDeviceDuration is how long "remember this device" skips 2FA
+	TrustedDeviceDuration = 30 * 24 * time.Hour
+	// TrustedDeviceCookieName holds the remember-this-device token
+	TrustedDeviceCookieName = "trusted_device"
+	// RecoveryCodeCount is how many recovery codes a user is given at a time
+	RecoveryCodeCount = 10
+)
+
+var (
+	ErrInvalidTwoFactorCode = errors.New("that code is not valid")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not turned on")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already turned on")
+	ErrTwoFactorRequired    = errors.New("two-factor authentication is required for your role")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TrustedDevice is a browser that may skip the second factor until it expires
+type TrustedDevice struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	SessionID  string    `db:"session_id"`
+	TokenHash  string    `db:"token_hash"`
+	UserAgent  string    `db:"user_agent"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastUsedAt time.Time `db:"last_used_at"`
+	ExpiresAt  time.Time `db:"expires_at"`
+}
+
+// TwoFactorRequired reports whether the user's role obliges them to use 2FA
+func (s *Service) TwoFactorRequired(user models.User) bool {
+	return s.config.RequireAdminMFA && user.Role == models.RoleAdmin
+}
+
+// CompleteTwoFactor finishes a login left pending by Login. The code may be a
+// TOTP code or an unused recovery code. On success the pending session is
+// replaced by a fresh full session; when remember is set, a trusted device
+// token is also returned for SetTrustedDeviceCookie.
+func (s *Service) CompleteTwoFactor(ctx context.Context, pendingSessionID, code string, remember bool, r *http.Request) (*models.Session, string, error) {
+	var pending models.Session
+	if err := s.db.GetContext(ctx, &pending, `
+		SELECT * FROM sessions
+		WHERE id = ? AND is_valid = true AND mfa_pending = true
+	`, pendingSessionID); err != nil {
+		return nil, "", ErrSessionInvalid
+	}
+	if time.Now().After(pending.ExpiresAt) {
+		s.InvalidateSession(pending.ID)
+		return nil, "", ErrSessionExpired
+	}
+
+	var user models.User
+	if err := s.db.GetContext(ctx, &user, `SELECT `+userColumns+` FROM users WHERE id = ? AND is_active = true`, pending.UserID); err != nil {
+		s.InvalidateSession(pending.ID)
+		return nil, "", ErrUserInactive
+	}
+
+	// Wrong codes count as failed logins, so guessing is throttled the same way
+	if tooMany, err := s.tooManyFailedAttempts(user.Username, r.RemoteAddr); err != nil {
+		log.Printf("Error checking login attempts: %v", err)
+	} else if tooMany {
+		s.InvalidateSession(pending.ID)
+		return nil, "", ErrTooManyAttempts
+	}
+
+	ok, err := s.checkSecondFactor(ctx, user, code)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		log.Printf("Two-factor verification failed for user %s", user.Username)
+		s.recordLoginAttempt(user.Username, r, false)
+		return nil, "", ErrInvalidTwoFactorCode
+	}
+	s.recordLoginAttempt(user.Username, r, true)
+
+	// Swap the pending session for a new one so its ID never carries full access
+	if err := s.InvalidateSession(pending.ID); err != nil {
+		return nil, "", err
+	}
+	session, err := s.createSession(user, r, false)
+	if err != nil {
+		return nil, "", err
+	}
+	s.touchLastLogin(user.ID)
+
+	if !remember {
+		return session, "", nil
+	}
+	token, err := s.trustDevice(ctx, user.ID, session.ID, r.UserAgent())
+	if err != nil {
+		log.Printf("Failed to remember device for user %s: %v", user.Username, err)
+		return session, "", nil
+	}
+	return session, token, nil
+}
+
+// checkSecondFactor accepts either a current TOTP code or a recovery code,
+// consuming whichever was used
+func (s *Service) checkSecondFactor(ctx context.Context, user models.User, code string) (bool, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(normalized) == totpDigits {
+		return s.consumeTOTP(ctx, user, normalized)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE mfa_recovery_codes SET used_at = ?
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+	`, time.Now().UTC(), user.ID, hashRecoveryCode(normalized))
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	if n == 1 {
+		log.Printf("User %s signed in with a recovery code", user.Username)
+	}
+	return n == 1, nil
+}
+
+// consumeTOTP verifies a TOTP code and records its time step, so the same
+// code can't be used again even by a concurrent request
+func (s *Service) consumeTOTP(ctx context.Context, user models.User, code string) (bool, error) {
+	if user.TOTPSecret == "" {
+		return false, nil
+	}
+	step, ok := verifyTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		return false, nil
+	}
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE users SET totp_last_step = ? WHERE id = ? AND totp_last_step < ?
+	`, step, user.ID, step)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n == 1, nil
+}
+
+// BeginTOTPEnrolment returns the secret to show while the user sets up their
+// authenticator app, creating one if needed. It stays unconfirmed until
+// EnableTOTP is given a code generated from it.
+func (s *Service) BeginTOTPEnrolment(ctx context.Context, user models.User) (string, error) {
+	if user.TwoFactorEnabled() {
+		return "", ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret != "" {
+		return user.TOTPSecret, nil
+	}
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE users SET totp_secret = ?, totp_last_step = 0 WHERE id = ? AND totp_enabled_at IS NULL
+	`, secret, user.ID); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// EnableTOTP confirms enrolment with a code from the user's app and returns
+// their recovery codes, which are only ever shown this once
+func (s *Service) EnableTOTP(ctx context.Context, user models.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	ok, err := s.consumeTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	if _, err := s.db.ExecContext(ctx, `UPDATE users SET totp_enabled_at = ? WHERE id = ?`, time.Now().UTC(), user.ID); err != nil {
+		return nil, err
+	}
+	log.Printf("Two-factor authentication enabled for user %s", user.Username)
+	return s.replaceRecoveryCodes(ctx, user.ID)
+}
+
+// RegenerateRecoveryCodes replaces all of a user's recovery codes after
+// checking a current TOTP code
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, user models.User, code string) ([]string, error) {
+	if !user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	ok, err := s.consumeTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	return s.replaceRecoveryCodes(ctx, user.ID)
+}
+
+// DisableTOTP turns 2FA off after checking a current code. Users whose role
+// requires 2FA can't turn it off themselves.
+func (s *Service) DisableTOTP(ctx context.Context, user models.User, code string) error {
+	if !user.TwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+	if s.TwoFactorRequired(user) {
+		return ErrTwoFactorRequired
+	}
+	ok, err := s.checkSecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	log.Printf("Two-factor authentication disabled for user %s", user.Username)
+	return ResetTwoFactor(ctx, s.db, user.ID)
+}
+
+// ResetTwoFactor removes a user's 2FA enrolment, recovery codes and trusted
+// devices. Admins use it for users who have lost their phone and codes.
+func ResetTwoFactor(ctx context.Context, db *database.DB, userID int64) error {
+	if _, err := db.ExecContext(ctx, `
+		UPDATE users SET totp_secret = '', totp_enabled_at = NULL, totp_last_step = 0 WHERE id = ?
+	`, userID); err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, `DELETE FROM trusted_devices WHERE user_id = ?`, userID)
+	return err
+}
+
+// RecoveryCodesRemaining counts a user's unused recovery codes
+func (s *Service) RecoveryCodesRemaining(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := s.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = ? AND used_at IS NULL
+	`, userID)
+	return count, err
+}
+
+// replaceRecoveryCodes issues a new set of recovery codes, voiding the old set
+func (s *Service) replaceRecoveryCodes(ctx context.Context, userID int64) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))[:10]
+		codes[i] = encoded[:5] + "-" + encoded[5:]
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, code := range codes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash, created_at) VALUES (?, ?, ?)
+		`, userID, hashRecoveryCode(code), now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode returns the stored form of a recovery code, ignoring case
+// and the dash
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(code, "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// trustDevice records a remember-this-device token bound to the session
+func (s *Service) trustDevice(ctx context.Context, userID int64, sessionID, userAgent string) (string, error) {
+	token, err := generateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO trusted_devices (user_id, session_id, token_hash, user_agent, created_at, last_used_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, userID, sessionID, hashPasswordToken(token), userAgent, now, now, now.Add(TrustedDeviceDuration)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// trustedDeviceFor returns the user's trusted device matching the request's
+// remember-this-device cookie, if it is still valid. The device is only as
+// good as the session it last signed in: once that session is revoked or has
+// expired, the device has to pass two-factor again.
+func (s *Service) trustedDeviceFor(r *http.Request, userID int64) *TrustedDevice {
+	cookie, err := r.Cookie(TrustedDeviceCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	var device TrustedDevice
+	err = s.db.GetContext(r.Context(), &device, `
+		SELECT id, user_id, session_id, token_hash, user_agent, created_at, last_used_at, expires_at
+		FROM trusted_devices
+		WHERE token_hash = ? AND user_id = ? AND expires_at > ?
+	`, hashPasswordToken(cookie.Value), userID, time.Now().UTC())
+	if err != nil {
+		return nil
+	}
+
+	var session struct {
+		IsValid   bool      `db:"is_valid"`
+		ExpiresAt time.Time `db:"expires_at"`
+	}
+	if err := s.db.GetContext(r.Context(), &session, `
+		SELECT is_valid, expires_at FROM sessions WHERE id = ? AND user_id = ?
+	`, device.SessionID, userID); err != nil {
+		return nil
+	}
+	if !session.IsValid || !time.Now().Before(session.ExpiresAt) {
+		return nil
+	}
+	return &device
+}
+
+// bindTrustedDevice moves a trusted device onto the session it just signed in
+func (s *Service) bindTrustedDevice(deviceID int64, sessionID string) {
+	if _, err := s.db.Exec(`
+		UPDATE trusted_devices SET session_id = ?, last_used_at = ? WHERE id = ?
+	`, sessionID, time.Now().UTC(), deviceID); err != nil {
+		log.Printf("Failed to bind trusted device %d to session: %v", deviceID, err)
+	}
+}
+
+// TrustedDevices lists a user's unexpired remembered devices
+func (s *Service) TrustedDevices(ctx context.Context, userID int64) ([]TrustedDevice, error) {
+	var devices []TrustedDevice
+	err := s.db.SelectContext(ctx, &devices, `
+		SELECT id, user_id, session_id, token_hash, user_agent, created_at, last_used_at, expires_at
+		FROM trusted_devices
+		WHERE user_id = ? AND expires_at > ?
+		ORDER BY last_used_at DESC
+	`, userID, time.Now().UTC())
+	return devices, err
+}
+
+// ForgetTrustedDevices makes every device ask for a code again
+func (s *Service) ForgetTrustedDevices(ctx context.Context, userID int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM trusted_devices WHERE user_id = ?`, userID)
+	return err
+}
+
+// SetTrustedDeviceCookie stores a remember-this-device token in the browser
+func (s *Service) SetTrustedDeviceCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     TrustedDeviceCookieName,
+		Value:    token,
+		Path:     s.config.CookiePath,
+		Expires:  time.Now().Add(TrustedDeviceDuration),
+		HttpOnly: true,
+		Secure:   s.config.CookieSecure,
+		SameSite: s.config.CookieSameSite,
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"jim-dot-tennis/internal/models"
+)
+
+const (
+	twoFactorLoginPath   = "/login/2fa"
+	twoFactorAccountPath = "/account/2fa"
+)
+
+// localRedirect returns target if it is a path on this site, otherwise fallback
+func localRedirect(target, fallback string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return fallback
+	}
+	return target
+}
+
+// withRedirect adds target to path as the page to return to after logging
+// in, leaving it off if target isn't a path on this site
+func withRedirect(path, target string) string {
+	if localRedirect(target, "") == "" {
+		return path
+	}
+	return path + "?redirect=" + url.QueryEscape(target)
+}
+
+// TwoFactorLoginHandler handles GET/POST /login/2fa, the second step of a
+// login for users with two-factor authentication turned on
+func (h *Handler) TwoFactorLoginHandler() http.HandlerFunc {
+	type twoFactorLoginData struct {
+		Error        string
+		Redirect     string
+		RememberDays int
+		StartOver    bool
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(h.service.config.CookieName)
+		if err != nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		session, err := h.service.ValidateSession(cookie.Value, r)
+		if err != nil || !session.MFAPending {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+
+		data := twoFactorLoginData{
+			Redirect:     r.URL.Query().Get("redirect"),
+			RememberDays: int(TrustedDeviceDuration.Hours() / 24),
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			h.renderPage(w, "login_2fa.html", data)
+		case http.MethodPost:
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+			data.Redirect = r.FormValue("redirect")
+
+			full, deviceToken, err := h.service.CompleteTwoFactor(r.Context(), cookie.Value, r.FormValue("code"), r.FormValue("remember") == "on", r)
+			if err != nil {
+				log.Printf("Two-factor login failed: %v", err)
+				data.Error = err.Error()
+				if !errors.Is(err, ErrInvalidTwoFactorCode) {
+					h.service.ClearSessionCookie(w)
+					data.StartOver = true
+				}
+				w.WriteHeader(http.StatusUnauthorized)
+				h.renderPage(w, "login_2fa.html", data)
+				return
+			}
+
+			h.service.SetSessionCookie(w, full)
+			if deviceToken != "" {
+				h.service.SetTrustedDeviceCookie(w, deviceToken)
+			}
+			http.Redirect(w, r, localRedirect(data.Redirect, h.redirectPath), http.StatusSeeOther)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// TwoFactorAccountHandler handles GET/POST /account/2fa, where a logged-in
+// user sets up, manages or turns off two-factor authentication
+func (h *Handler) TwoFactorAccountHandler() http.HandlerFunc {
+	type twoFactorAccountData struct {
+		User                   models.User
+		Enabled                bool
+		Required               bool
+		MustEnrol              bool
+		Secret                 string
+		QRCode                 template.URL
+		RecoveryCodes          []string
+		RecoveryCodesRemaining int
+		Devices                []TrustedDevice
+		Error                  string
+		Notice                 string
+		BackPath               string
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user, err := GetUserFromContext(ctx)
+		if err != nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		// The page can show a secret or recovery codes, so never cache it
+		w.Header().Set("Cache-Control", "no-store")
+
+		data := twoFactorAccountData{BackPath: h.redirectPath}
+		if user.Role != models.RoleAdmin {
+			data.BackPath = "/"
+		}
+
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+			code := r.FormValue("code")
+			switch r.FormValue("action") {
+			case "enable":
+				data.RecoveryCodes, err = h.service.EnableTOTP(ctx, user, code)
+				if err == nil {
+					data.Notice = "Two-factor authentication is on. Save your recovery codes now; they won't be shown again."
+				}
+			case "recovery-codes":
+				data.RecoveryCodes, err = h.service.RegenerateRecoveryCodes(ctx, user, code)
+				if err == nil {
+					data.Notice = "New recovery codes issued. Your old codes no longer work."
+				}
+			case "disable":
+				err = h.service.DisableTOTP(ctx, user, code)
+				if err == nil {
+					data.Notice = "Two-factor authentication is off."
+				}
+			case "forget-devices":
+				err = h.service.ForgetTrustedDevices(ctx, user.ID)
+				if err == nil {
+					data.Notice = "All remembered devices will ask for a code next time."
+				}
+			default:
+				http.Error(w, "Unknown action", http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				data.Error = err.Error()
+			}
+
+			// Reload so the page reflects what just changed
+			if err := h.service.db.GetContext(ctx, &user, `SELECT `+userColumns+` FROM users WHERE id = ?`, user.ID); err != nil {
+				log.Printf("Failed to reload user %d: %v", user.ID, err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		} else if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		data.User = user
+		data.Enabled = user.TwoFactorEnabled()
+		data.Required = h.service.TwoFactorRequired(user)
+		data.MustEnrol = data.Required && !data.Enabled
+
+		if data.Enabled {
+			if data.RecoveryCodesRemaining, err = h.service.RecoveryCodesRemaining(ctx, user.ID); err != nil {
+				log.Printf("Failed to count recovery codes for user %d: %v", user.ID, err)
+			}
+			if data.Devices, err = h.service.TrustedDevices(ctx, user.ID); err != nil {
+				log.Printf("Failed to load trusted devices for user %d: %v", user.ID, err)
+			}
+		} else {
+			secret, err := h.service.BeginTOTPEnrolment(ctx, user)
+			if err != nil {
+				log.Printf("Failed to start two-factor enrolment for user %d: %v", user.ID, err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			data.Secret = secret
+			qr, err := TOTPQRCode(TOTPProvisioningURI(user.Username, secret))
+			if err != nil {
+				log.Printf("Failed to render QR code for user %d: %v", user.ID, err)
+			} else {
+				data.QRCode = qr
+			}
+		}
+
+		h.renderPage(w, "account_2fa.html", data)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1, secret "12345678901234567890"
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	for unix, want := range map[int64]string{59: "287082", 1111111109: "081804", 2000000000: "279037"} {
+		got, err := totpCode(secret, totpStep(time.Unix(unix, 0)))
+		if err != nil || got != want {
+			t.Errorf("code at %d = %q, %v; want %q", unix, got, err, want)
+		}
+	}
+}
+
+// An enrolled admin's password alone must only reach a pending session; a
+// code finishes the login once, recovery codes work once, and a remembered
+// device skips the prompt on its next login.
+func TestTwoFactorLoginFlow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RequireAdminMFA = true
+	s := newTestService(t, cfg)
+	ctx := context.Background()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if _, err := s.db.Exec(`UPDATE users SET password_hash = ?, role = 'admin', is_active = true WHERE id = 1`, string(hash)); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+	loadUser := func() models.User {
+		t.Helper()
+		var user models.User
+		if err := s.db.Get(&user, `SELECT * FROM users WHERE id = 1`); err != nil {
+			t.Fatalf("load user: %v", err)
+		}
+		return user
+	}
+	login := func(r *http.Request) *models.Session {
+		t.Helper()
+		session, err := s.Login(loadUser().Username, "correct horse", r)
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		return session
+	}
+
+	// Not yet enrolled: the middleware sends the admin to set 2FA up
+	unenrolled := login(httptest.NewRequest(http.MethodPost, "/login", nil))
+	if unenrolled.MFAPending {
+		t.Fatal("session pending before 2FA was enabled")
+	}
+	middleware := NewMiddleware(s, nil, nil, nil)
+	protected := middleware.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func(sessionID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/league", nil)
+		req.AddCookie(&http.Cookie{Name: cfg.CookieName, Value: sessionID})
+		rec := httptest.NewRecorder()
+		protected.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := get(unenrolled.ID); !strings.HasPrefix(rec.Header().Get("Location"), twoFactorAccountPath) {
+		t.Fatalf("unenrolled admin: %d %q, want redirect to enrolment", rec.Code, rec.Header().Get("Location"))
+	}
+
+	secret, err := s.BeginTOTPEnrolment(ctx, loadUser())
+	if err != nil {
+		t.Fatalf("begin enrolment: %v", err)
+	}
+	if _, err := s.EnableTOTP(ctx, loadUser(), "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("enable with wrong code: err = %v", err)
+	}
+	enrolCode, _ := totpCode(secret, totpStep(time.Now()))
+	recoveryCodes, err := s.EnableTOTP(ctx, loadUser(), enrolCode)
+	if err != nil || len(recoveryCodes) != RecoveryCodeCount {
+		t.Fatalf("enable: %d codes, %v", len(recoveryCodes), err)
+	}
+	if err := s.DisableTOTP(ctx, loadUser(), recoveryCodes[0]); !errors.Is(err, ErrTwoFactorRequired) {
+		t.Errorf("required admin disabling 2FA: err = %v, want ErrTwoFactorRequired", err)
+	}
+
+	pending := login(httptest.NewRequest(http.MethodPost, "/login", nil))
+	if !pending.MFAPending {
+		t.Fatal("password-only login was not left pending")
+	}
+	if rec := get(pending.ID); rec.Header().Get("Location") != twoFactorLoginPath+"?redirect=%2Fadmin%2Fleague" {
+		t.Fatalf("pending session: %d %q, want redirect to code prompt", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// The code used to enrol can't be replayed
+	req := httptest.NewRequest(http.MethodPost, twoFactorLoginPath, nil)
+	if _, _, err := s.CompleteTwoFactor(ctx, pending.ID, enrolCode, false, req); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("replayed code: err = %v", err)
+	}
+
+	full, deviceToken, err := s.CompleteTwoFactor(ctx, pending.ID, strings.ToUpper(recoveryCodes[1]), true, req)
+	if err != nil || full.MFAPending || full.ID == pending.ID || deviceToken == "" {
+		t.Fatalf("recovery code login: %+v, token %q, %v", full, deviceToken, err)
+	}
+	if rec := get(full.ID); rec.Code != http.StatusOK {
+		t.Errorf("full session: status %d, want 200", rec.Code)
+	}
+	if rec := get(pending.ID); rec.Code == http.StatusOK {
+		t.Error("pending session still works after being upgraded")
+	}
+	if remaining, _ := s.RecoveryCodesRemaining(ctx, 1); remaining != RecoveryCodeCount-1 {
+		t.Errorf("recovery codes remaining = %d, want %d", remaining, RecoveryCodeCount-1)
+	}
+
+	again := login(httptest.NewRequest(http.MethodPost, "/login", nil))
+	if _, _, err := s.CompleteTwoFactor(ctx, again.ID, recoveryCodes[1], false, req); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("reused recovery code: err = %v", err)
+	}
+
+	// The remembered device skips the prompt and is rebound to the new session
+	remembered := httptest.NewRequest(http.MethodPost, "/login", nil)
+	remembered.AddCookie(&http.Cookie{Name: TrustedDeviceCookieName, Value: deviceToken})
+	trusted := login(remembered)
+	if trusted.MFAPending {
+		t.Fatal("remembered device was still asked for a code")
+	}
+	var boundSession string
+	if err := s.db.Get(&boundSession, `SELECT session_id FROM trusted_devices`); err != nil || boundSession != trusted.ID {
+		t.Errorf("trusted device bound to %q (%v), want %q", boundSession, err, trusted.ID)
+	}
+
+	// Signing that session out revokes the device's trust with it
+	if err := s.InvalidateSession(trusted.ID); err != nil {
+		t.Fatalf("invalidate session: %v", err)
+	}
+	if !login(remembered).MFAPending {
+		t.Error("device bound to a revoked session skipped the code prompt")
+	}
+	if _, err := s.db.Exec(`UPDATE sessions SET is_valid = true WHERE id = ?`, trusted.ID); err != nil {
+		t.Fatalf("restore session: %v", err)
+	}
+	if login(remembered).MFAPending {
+		t.Fatal("device bound to a live session was asked for a code")
+	}
+
+	if err := s.ForgetTrustedDevices(ctx, 1); err != nil {
+		t.Fatalf("forget devices: %v", err)
+	}
+	if login(remembered).MFAPending != true {
+		t.Error("forgotten device skipped the code prompt")
+	}
+}
+
+// Login redirects carry the page to return to escaped, and only when it is a
+// path on this site
+func TestWithRedirectKeepsLoginRedirectsLocal(t *testing.T) {
+	for target, want := range map[string]string{
+		"/admin/league":        "/login?redirect=%2Fadmin%2Fleague",
+		"/admin/a b?x=1&y=2":   "/login?redirect=%2Fadmin%2Fa+b%3Fx%3D1%26y%3D2",
+		"//evil.example/":      "/login",
+		"/\\evil.example":      "/login",
+		"https://evil.example": "/login",
+		"":                     "/login",
+	} {
+		if got := withRedirect("/login", target); got != want {
+			t.Errorf("withRedirect(%q) = %q, want %q", target, got, want)
+		}
+	}
+}
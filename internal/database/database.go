@@ -8,6 +8,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -35,6 +38,9 @@ type Config struct {
 // DB is a wrapper around sqlx.DB
 type DB struct {
 	*sqlx.DB
+
+	// queryHook is called with every query made through the DB; see OnQuery
+	queryHook atomic.Pointer[func(query string)]
 }
 
 // New creates a new database connection
@@ -79,7 +85,7 @@ func New(cfg Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db}, nil
 }
 
 // Close closes the database connection
@@ -161,3 +167,46 @@ func (db *DB) ExecuteMigrations(migrationsPath string) error {
 	log.Println("Migrations applied successfully")
 	return nil
 }
+
+// CheckMigrations returns an error unless the schema is clean and at the
+// newest migration in migrationsPath, for the readiness probe
+func (db *DB) CheckMigrations(ctx context.Context, migrationsPath string) error {
+	var state struct {
+		Version int64 `db:"version"`
+		Dirty   bool  `db:"dirty"`
+	}
+	if err := db.GetContext(ctx, &state, `SELECT version, dirty FROM schema_migrations LIMIT 1`); err != nil {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if state.Dirty {
+		return fmt.Errorf("database is dirty at migration %d", state.Version)
+	}
+
+	latest, err := latestMigration(migrationsPath)
+	if err != nil {
+		return err
+	}
+	if state.Version != latest {
+		return fmt.Errorf("database is at migration %d, latest is %d", state.Version, latest)
+	}
+	return nil
+}
+
+// latestMigration finds the highest version among the up migrations
+func latestMigration(migrationsPath string) (int64, error) {
+	files, err := filepath.Glob(filepath.Join(migrationsPath, "*.up.sql"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	var latest int64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+		if version, err := strconv.ParseInt(prefix, 10, 64); err == nil && version > latest {
+			latest = version
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", migrationsPath)
+	}
+	return latest, nil
+}
@@ -3,7 +3,10 @@
 package database
 
 import (
+	"context"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -40,3 +43,44 @@ func TestNewSQLiteAppliesPragmas(t *testing.T) {
 		t.Errorf("busy_timeout = %d, want 5000", busyTimeout)
 	}
 }
+
+func TestCheckMigrations(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration := func(name, sql string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(sql), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	writeMigration("001_create_things.up.sql", "CREATE TABLE things (id INTEGER PRIMARY KEY);")
+	writeMigration("001_create_things.down.sql", "DROP TABLE things;")
+
+	db, err := New(Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	if err := db.CheckMigrations(ctx, dir); err == nil {
+		t.Error("unmigrated database reported clean")
+	}
+	if err := db.ExecuteMigrations(dir); err != nil {
+		t.Fatalf("ExecuteMigrations() failed: %v", err)
+	}
+	if err := db.CheckMigrations(ctx, dir); err != nil {
+		t.Errorf("migrated database: %v", err)
+	}
+
+	writeMigration("002_add_name.up.sql", "ALTER TABLE things ADD COLUMN name TEXT;")
+	if err := db.CheckMigrations(ctx, dir); err == nil || !strings.Contains(err.Error(), "latest is 2") {
+		t.Errorf("database behind the newest migration: err = %v", err)
+	}
+
+	if _, err := db.Exec(`UPDATE schema_migrations SET dirty = true`); err != nil {
+		t.Fatalf("marking dirty: %v", err)
+	}
+	if err := db.CheckMigrations(ctx, dir); err == nil || !strings.Contains(err.Error(), "dirty") {
+		t.Errorf("dirty database: err = %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"jim-dot-tennis/internal/observability"
+)
+
+// The methods below shadow the ones DB inherits from sqlx.DB and sql.DB so
+// every query made through a *DB is timed for the metrics, and slow ones are
+// logged against the request that made them. A query whose context carries a
+// unit of work (see WithTx) runs in that transaction. Queries made directly
+// on a *sqlx.Tx go straight to the driver and are not timed.
+
+// OnQuery calls fn with the text of every query made through db from now
+// on, so tests can count the queries a screen makes. A nil fn stops it.
+func (db *DB) OnQuery(fn func(query string)) {
+	if fn == nil {
+		db.queryHook.Store(nil)
+		return
+	}
+	db.queryHook.Store(&fn)
+}
+
+// queryer is what DB and a transaction have in common
+type queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn returns the unit of work's transaction when ctx carries one, and the
+// connection pool otherwise
+func (db *DB) conn(ctx context.Context) queryer {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db.DB
+}
+
+// observe records a finished query
+func (db *DB) observe(ctx context.Context, query string, start time.Time, err error) {
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	observability.ObserveQuery(ctx, query, time.Since(start), err)
+	if hook := db.queryHook.Load(); hook != nil {
+		(*hook)(query)
+	}
+}
+
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := db.conn(ctx).GetContext(ctx, dest, query, args...)
+	db.observe(ctx, query, start, err)
+	return err
+}
+
+func (db *DB) Get(dest interface{}, query string, args ...interface{}) error {
+	return db.GetContext(context.Background(), dest, query, args...)
+}
+
+func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := db.conn(ctx).SelectContext(ctx, dest, query, args...)
+	db.observe(ctx, query, start, err)
+	return err
+}
+
+func (db *DB) Select(dest interface{}, query string, args ...interface{}) error {
+	return db.SelectContext(context.Background(), dest, query, args...)
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.conn(ctx).ExecContext(ctx, query, args...)
+	db.observe(ctx, query, start, err)
+	return result, err
+}
+
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+func (db *DB) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.conn(ctx).NamedExecContext(ctx, query, arg)
+	db.observe(ctx, query, start, err)
+	return result, err
+}
+
+func (db *DB) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return db.NamedExecContext(context.Background(), query, arg)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.conn(ctx).QueryContext(ctx, query, args...)
+	db.observe(ctx, query, start, err)
+	return rows, err
+}
+
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	start := time.Now()
+	rows, err := db.conn(ctx).QueryxContext(ctx, query, args...)
+	db.observe(ctx, query, start, err)
+	return rows, err
+}
+
+func (db *DB) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return db.QueryxContext(context.Background(), query, args...)
+}
+
+// QueryRowContext times the query up to the point the row is returned; the
+// driver has run the statement by then, leaving only the scan
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := db.conn(ctx).QueryRowContext(ctx, query, args...)
+	db.observe(ctx, query, start, row.Err())
+	return row
+}
+
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	start := time.Now()
+	row := db.conn(ctx).QueryRowxContext(ctx, query, args...)
+	db.observe(ctx, query, start, row.Err())
+	return row
+}
+
+func (db *DB) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return db.QueryRowxContext(context.Background(), query, args...)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// txKey is the context key for the transaction started by WithTx
+type txKey struct{}
+
+// WithTx runs fn as one unit of work. Every query made through db with the
+// context fn is given, including those made by repositories, runs inside a
+// single transaction, which is committed if fn returns nil and rolled back
+// otherwise. Calling WithTx with a context that already carries a
+// transaction joins it, so the outermost call decides the outcome.
+func (db *DB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// txFromContext returns the transaction ctx carries, if any
+func txFromContext(ctx context.Context) *sqlx.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx
+}
+
+// BeginTxx starts a separate transaction. It refuses to when ctx carries one
+// from WithTx: SQLite allows a single writer, so the new transaction would
+// wait on the caller's until the busy timeout. Repository methods that need
+// their own transaction cannot be part of a unit of work.
+func (db *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	if txFromContext(ctx) != nil {
+		return nil, errors.New("cannot begin a transaction inside a unit of work")
+	}
+	return db.DB.BeginTxx(ctx, opts)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+// Package health serves the liveness and readiness probes used by Docker
+// and the reverse proxy.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds each readiness check, so a locked database makes the
+// probe fail rather than hang
+const checkTimeout = 2 * time.Second
+
+// Check reports whether one dependency is usable
+type Check func(ctx context.Context) error
+
+// Checker runs the readiness checks
+type Checker struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]Check
+}
+
+// NewChecker creates a checker with no checks
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// AddCheck adds a named readiness check
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.checks[name]; !exists {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// RegisterRoutes registers /healthz and /readyz
+func (c *Checker) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", c.HandleHealthz)
+	mux.HandleFunc("/readyz", c.HandleReadyz)
+}
+
+// HandleHealthz answers as long as the process is serving requests
+func (c *Checker) HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte("ok\n"))
+}
+
+// readyResponse is the /readyz body
+type readyResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// HandleReadyz runs every check and answers 503 if any fails
+func (c *Checker) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	names := append([]string(nil), c.names...)
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	response := readyResponse{Status: "ready", Checks: make(map[string]string, len(names))}
+	status := http.StatusOK
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		err := checks[name](ctx)
+		cancel()
+		if err != nil {
+			slog.WarnContext(r.Context(), "readiness check failed", "check", name, "error", err)
+			response.Checks[name] = err.Error()
+			response.Status = "not ready"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
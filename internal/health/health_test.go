@@ -0,0 +1,50 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadyzReportsEachCheck(t *testing.T) {
+	c := NewChecker()
+	c.AddCheck("database", func(ctx context.Context) error { return nil })
+	var migrationsErr error
+	c.AddCheck("migrations", func(ctx context.Context) error { return migrationsErr })
+	mux := http.NewServeMux()
+	c.RegisterRoutes(mux)
+
+	get := func(path string) (*httptest.ResponseRecorder, readyResponse) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var body readyResponse
+		if path == "/readyz" {
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("readyz body %q: %v", rec.Body.String(), err)
+			}
+		}
+		return rec, body
+	}
+
+	if rec, body := get("/readyz"); rec.Code != http.StatusOK || body.Status != "ready" || body.Checks["migrations"] != "ok" {
+		t.Errorf("healthy readyz: %d %+v", rec.Code, body)
+	}
+
+	migrationsErr = errors.New("database is dirty at migration 36")
+	rec, body := get("/readyz")
+	if rec.Code != http.StatusServiceUnavailable || body.Status != "not ready" {
+		t.Errorf("failing readyz: %d %+v", rec.Code, body)
+	}
+	if body.Checks["database"] != "ok" || body.Checks["migrations"] != migrationsErr.Error() {
+		t.Errorf("checks = %+v", body.Checks)
+	}
+
+	if rec, _ := get("/healthz"); rec.Code != http.StatusOK {
+		t.Errorf("healthz while not ready: %d, want 200", rec.Code)
+	}
+}
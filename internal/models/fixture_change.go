@@ -0,0 +1,26 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package models
+
+import "time"
+
+// FixtureChangeType identifies which player-visible detail of a fixture changed
+type FixtureChangeType string
+
+const (
+	FixtureChangeDate   FixtureChangeType = "Date"   // Rescheduled
+	FixtureChangeStatus FixtureChangeType = "Status" // Postponed / Cancelled / reinstated
+	FixtureChangeVenue  FixtureChangeType = "Venue"  // Resolved venue club changed
+)
+
+// FixtureChange is one entry in a fixture's change log. OldValue/NewValue are
+// human-readable (formatted date, status, venue name) as sent to players.
+type FixtureChange struct {
+	ID            uint              `json:"id" db:"id"`
+	FixtureID     uint              `json:"fixture_id" db:"fixture_id"`
+	ChangeType    FixtureChangeType `json:"change_type" db:"change_type"`
+	OldValue      string            `json:"old_value" db:"old_value"`
+	NewValue      string            `json:"new_value" db:"new_value"`
+	NotifiedCount int               `json:"notified_count" db:"notified_count"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
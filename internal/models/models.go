@@ -386,6 +386,131 @@ type Tournament struct {
 	ProviderName string `json:"provider_name,omitempty" db:"provider_name"`
 }
 
+// TournamentEventType is whether an event is played as singles or doubles
+type TournamentEventType string
+
+const (
+	TournamentSingles TournamentEventType = "Singles"
+	TournamentDoubles TournamentEventType = "Doubles"
+)
+
+// TournamentEventGender restricts who may enter an event
+type TournamentEventGender string
+
+const (
+	TournamentEventMen   TournamentEventGender = "Men"
+	TournamentEventWomen TournamentEventGender = "Women"
+	TournamentEventMixed TournamentEventGender = "Mixed" // doubles pairs of one man and one woman
+	TournamentEventOpen  TournamentEventGender = "Open"
+)
+
+// TournamentEvent is an event members can enter for a tournament
+type TournamentEvent struct {
+	ID               uint                  `json:"id" db:"id"`
+	TournamentID     uint                  `json:"tournament_id" db:"tournament_id"`
+	Name             string                `json:"name" db:"name"`
+	EventType        TournamentEventType   `json:"event_type" db:"event_type"`
+	Gender           TournamentEventGender `json:"gender" db:"gender"`
+	EntryDeadline    time.Time             `json:"entry_deadline" db:"entry_deadline"`
+	CourthiveEventID string                `json:"courthive_event_id" db:"courthive_event_id"`
+	CreatedAt        time.Time             `json:"created_at" db:"created_at"`
+
+	// Not stored in DB — populated by joins
+	TournamentName string `json:"tournament_name,omitempty" db:"tournament_name"`
+}
+
+// TournamentEntryStatus is where an entry is in the approval workflow
+type TournamentEntryStatus string
+
+const (
+	EntryPending   TournamentEntryStatus = "Pending"
+	EntryApproved  TournamentEntryStatus = "Approved"
+	EntryRejected  TournamentEntryStatus = "Rejected"
+	EntryWithdrawn TournamentEntryStatus = "Withdrawn"
+)
+
+// TournamentEntrySyncStatus is whether an entry is in CourtHive. Synced stays
+// set after a withdrawal or rejection until the export removes the entry.
+type TournamentEntrySyncStatus string
+
+const (
+	EntryNotSynced  TournamentEntrySyncStatus = "NotSynced"
+	EntrySynced     TournamentEntrySyncStatus = "Synced"
+	EntrySyncFailed TournamentEntrySyncStatus = "Failed"
+)
+
+// TournamentEntry is a player's entry into a tournament event, with their
+// partner for doubles
+type TournamentEntry struct {
+	ID         uint                      `json:"id" db:"id"`
+	EventID    uint                      `json:"event_id" db:"event_id"`
+	PlayerID   string                    `json:"player_id" db:"player_id"`
+	PartnerID  *string                   `json:"partner_id,omitempty" db:"partner_id"`
+	Status     TournamentEntryStatus     `json:"status" db:"status"`
+	SyncStatus TournamentEntrySyncStatus `json:"sync_status" db:"sync_status"`
+	SyncError  string                    `json:"sync_error" db:"sync_error"`
+	SyncedAt   *time.Time                `json:"synced_at,omitempty" db:"synced_at"`
+	CreatedAt  time.Time                 `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time                 `json:"updated_at" db:"updated_at"`
+
+	// Not stored in DB — populated by joins
+	PlayerName  string `json:"player_name,omitempty" db:"player_name"`
+	PartnerName string `json:"partner_name,omitempty" db:"partner_name"`
+	EventName   string `json:"event_name,omitempty" db:"event_name"`
+}
+
+// StaleInCourtHive reports an entry that reached CourtHive but has since been
+// withdrawn or rejected, so the next export must remove it there
+func (e TournamentEntry) StaleInCourtHive() bool {
+	return e.Status != EntryApproved && e.SyncStatus == EntrySynced
+}
+
+// TournamentDraw is a cup draw synced from one of a tournament's CourtHive events
+type TournamentDraw struct {
+	ID              uint      `json:"id" db:"id"`
+	TournamentID    uint      `json:"tournament_id" db:"tournament_id"`
+	CourthiveDrawID string    `json:"courthive_draw_id" db:"courthive_draw_id"`
+	Name            string    `json:"name" db:"name"`
+	DrawType        string    `json:"draw_type" db:"draw_type"`
+	EventName       string    `json:"event_name" db:"event_name"`
+	EventType       string    `json:"event_type" db:"event_type"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// Not stored in DB — populated by queries
+	Matches []TournamentMatch `json:"matches,omitempty"`
+}
+
+// TournamentMatch is one position in a cup draw. Side 1 and 2 follow
+// CourtHive; WinningSide is nil until the match is decided.
+type TournamentMatch struct {
+	ID                 uint       `json:"id" db:"id"`
+	DrawID             uint       `json:"draw_id" db:"draw_id"`
+	CourthiveMatchUpID string     `json:"courthive_match_up_id" db:"courthive_match_up_id"`
+	RoundNumber        int        `json:"round_number" db:"round_number"`
+	RoundPosition      int        `json:"round_position" db:"round_position"`
+	RoundName          string     `json:"round_name" db:"round_name"`
+	Side1Name          string     `json:"side1_name" db:"side1_name"`
+	Side2Name          string     `json:"side2_name" db:"side2_name"`
+	WinningSide        *int       `json:"winning_side,omitempty" db:"winning_side"`
+	Score              string     `json:"score" db:"score"`             // from side 1's point of view
+	ScoreSide2         string     `json:"score_side2" db:"score_side2"` // from side 2's point of view
+	Status             string     `json:"status" db:"status"`
+	PlayedDate         *time.Time `json:"played_date,omitempty" db:"played_date"`
+
+	// Not stored in DB — populated by queries
+	Players []TournamentMatchPlayer `json:"players,omitempty"`
+}
+
+// TournamentMatchPlayer is an individual on one side of a cup match,
+// linked to our player record when their name matched
+type TournamentMatchPlayer struct {
+	ID              uint    `json:"id" db:"id"`
+	MatchID         uint    `json:"match_id" db:"match_id"`
+	Side            int     `json:"side" db:"side"`
+	ParticipantName string  `json:"participant_name" db:"participant_name"`
+	PlayerID        *string `json:"player_id,omitempty" db:"player_id"`
+}
+
 // PlayerTennisPreferences captures a player's self-authored 'My Tennis' profile.
 // One row per player; every scalar is nullable — partial completion is the norm.
 // JSON-TEXT columns (PreferredDays, PreferredTimes, ImprovementFocus) hold
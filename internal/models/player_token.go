@@ -0,0 +1,35 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package models
+
+import "time"
+
+// RetiredPlayerToken is a player link that has been rotated out. Until
+// GraceUntil it redirects to ReplacedByToken; afterwards it is refused.
+type RetiredPlayerToken struct {
+	ID              uint      `json:"id" db:"id"`
+	PlayerID        string    `json:"player_id" db:"player_id"`
+	Token           string    `json:"token" db:"token"`
+	ReplacedByToken string    `json:"replaced_by_token" db:"replaced_by_token"`
+	Reason          string    `json:"reason" db:"reason"`
+	RetiredBy       string    `json:"retired_by" db:"retired_by"` // Admin username, or "player"
+	GraceUntil      time.Time `json:"grace_until" db:"grace_until"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// InGrace reports whether the retired token still redirects at the given time
+func (t RetiredPlayerToken) InGrace(at time.Time) bool {
+	return at.Before(t.GraceUntil)
+}
+
+// PlayerTokenAccess summarises one device's use of a player link
+type PlayerTokenAccess struct {
+	ID          uint      `json:"id" db:"id"`
+	PlayerID    string    `json:"player_id" db:"player_id"`
+	Token       string    `json:"token" db:"token"`
+	UserAgent   string    `json:"user_agent" db:"user_agent"`
+	IP          string    `json:"ip" db:"ip"`
+	AccessCount int       `json:"access_count" db:"access_count"`
+	FirstSeenAt time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
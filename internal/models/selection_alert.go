@@ -0,0 +1,19 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package models
+
+import "time"
+
+// SelectionAlert records that a selected player changed their availability
+// for a fixture after the captain picked them. Unacknowledged alerts are
+// flagged on the team-selection screen.
+type SelectionAlert struct {
+	ID             uint               `json:"id" db:"id"`
+	FixtureID      uint               `json:"fixture_id" db:"fixture_id"`
+	PlayerID       string             `json:"player_id" db:"player_id"`
+	TeamID         *uint              `json:"team_id,omitempty" db:"team_id"` // Team the player was selected for
+	PreviousStatus AvailabilityStatus `json:"previous_status" db:"previous_status"`
+	NewStatus      AvailabilityStatus `json:"new_status" db:"new_status"`
+	AcknowledgedAt *time.Time         `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package models
+
+import "time"
+
+// SubOfferStatus represents the lifecycle state of a substitute call-out
+type SubOfferStatus string
+
+const (
+	SubOfferOpen      SubOfferStatus = "Open"      // Broadcast and waiting for a taker
+	SubOfferFilled    SubOfferStatus = "Filled"    // A recipient accepted and was added to the selection
+	SubOfferCancelled SubOfferStatus = "Cancelled" // Withdrawn by the captain
+)
+
+// SubOfferResponse represents a recipient's answer to a substitute call-out
+type SubOfferResponse string
+
+const (
+	SubOfferPending  SubOfferResponse = "Pending"
+	SubOfferAccepted SubOfferResponse = "Accepted"
+	SubOfferDeclined SubOfferResponse = "Declined"
+	SubOfferTooLate  SubOfferResponse = "TooLate" // Tried to accept after the spot was filled
+)
+
+// SubOffer is a 'find a sub' broadcast for a team that is short for a fixture.
+// Gender is set when only one side of the team is short so the offer is only
+// sent to players who can fill that gap.
+type SubOffer struct {
+	ID               uint           `json:"id" db:"id"`
+	FixtureID        uint           `json:"fixture_id" db:"fixture_id"`
+	TeamID           uint           `json:"team_id" db:"team_id"` // The short team the sub would play for
+	Gender           *PlayerGender  `json:"gender,omitempty" db:"gender"`
+	Status           SubOfferStatus `json:"status" db:"status"`
+	FilledByPlayerID *string        `json:"filled_by_player_id,omitempty" db:"filled_by_player_id"`
+	CreatedByUserID  *int64         `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	FilledAt         *time.Time     `json:"filled_at,omitempty" db:"filled_at"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// SubOfferRecipient is one player a sub offer was broadcast to
+type SubOfferRecipient struct {
+	ID          uint             `json:"id" db:"id"`
+	SubOfferID  uint             `json:"sub_offer_id" db:"sub_offer_id"`
+	PlayerID    string           `json:"player_id" db:"player_id"`
+	Response    SubOfferResponse `json:"response" db:"response"`
+	Notified    bool             `json:"notified" db:"notified"` // Whether a push was delivered when the offer went out
+	RespondedAt *time.Time       `json:"responded_at,omitempty" db:"responded_at"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+}
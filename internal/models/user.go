@@ -19,12 +19,24 @@ const (
 type User struct {
 	ID           int64     `db:"id"`
 	Username     string    `db:"username"`
+	Email        string    `db:"email"` // Optional; used to deliver invitation and reset links
 	PasswordHash string    `db:"password_hash"`
 	Role         Role      `db:"role"`
 	PlayerID     *string   `db:"player_id"`
 	IsActive     bool      `db:"is_active"`
 	CreatedAt    time.Time `db:"created_at"`
 	LastLoginAt  time.Time `db:"last_login_at"`
+
+	// Two-factor authentication; TOTPSecret may hold an unconfirmed secret
+	// while TOTPEnabledAt is still nil
+	TOTPSecret    string     `db:"totp_secret"`
+	TOTPEnabledAt *time.Time `db:"totp_enabled_at"`
+	TOTPLastStep  int64      `db:"totp_last_step"`
+}
+
+// TwoFactorEnabled reports whether the user must enter a TOTP code to log in
+func (u User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
 }
 
 // Session represents a user's authenticated session
@@ -39,6 +51,7 @@ type Session struct {
 	UserAgent      string    `db:"user_agent"`
 	DeviceInfo     string    `db:"device_info"`
 	IsValid        bool      `db:"is_valid"`
+	MFAPending     bool      `db:"mfa_pending"` // Password checked, second factor still owed
 }
 
 // LoginAttempt tracks authentication attempts
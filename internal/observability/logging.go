@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+// Package observability provides structured logging, request IDs and the
+// Prometheus metrics served on /metrics.
+package observability
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// RequestIDHeader carries the request ID in from the proxy, if it set one,
+// and back out to the client
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits which incoming IDs are trusted, so a client can't
+// fill the logs with arbitrary text
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{8,64}$`)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID generates a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// SetupLogging makes slog the default logger. LOG_FORMAT picks "json" or
+// "text" (default: json in production, text otherwise) and LOG_LEVEL picks
+// debug, info, warn or error. The standard log package is routed through
+// the same handler, so existing log.Printf calls come out structured too.
+func SetupLogging() {
+	slog.SetDefault(slog.New(newHandler(os.Stderr, os.Getenv("LOG_FORMAT"), os.Getenv("LOG_LEVEL"))))
+}
+
+// newHandler builds the slog handler for the given format and level
+func newHandler(w io.Writer, format, level string) slog.Handler {
+	if format == "" {
+		format = "text"
+		if os.Getenv("APP_ENV") == "production" {
+			format = "json"
+		}
+	}
+
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var h slog.Handler
+	if strings.EqualFold(format, "json") {
+		h = slog.NewJSONHandler(w, opts)
+	} else {
+		h = slog.NewTextHandler(w, opts)
+	}
+	return contextHandler{h}
+}
+
+// contextHandler adds the request ID to records logged with a context, so
+// slog.InfoContext(ctx, ...) in a service call is tied to its request
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package observability
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace prefixes every metric the app exposes
+const metricsNamespace = "jimtennis"
+
+// Registry holds the app's metrics. It is separate from Prometheus's global
+// registry so only what is registered here is exposed.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by route pattern, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests, by route pattern and method.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"route", "method"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Time taken by database queries, by statement type and outcome.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "outcome"})
+
+	pushSends = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "push_send_attempts_total",
+		Help:      "Push notification delivery attempts, by notification type and outcome.",
+	}, []string{"type", "outcome"})
+
+	importRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "import_runs_total",
+		Help:      "Data import runs, by importer and result.",
+	}, []string{"importer", "result"})
+
+	importDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "import_run_duration_seconds",
+		Help:      "Time taken by data import runs, by importer.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600},
+	}, []string{"importer"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, dbQueryDuration, pushSends, importRuns, importDuration,
+	)
+}
+
+// slowQueryThreshold is how long a query may take before it is logged
+const slowQueryThreshold = 250 * time.Millisecond
+
+// ObserveQuery records how long a database query took. Slow queries are also
+// logged, with the request ID from ctx when there is one.
+func ObserveQuery(ctx context.Context, query string, elapsed time.Duration, err error) {
+	operation := queryOperation(query)
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	dbQueryDuration.WithLabelValues(operation, outcome).Observe(elapsed.Seconds())
+
+	if elapsed >= slowQueryThreshold {
+		slog.WarnContext(ctx, "slow database query",
+			"operation", operation,
+			"duration_ms", elapsed.Milliseconds(),
+			"query", strings.Join(strings.Fields(query), " "),
+		)
+	}
+}
+
+// queryOperation labels a query by its leading keyword, keeping the label
+// set small however many distinct queries there are
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "other"
+	}
+	switch keyword := strings.ToLower(fields[0]); keyword {
+	case "select", "insert", "update", "delete", "with", "replace":
+		return keyword
+	}
+	return "other"
+}
+
+// RecordPushSend counts one push delivery attempt
+func RecordPushSend(notificationType, outcome string) {
+	pushSends.WithLabelValues(notificationType, outcome).Inc()
+}
+
+// RecordImportRun counts one run of a data importer. A run that returned an
+// error is "failed"; one that finished with per-item errors is "partial".
+func RecordImportRun(importer string, elapsed time.Duration, err error, itemErrors int) {
+	result := "success"
+	switch {
+	case err != nil:
+		result = "failed"
+	case itemErrors > 0:
+		result = "partial"
+	}
+	importRuns.WithLabelValues(importer, result).Inc()
+	importDuration.WithLabelValues(importer).Observe(elapsed.Seconds())
+}
+
+// RegisterActiveSessions exposes the number of signed-in sessions, counted
+// when metrics are scraped. Errors are logged and reported as zero.
+func RegisterActiveSessions(count func(ctx context.Context) (int, error)) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "active_sessions",
+		Help:      "Signed-in user sessions that have not expired.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		n, err := count(ctx)
+		if err != nil {
+			slog.Error("failed to count active sessions for metrics", "error", err)
+			return 0
+		}
+		return float64(n)
+	}))
+}
+
+// MetricsHandler serves the metrics in the Prometheus text format. With
+// METRICS_TOKEN set, scrapers must send it as a bearer token; without it,
+// only direct requests from loopback or private networks are answered, so
+// requests forwarded by the public proxy are refused.
+func MetricsHandler() http.Handler {
+	token := os.Getenv("METRICS_TOKEN")
+	metrics := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !metricsAllowed(r, token) {
+			http.NotFound(w, r)
+			return
+		}
+		metrics.ServeHTTP(w, r)
+	})
+}
+
+// metricsAllowed decides whether a request may read the metrics
+func metricsAllowed(r *http.Request, token string) bool {
+	if token != "" {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		return ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+	}
+	if r.Header.Get("X-Forwarded-For") != "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package observability
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quietPrefixes are paths whose successful requests are only logged at
+// debug level; a page load fetches a dozen static files
+var quietPrefixes = []string{"/static/", "/metrics"}
+
+// Middleware gives each request an ID, logs it once it completes and
+// records its count and latency under the route pattern it matched in mux
+func Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		r = r.WithContext(WithRequestID(r.Context(), id))
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		elapsed := time.Since(start)
+
+		route := routeLabel(mux, r)
+		httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		httpDuration.WithLabelValues(route, r.Method).Observe(elapsed.Seconds())
+
+		level := slog.LevelInfo
+		switch {
+		case rec.status >= 500:
+			level = slog.LevelError
+		case rec.status < 400 && isQuiet(r.URL.Path):
+			level = slog.LevelDebug
+		}
+		slog.Log(r.Context(), level, "request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"route", route,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration_ms", elapsed.Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		)
+	})
+}
+
+// routeLabel names the route a request was served by, using the mux pattern
+// rather than the path so player tokens and IDs don't become label values
+func routeLabel(mux *http.ServeMux, r *http.Request) string {
+	if _, pattern := mux.Handler(r); pattern != "" {
+		return pattern
+	}
+	return "unmatched"
+}
+
+// isQuiet reports whether a path is logged at debug level when it succeeds
+func isQuiet(path string) bool {
+	for _, prefix := range quietPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseRecorder notes the status code and size of a response
+type responseRecorder struct {
+	http.ResponseWriter
+	status  int
+	bytes   int
+	written bool
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	if !w.written {
+		w.written = true
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.written = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
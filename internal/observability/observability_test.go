@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package observability
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Each request gets an ID that reaches handler logs through the context,
+// and metrics are labelled by route pattern rather than the raw path.
+func TestMiddlewareRequestIDAndMetrics(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(newHandler(&logs, "json", "debug")))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	mux := http.NewServeMux()
+	var seen string
+	mux.HandleFunc("/my-availability/", func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+		slog.InfoContext(r.Context(), "loading availability")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.Handle("/metrics", MetricsHandler())
+	handler := Middleware(mux, mux)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/my-availability/Sabalenka_Djokovic_Gauff_Sinner", nil))
+	id := rec.Header().Get(RequestIDHeader)
+	if id == "" || id != seen {
+		t.Fatalf("response ID %q, handler saw %q", id, seen)
+	}
+
+	var tagged int
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		if entry["request_id"] == id {
+			tagged++
+		}
+		if entry["msg"] == "request" && (entry["route"] != "/my-availability/" || entry["status"] != float64(http.StatusAccepted)) {
+			t.Errorf("request log = %v", entry)
+		}
+	}
+	if tagged != 2 {
+		t.Errorf("%d log lines carry the request ID, want 2 (handler and request):\n%s", tagged, logs.String())
+	}
+
+	// A well-formed ID from the proxy is kept; anything else is replaced
+	req := httptest.NewRequest(http.MethodGet, "/my-availability/x", nil)
+	req.Header.Set(RequestIDHeader, "caddy-0123456789")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(RequestIDHeader); got != "caddy-0123456789" {
+		t.Errorf("incoming request ID replaced with %q", got)
+	}
+	req.Header.Set(RequestIDHeader, "<script>")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(RequestIDHeader); got == "<script>" {
+		t.Error("malformed request ID was trusted")
+	}
+
+	scrape := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := scrape("203.0.113.9:4000", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("public scrape: status %d, want 404", rec.Code)
+	}
+	if rec := scrape("172.18.0.3:4000", "203.0.113.9"); rec.Code != http.StatusNotFound {
+		t.Errorf("scrape forwarded by the proxy: status %d, want 404", rec.Code)
+	}
+	rec = scrape("172.18.0.4:4000", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("private scrape: status %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `jimtennis_http_requests_total{method="GET",route="/my-availability/",status="202"} 3`) {
+		t.Errorf("request counter missing from metrics:\n%s", body)
+	}
+	if strings.Contains(body, "Sabalenka") {
+		t.Error("metrics expose a player token")
+	}
+}
@@ -75,6 +75,16 @@ func (h *AvailabilityHandler) HandleAvailability(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Sub offer sub-route: /my-availability/{token}/sub-offer/{offerID}
+	if action == "sub-offer" && len(pathParts) > 2 {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleSubOffer(w, r, &player, authToken, pathParts[2])
+		return
+	}
+
 	// Route based on action and method
 	switch {
 	case action == "data" && r.Method == http.MethodGet:
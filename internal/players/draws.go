@@ -0,0 +1,116 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// DrawsHandler handles the public cup draw brackets
+type DrawsHandler struct {
+	service     *Service
+	templateDir string
+}
+
+// NewDrawsHandler creates a new draws handler
+func NewDrawsHandler(service *Service, templateDir string) *DrawsHandler {
+	return &DrawsHandler{service: service, templateDir: templateDir}
+}
+
+// Bracket is a cup draw laid out for the public draws page, one column per round
+type Bracket struct {
+	Draw   models.TournamentDraw
+	Rounds []BracketRound
+}
+
+// BracketRound is one column of a bracket
+type BracketRound struct {
+	Name    string
+	Matches []models.TournamentMatch
+}
+
+// DrawsPageData holds all data for the draws template
+type DrawsPageData struct {
+	Tournament *models.Tournament
+	Draws      []Bracket
+}
+
+// GetPublicDraws loads a visible tournament and its draws as brackets. A
+// hidden or missing tournament returns a nil tournament.
+func (s *Service) GetPublicDraws(ctx context.Context, tournamentID uint) (*models.Tournament, []Bracket, error) {
+	tournament, err := s.tournamentRepository.FindByID(ctx, tournamentID)
+	if err != nil || !tournament.IsVisible {
+		return nil, nil, nil
+	}
+	draws, err := s.tournamentDrawRepository.FindByTournament(ctx, tournament.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tournament, bracketsFor(draws), nil
+}
+
+// bracketsFor groups each draw's matches into rounds. Matches arrive sorted
+// by round and position, so each round keeps draw order.
+func bracketsFor(draws []models.TournamentDraw) []Bracket {
+	brackets := make([]Bracket, 0, len(draws))
+	for _, draw := range draws {
+		b := Bracket{Draw: draw}
+		roundIndex := make(map[int]int)
+		for _, match := range draw.Matches {
+			i, ok := roundIndex[match.RoundNumber]
+			if !ok {
+				name := match.RoundName
+				if name == "" {
+					name = fmt.Sprintf("Round %d", match.RoundNumber)
+				}
+				i = len(b.Rounds)
+				roundIndex[match.RoundNumber] = i
+				b.Rounds = append(b.Rounds, BracketRound{Name: name})
+			}
+			b.Rounds[i].Matches = append(b.Rounds[i].Matches, match)
+		}
+		brackets = append(brackets, b)
+	}
+	return brackets
+}
+
+// HandleDraws handles GET /draws/{tournamentID}
+func (h *DrawsHandler) HandleDraws(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/draws/"), 10, 32)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	tournament, brackets, err := h.service.GetPublicDraws(r.Context(), uint(id))
+	if err != nil {
+		log.Printf("Failed to load draws for tournament %d: %v", id, err)
+		http.Error(w, "Failed to load draws", http.StatusInternalServerError)
+		return
+	}
+	if tournament == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "players/draws.html")
+	if err != nil {
+		log.Printf("Error parsing draws template: %v", err)
+		http.Error(w, "Failed to load page", http.StatusInternalServerError)
+		return
+	}
+	if err := renderTemplate(w, tmpl, DrawsPageData{Tournament: tournament, Draws: brackets}); err != nil {
+		log.Printf("Error rendering draws template: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jim-dot-tennis/internal/database"
+)
+
+// The public draws page lays each visible tournament's draws out by round
+// and hides tournaments that aren't published
+func TestPublicDrawsPage(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "draws.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+	exec(`INSERT INTO tournament_providers (id, name, provider_abbr) VALUES (1, 'Parks League Cup', 'PLC')`)
+	exec(`INSERT INTO tournaments (id, name, courthive_tournament_id, provider_id, is_visible) VALUES (1, 'Parks Cup', 'cup-2026', 1, 1), (2, 'Draft Cup', 'draft', 1, 0)`)
+	exec(`INSERT INTO tournament_draws (id, tournament_id, courthive_draw_id, name, event_name) VALUES (1, 1, 'mixed', 'Main Draw', 'Mixed Doubles')`)
+	exec(`INSERT INTO tournament_matches (draw_id, courthive_match_up_id, round_number, round_position, round_name, side1_name, side2_name, winning_side, score) VALUES
+		(1, 'sf1', 1, 1, 'Semi Final', 'A. Able & B. Baker', 'C. Cole & D. Dean', 1, '6-4 6-3'),
+		(1, 'sf2', 1, 2, 'Semi Final', 'E. Ede & F. Ford', 'G. Gray & H. Hill', NULL, ''),
+		(1, 'f', 2, 1, '', 'A. Able & B. Baker', '', NULL, '')`)
+
+	h := New(db, filepath.Join(filepath.Dir(findMigrationsPath(t)), "templates"), 1)
+	mux := http.NewServeMux()
+	h.RegisterPublicRoutes(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/draws/1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("visible tournament = %d %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"Parks Cup", "Mixed Doubles", "Semi Final", "Round 2", "6-4 6-3", "C. Cole &amp; D. Dean"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("draws page is missing %q", want)
+		}
+	}
+	if strings.Index(body, "Semi Final") > strings.Index(body, "Round 2") {
+		t.Errorf("rounds are out of order")
+	}
+
+	for _, path := range []string{"/draws/2", "/draws/99", "/draws/abc"} {
+		if rec := get(path); rec.Code != http.StatusNotFound {
+			t.Errorf("%s = %d; want 404", path, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// EntryPage is what a player sees on their cup entries page
+type EntryPage struct {
+	OpenEvents []OpenEvent
+	Entries    []models.TournamentEntry
+	Partners   []PartnerOption
+}
+
+// OpenEvent is an event still taking entries, with whether the player is
+// already on an entry for it
+type OpenEvent struct {
+	models.TournamentEvent
+	Entered bool
+}
+
+// GetEntryPage loads the events a player can enter, the entries they are on
+// and the club-mates they can pick as a doubles partner
+func (s *Service) GetEntryPage(ctx context.Context, playerID string) (*EntryPage, error) {
+	events, err := s.tournamentEntryRepository.FindOpenEvents(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open events: %w", err)
+	}
+	page := &EntryPage{}
+	for _, event := range events {
+		entered, err := s.tournamentEntryRepository.IsEntered(ctx, event.ID, playerID)
+		if err != nil {
+			return nil, err
+		}
+		page.OpenEvents = append(page.OpenEvents, OpenEvent{TournamentEvent: event, Entered: entered})
+	}
+
+	if page.Entries, err = s.tournamentEntryRepository.FindByPlayer(ctx, playerID); err != nil {
+		return nil, fmt.Errorf("failed to load entries: %w", err)
+	}
+	if page.Partners, err = s.GetPartnerRoster(playerID); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// EnterTournamentEvent enters a player, with partnerID for doubles, into an
+// event. Reasons the entry isn't allowed are returned as a
+// *services.EntryError.
+func (s *Service) EnterTournamentEvent(ctx context.Context, playerID string, eventID uint, partnerID string) (*models.TournamentEntry, error) {
+	var entry *models.TournamentEntry
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		event, err := s.tournamentEntryRepository.FindEventByID(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("event %d not found: %w", eventID, err)
+		}
+		player, err := s.playerRepository.FindByID(ctx, playerID)
+		if err != nil {
+			return fmt.Errorf("player not found: %w", err)
+		}
+
+		var partner *models.Player
+		if partnerID != "" {
+			partner, err = s.playerRepository.FindByID(ctx, partnerID)
+			if err != nil || partner.ClubID != player.ClubID {
+				return &services.EntryError{Reason: "Your partner must be a member of your club."}
+			}
+		}
+		if err := services.CheckEntryEligibility(event, player, partner, time.Now()); err != nil {
+			return err
+		}
+
+		entrants := []*models.Player{player}
+		if partner != nil {
+			entrants = append(entrants, partner)
+		}
+		for _, p := range entrants {
+			entered, err := s.tournamentEntryRepository.IsEntered(ctx, eventID, p.ID)
+			if err != nil {
+				return err
+			}
+			if entered {
+				if p.ID == playerID {
+					return &services.EntryError{Reason: "You're already entered in " + event.Name + "."}
+				}
+				return &services.EntryError{Reason: p.FirstName + " is already entered in " + event.Name + "."}
+			}
+		}
+
+		entry = &models.TournamentEntry{EventID: eventID, PlayerID: playerID}
+		if partner != nil {
+			entry.PartnerID = &partner.ID
+		}
+		return s.tournamentEntryRepository.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// WithdrawTournamentEntry withdraws an entry the player made, or was entered
+// into as a partner, while the event is still taking entries. An entry
+// already in CourtHive keeps its sync status, which flags it to admins as
+// out of date until their next export removes it.
+func (s *Service) WithdrawTournamentEntry(ctx context.Context, playerID string, entryID uint) error {
+	entry, err := s.tournamentEntryRepository.FindByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if entry.PlayerID != playerID && (entry.PartnerID == nil || *entry.PartnerID != playerID) {
+		return fmt.Errorf("player %s is not on entry %d", playerID, entryID)
+	}
+	event, err := s.tournamentEntryRepository.FindEventByID(ctx, entry.EventID)
+	if err != nil {
+		return err
+	}
+	if !time.Now().Before(event.EntryDeadline) {
+		return &services.EntryError{Reason: "Entries for " + event.Name + " have closed, so please contact the club to withdraw."}
+	}
+	return s.tournamentEntryRepository.UpdateStatus(ctx, entryID, models.EntryWithdrawn)
+}
+
+// handleEntries shows the events a player can enter (GET) or enters or
+// withdraws them (POST) for /my-profile/{token}/entries
+func (h *ProfileHandler) handleEntries(w http.ResponseWriter, r *http.Request, player *models.Player, authToken string) {
+	ctx := r.Context()
+
+	message, problem := "", ""
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form", http.StatusBadRequest)
+			return
+		}
+		var err error
+		switch r.FormValue("action") {
+		case "enter":
+			eventID, parseErr := strconv.ParseUint(r.FormValue("event_id"), 10, 32)
+			if parseErr != nil {
+				http.Error(w, "Invalid event ID", http.StatusBadRequest)
+				return
+			}
+			_, err = h.service.EnterTournamentEvent(ctx, player.ID, uint(eventID), r.FormValue("partner_id"))
+			message = "Thanks — your entry has been received and is waiting for approval."
+		case "withdraw":
+			entryID, parseErr := strconv.ParseUint(r.FormValue("entry_id"), 10, 32)
+			if parseErr != nil {
+				http.Error(w, "Invalid entry ID", http.StatusBadRequest)
+				return
+			}
+			err = h.service.WithdrawTournamentEntry(ctx, player.ID, uint(entryID))
+			message = "Your entry has been withdrawn."
+		default:
+			http.Error(w, "Invalid action", http.StatusBadRequest)
+			return
+		}
+
+		var entryErr *services.EntryError
+		if errors.As(err, &entryErr) {
+			message, problem = "", entryErr.Reason
+		} else if err != nil {
+			logAndError(w, "Failed to update your entry", err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := h.service.GetEntryPage(ctx, player.ID)
+	if err != nil {
+		logAndError(w, "Failed to load cup entries", err, http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "players/entries.html")
+	if err != nil {
+		log.Printf("Error parsing entries template: %v", err)
+		renderFallbackHTML(w, "Cup Entries", "Cup Entries",
+			"Cup entries page - template error",
+			fmt.Sprintf("/my-availability/%s", authToken))
+		return
+	}
+
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"Player":    player,
+		"AuthToken": authToken,
+		"Page":      page,
+		"Message":   message,
+		"Problem":   problem,
+	}); err != nil {
+		logAndError(w, err.Error(), err, http.StatusInternalServerError)
+	}
+}
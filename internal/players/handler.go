@@ -7,6 +7,7 @@ import (
 
 	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/webpush"
 )
 
 // Handler represents the players handler
@@ -18,11 +19,12 @@ type Handler struct {
 	availability *AvailabilityHandler
 	profile      *ProfileHandler
 	standings    *StandingsHandler
+	draws        *DrawsHandler
 }
 
 // New creates a new players handler
-func New(db *database.DB, templateDir string, homeClubID uint) *Handler {
-	service := NewService(db, homeClubID)
+func New(db *database.DB, templateDir string, homeClubID uint, pushService ...*webpush.Service) *Handler {
+	service := NewService(db, homeClubID, pushService...)
 
 	return &Handler{
 		service:      service,
@@ -30,6 +32,7 @@ func New(db *database.DB, templateDir string, homeClubID uint) *Handler {
 		availability: NewAvailabilityHandler(service, templateDir),
 		profile:      NewProfileHandler(service, templateDir),
 		standings:    NewStandingsHandler(service, templateDir),
+		draws:        NewDrawsHandler(service, templateDir),
 	}
 }
 
@@ -46,10 +49,24 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middle
 	mux.Handle("/my-profile/", authMiddleware.RequireFantasyTokenAuth(
 		http.HandlerFunc(h.profile.HandleProfile),
 	))
+
+	// Push notification action buttons. The signed link in the path is the
+	// credential (player, fixture and answer are all inside it), so this route
+	// deliberately sits outside the fantasy token middleware.
+	mux.HandleFunc("/push-action/availability/", h.availability.HandlePushAvailabilityAction)
+
+	// Short-lived signed links sent in notifications. They resolve to the
+	// player's current link at click time, so they also sit outside the
+	// middleware and never expose the permanent token in a push payload.
+	mux.HandleFunc("/go/", h.availability.HandleDeepLink)
 }
 
 // RegisterPublicRoutes registers public-facing player routes (no auth required)
 func (h *Handler) RegisterPublicRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/standings", h.standings.HandleStandings)
 	mux.HandleFunc("/standings/", h.standings.HandleStandings)
+	mux.HandleFunc("/standings/head-to-head", h.standings.HandleHeadToHead)
+	mux.HandleFunc("/standings/projections", h.standings.HandleProjections)
+	mux.HandleFunc("/standings/card/", h.standings.HandleShareCard)
+	mux.HandleFunc("/draws/", h.draws.HandleDraws)
 }
@@ -0,0 +1,80 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+)
+
+// HeadToHeadPageData holds data for the public head-to-head page
+type HeadToHeadPageData struct {
+	HeadToHead   *services.HeadToHead
+	Clubs        []models.Club
+	ClubID       uint
+	OpponentClub uint
+	HomeClubID   uint
+	Problem      string
+}
+
+// HandleHeadToHead handles GET /standings/head-to-head. Two teams are
+// compared with ?team=&opponent= and two clubs with ?club=&opponent_club=.
+func (h *StandingsHandler) HandleHeadToHead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	query := r.URL.Query()
+	queryID := func(key string) uint {
+		id, err := strconv.ParseUint(query.Get(key), 10, 32)
+		if err != nil {
+			return 0
+		}
+		return uint(id)
+	}
+
+	data := HeadToHeadPageData{
+		ClubID:       queryID("club"),
+		OpponentClub: queryID("opponent_club"),
+		HomeClubID:   h.service.homeClubID,
+	}
+	if data.ClubID == 0 {
+		data.ClubID = data.HomeClubID
+	}
+
+	clubs, err := h.service.clubRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("Failed to load clubs: %v", err)
+	}
+	data.Clubs = clubs
+
+	var h2h *services.HeadToHead
+	switch {
+	case queryID("team") != 0 && queryID("opponent") != 0:
+		h2h, err = h.headToHead.Teams(ctx, queryID("team"), queryID("opponent"))
+	case data.ClubID != 0 && data.OpponentClub != 0:
+		h2h, err = h.headToHead.Clubs(ctx, data.ClubID, data.OpponentClub)
+	}
+	if err != nil {
+		log.Printf("Failed to build head-to-head: %v", err)
+		data.Problem = "We couldn't find a record between those two."
+	}
+	data.HeadToHead = h2h
+
+	tmpl, err := parseTemplate(h.templateDir, "players/head_to_head.html")
+	if err != nil {
+		log.Printf("Error parsing head-to-head template: %v", err)
+		renderFallbackHTML(w, "Head to Head", "Head to Head",
+			"Head-to-head page - template error", "/standings")
+		return
+	}
+	if err := renderTemplate(w, tmpl, data); err != nil {
+		log.Printf("Error rendering head-to-head: %v", err)
+	}
+}
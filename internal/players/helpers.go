@@ -2,6 +2,8 @@
 
 package players
 
+import "strings"
+
 // initialsFor renders a player's name as initials (e.g. "A.B.") for use on
 // shareable token URLs, where full names must never leak.
 func initialsFor(first, last string) string {
@@ -15,6 +17,19 @@ func initialsFor(first, last string) string {
 	return out
 }
 
+// initialsForName gives the initials of a full name such as a CourtHive
+// participant's, from its first and last words
+func initialsForName(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+	if len(words) == 1 {
+		return initialsFor(words[0], "")
+	}
+	return initialsFor(words[0], words[len(words)-1])
+}
+
 func firstRune(s string) string {
 	for _, r := range s {
 		return string(r)
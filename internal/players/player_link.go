@@ -0,0 +1,162 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/ratelimit"
+	"jim-dot-tennis/internal/services"
+)
+
+// LinkDevice is one device that has opened a player's link, for display
+type LinkDevice struct {
+	Device      string
+	IP          string
+	AccessCount int
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	Current     bool // Seen on the player's current link rather than a retired one
+}
+
+// GetLinkDevices lists the devices that have used any of a player's links
+func (s *Service) GetLinkDevices(ctx context.Context, playerID, currentToken string) ([]LinkDevice, error) {
+	accesses, err := s.playerTokenRepository.FindAccessesByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]LinkDevice, 0, len(accesses))
+	for _, a := range accesses {
+		devices = append(devices, LinkDevice{
+			Device:      services.DescribeDevice(a.UserAgent),
+			IP:          a.IP,
+			AccessCount: a.AccessCount,
+			FirstSeenAt: a.FirstSeenAt,
+			LastSeenAt:  a.LastSeenAt,
+			Current:     a.Token == currentToken,
+		})
+	}
+	return devices, nil
+}
+
+// RotateOwnLink gives a player a new private link at their own request. The
+// old link stops working immediately: a player rotating their own link has
+// usually shared it by mistake. pushEndpoint is the push subscription of the
+// device they asked from, if any, which keeps its notifications.
+func (s *Service) RotateOwnLink(ctx context.Context, playerID, pushEndpoint string) (string, error) {
+	rotator := services.NewPlayerLinkRotator(s.db, s.playerRepository, s.fantasyRepository, s.playerTokenRepository, s.pushService)
+	match, err := rotator.Rotate(ctx, playerID, 0, "Rotated by player", services.LinkRotatedByPlayer, pushEndpoint)
+	if err != nil {
+		return "", err
+	}
+	return match.AuthToken, nil
+}
+
+// handleLink shows the player's private link and the devices that have used
+// it (GET), or replaces it with a new one (POST)
+func (h *ProfileHandler) handleLink(w http.ResponseWriter, r *http.Request, player *models.Player, authToken string) {
+	if r.Method == http.MethodPost {
+		newToken, err := h.service.RotateOwnLink(r.Context(), player.ID, r.FormValue("push_endpoint"))
+		if err != nil {
+			logAndError(w, "Failed to create a new link", err, http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/my-profile/%s/link?rotated=1", newToken), http.StatusSeeOther)
+		return
+	}
+
+	devices, err := h.service.GetLinkDevices(r.Context(), player.ID, authToken)
+	if err != nil {
+		logAndError(w, "Failed to load link activity", err, http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "players/my_link.html")
+	if err != nil {
+		log.Printf("Error parsing my link template: %v", err)
+		renderFallbackHTML(w, "Your Link", "Your Private Link",
+			"Link settings page - template error",
+			fmt.Sprintf("/my-availability/%s", authToken))
+		return
+	}
+
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"Player":    player,
+		"AuthToken": authToken,
+		"Devices":   devices,
+		"Rotated":   r.URL.Query().Get("rotated") == "1",
+	}); err != nil {
+		logAndError(w, err.Error(), err, http.StatusInternalServerError)
+	}
+}
+
+// ResolveDeepLink verifies a /go/ link and returns the page it points to
+// under the player's current link. A link minted for a token that has since
+// been revoked (or whose grace period has ended) is refused as expired.
+func (s *Service) ResolveDeepLink(ctx context.Context, signedToken string) (string, error) {
+	claims, err := s.linkSigner.Verify(signedToken, auth.LinkPurposeDeepLink)
+	if err != nil {
+		return "", err
+	}
+	if claims.Resource != "" && (!strings.HasPrefix(claims.Resource, "/") || strings.Contains(claims.Resource, "..")) {
+		return "", auth.ErrLinkInvalid
+	}
+
+	current := s.playerFantasyToken(ctx, claims.Subject)
+	if current == "" {
+		return "", auth.ErrLinkExpired
+	}
+	if auth.TokenFingerprint(current) != claims.Action {
+		retired, err := s.playerTokenRepository.FindRetiredByPlayer(ctx, claims.Subject)
+		if err != nil {
+			return "", err
+		}
+		valid := false
+		for _, t := range retired {
+			if auth.TokenFingerprint(t.Token) == claims.Action && t.InGrace(time.Now()) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "", auth.ErrLinkExpired
+		}
+	}
+
+	return "/my-availability/" + current + claims.Resource, nil
+}
+
+// HandleDeepLink handles GET /go/{signedToken}, the short-lived links carried
+// by notifications in place of the player's permanent link
+func (h *AvailabilityHandler) HandleDeepLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	signedToken := strings.TrimPrefix(r.URL.Path, "/go/")
+	target, err := h.service.ResolveDeepLink(r.Context(), signedToken)
+	switch {
+	case errors.Is(err, auth.ErrLinkExpired):
+		ratelimit.ReportFailure(r.Context())
+		http.Error(w, "This notification link has expired. Open Jim.Tennis from your private link instead.", http.StatusGone)
+		return
+	case errors.Is(err, auth.ErrLinkInvalid):
+		ratelimit.ReportFailure(r.Context())
+		http.Error(w, "Invalid link", http.StatusForbidden)
+		return
+	case err != nil:
+		logAndError(w, "Failed to open link", err, http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, target, http.StatusFound)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/services"
+	"jim-dot-tennis/internal/webpush"
+)
+
+// Rotating a link with a grace period must redirect the old link to the new
+// one (deep links follow along) and retire push action buttons minted for it;
+// rotating it again without grace must refuse every earlier link, and deep
+// links minted for them, outright, dropping every push subscription but the
+// one on the device the player rotated from.
+func TestPlayerLinkRotationGraceAndRevocation(t *testing.T) {
+	t.Setenv("LINK_SIGNING_SECRET", "test-secret")
+	dbPath := filepath.Join(t.TempDir(), "player_link_test.db")
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'Home') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('p1', 'Test', 'Player', 1)`)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026', 2026, '2026-04-01', '2026-09-30', 1)`)
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, '2026-04-01', '2026-04-07', '')`)
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name) VALUES (2, 'Away') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'Home A', 1, 1, 1), (2, 'Away A', 2, 1, 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (7, 1, 2, 1, 1, 1, '2026-04-03', '', 'Scheduled', '')`)
+	for i := 1; i <= 8; i++ {
+		tour, gender := "ATP", "Men"
+		if i%2 == 0 {
+			tour, gender = "WTA", "Women"
+		}
+		exec(`INSERT INTO tennis_players (id, first_name, last_name, common_name, nationality, gender, current_rank,
+			highest_rank, year_pro, wikipedia_url, hand, birth_date, birth_place, tour)
+			VALUES (?, 'Pro', ?, ?, 'GBR', ?, ?, ?, 2010, '', 'Right', '1990-01-01', '', ?)`,
+			i, fmt.Sprintf("Surname%d", i), fmt.Sprintf("Pro %d", i), gender, i, i, tour)
+	}
+
+	push := webpush.New(db)
+	svc := NewService(db, 1, push)
+	first, err := svc.fantasyRepository.CreateRandomMatch(ctx)
+	if err != nil {
+		t.Fatalf("create first link: %v", err)
+	}
+	exec(`UPDATE players SET fantasy_match_id = ? WHERE id = 'p1'`, first.ID)
+
+	middleware := auth.NewMiddleware(nil, svc.playerRepository, svc.fantasyRepository, svc.playerTokenRepository)
+	protected := middleware.RequireFantasyTokenAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/my-availability/"+token+"/fixture/7", nil)
+		req.Header.Set("User-Agent", "WhatsApp/2.23")
+		rec := httptest.NewRecorder()
+		protected.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(first.AuthToken); rec.Code != http.StatusOK {
+		t.Fatalf("first link: status %d, want 200", rec.Code)
+	}
+	deepLink := svc.linkSigner.NotificationLink("p1", first.AuthToken, "/fixture/7")
+	actionLink, err := svc.linkSigner.Sign(auth.LinkClaims{
+		Purpose:   auth.LinkPurposeAvailabilityAction,
+		Subject:   "p1",
+		Resource:  "7",
+		Action:    "Available",
+		Token:     auth.TokenFingerprint(first.AuthToken),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("sign action link: %v", err)
+	}
+	if _, err := svc.ApplyAvailabilityAction(ctx, actionLink); err != nil {
+		t.Fatalf("action link for the current link: %v", err)
+	}
+
+	rotator := services.NewPlayerLinkRotator(db, svc.playerRepository, svc.fantasyRepository, svc.playerTokenRepository, push)
+	second, err := rotator.Rotate(ctx, "p1", 24*time.Hour, "leaked", "admin", "")
+	if err != nil {
+		t.Fatalf("rotate with grace: %v", err)
+	}
+
+	rec := get(first.AuthToken)
+	want := "/my-availability/" + second.AuthToken + "/fixture/7"
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != want {
+		t.Fatalf("old link in grace: %d %q, want 302 %q", rec.Code, rec.Header().Get("Location"), want)
+	}
+	target, err := svc.ResolveDeepLink(ctx, deepLink[len("/go/"):])
+	if err != nil || target != want {
+		t.Fatalf("deep link in grace = %q, %v; want %q", target, err, want)
+	}
+	if _, err := svc.ApplyAvailabilityAction(ctx, actionLink); !errors.Is(err, auth.ErrLinkExpired) {
+		t.Errorf("action link after rotation: err = %v, want ErrLinkExpired", err)
+	}
+
+	p256dh, authSecret := browserKeys(t)
+	for _, endpoint := range []string{"https://push.example/own-phone", "https://push.example/whoever-found-it"} {
+		if err := push.SaveSubscription(&webpush.Subscription{Endpoint: endpoint, P256dh: p256dh, Auth: authSecret, PlayerToken: &second.AuthToken}); err != nil {
+			t.Fatalf("save subscription: %v", err)
+		}
+	}
+
+	third, err := svc.RotateOwnLink(ctx, "p1", "https://push.example/own-phone")
+	if err != nil {
+		t.Fatalf("self rotate: %v", err)
+	}
+	subs, err := push.GetSubscriptionsByPlayerToken(third)
+	if err != nil || len(subs) != 1 || subs[0].Endpoint != "https://push.example/own-phone" {
+		t.Errorf("subscriptions after self rotate = %+v, %v; want only the player's own phone", subs, err)
+	}
+	for _, token := range []string{first.AuthToken, second.AuthToken} {
+		if rec := get(token); rec.Code != http.StatusGone {
+			t.Errorf("revoked link %s: status %d, want 410", token, rec.Code)
+		}
+	}
+	if rec := get(third); rec.Code != http.StatusOK {
+		t.Errorf("current link: status %d, want 200", rec.Code)
+	}
+	if _, err := svc.ResolveDeepLink(ctx, deepLink[len("/go/"):]); !errors.Is(err, auth.ErrLinkExpired) {
+		t.Errorf("deep link after revocation: err = %v, want ErrLinkExpired", err)
+	}
+
+	devices, err := svc.GetLinkDevices(ctx, "p1", third)
+	if err != nil {
+		t.Fatalf("load devices: %v", err)
+	}
+	// The first link was opened and then redirected; refused requests aren't logged
+	if len(devices) != 2 || !devices[0].Current || devices[1].AccessCount != 2 || devices[0].Device != "WhatsApp link preview" {
+		t.Errorf("devices = %+v, want the current link plus two visits on the first", devices)
+	}
+
+	// A rotation that fails part way leaves the player on their current link
+	exec(`DROP TABLE push_deliveries`)
+	if _, err := svc.RotateOwnLink(ctx, "p1", ""); err == nil {
+		t.Fatalf("rotate without push_deliveries succeeded")
+	}
+	if rec := get(third); rec.Code != http.StatusOK {
+		t.Errorf("link after failed rotation: status %d, want 200", rec.Code)
+	}
+	if retired, _ := svc.playerTokenRepository.FindRetiredByPlayer(ctx, "p1"); len(retired) != 2 {
+		t.Errorf("retired links after failed rotation = %d, want 2", len(retired))
+	}
+}
+
+// browserKeys returns a p256dh/auth pair like a browser would hand over on
+// subscribe, so the real payload encryption runs in the test
+func browserKeys(t *testing.T) (p256dh, auth string) {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate auth: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(secret)
+}
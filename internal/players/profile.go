@@ -60,6 +60,10 @@ func (h *ProfileHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 	// GET /my-profile/{token}         → blank 'My Tennis' form (write-only; WI-095/WI-097)
 	// POST /my-profile/{token}        → merge-save partial preferences (WI-097)
 	// GET /my-profile/{token}/history → match history (initials-only; WI-093)
+	// GET /my-profile/{token}/link    → private link and devices that used it
+	// POST /my-profile/{token}/link   → replace the link, revoking the old one
+	// GET /my-profile/{token}/entries  → open cup events and the player's entries
+	// POST /my-profile/{token}/entries → enter an event, or withdraw an entry
 	switch {
 	case action == "" && r.Method == http.MethodGet:
 		h.myTennis.HandleGet(w, r, &player, authToken)
@@ -67,6 +71,10 @@ func (h *ProfileHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 		h.myTennis.HandlePost(w, r, &player, authToken)
 	case action == "history" && r.Method == http.MethodGet:
 		h.handleMatchHistory(w, r, &player, authToken)
+	case action == "link" && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+		h.handleLink(w, r, &player, authToken)
+	case action == "entries" && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+		h.handleEntries(w, r, &player, authToken)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -0,0 +1,375 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+const (
+	// projectionRuns is how many times the rest of the season is simulated
+	projectionRuns = 5000
+
+	// rubbersPerFixture is one each of Mens, Womens, 1st and 2nd Mixed,
+	// worth two points apiece
+	rubbersPerFixture = 4
+
+	// strengthPrior credits every team with this many rubbers at 50%, so a
+	// team that has won its only rubber isn't treated as unbeatable
+	strengthPrior = 4
+
+	// projectionCacheSize is how many projections are kept per division,
+	// the plain one and recent what-ifs
+	projectionCacheSize = 32
+)
+
+// projectionCache keeps simulated projections per division so a public page
+// view doesn't rerun the simulation. Entries are keyed by the table and
+// fixtures they were simulated from, so entering a result, which changes
+// the table and the remaining fixtures, invalidates them. The simulation is
+// seeded per division, so a cached projection is the one a rerun would give.
+type projectionCache struct {
+	mu        sync.Mutex
+	divisions map[uint]map[string][]TeamProjection
+}
+
+func newProjectionCache() *projectionCache {
+	return &projectionCache{divisions: make(map[uint]map[string][]TeamProjection)}
+}
+
+// get returns a cached projection, or simulates and caches one. A division
+// whose cache is full is cleared, dropping stale keys and old what-ifs.
+func (c *projectionCache) get(divisionID uint, key string, simulate func() []TeamProjection) []TeamProjection {
+	c.mu.Lock()
+	projections, ok := c.divisions[divisionID][key]
+	c.mu.Unlock()
+	if ok {
+		return projections
+	}
+
+	projections = simulate()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := c.divisions[divisionID]
+	if cached == nil || len(cached) >= projectionCacheSize {
+		cached = make(map[string][]TeamProjection)
+		c.divisions[divisionID] = cached
+	}
+	cached[key] = projections
+	return projections
+}
+
+// projectionKey identifies what a projection was simulated from: each
+// team's record and each remaining fixture with its what-if
+func projectionKey(table []TeamStanding, fixtures []RemainingFixture) string {
+	var b strings.Builder
+	for _, ts := range table {
+		fmt.Fprintf(&b, "t%d:%d/%d/%d/%d/%d/%d/%d;", ts.TeamID, ts.Played, ts.Won, ts.Drawn, ts.Lost,
+			ts.RubberFor, ts.RubberAgainst, ts.LeaguePoints)
+	}
+	for _, f := range fixtures {
+		fmt.Fprintf(&b, "f%d:%d-%d=%d;", f.ID, f.HomeTeamID, f.AwayTeamID, f.WhatIf)
+	}
+	return b.String()
+}
+
+// RemainingFixture is a scheduled fixture still to be played in a division
+type RemainingFixture struct {
+	ID            uint      `db:"id"`
+	WeekNumber    int       `db:"week_number"`
+	ScheduledDate time.Time `db:"scheduled_date"`
+	HomeTeamID    uint      `db:"home_team_id"`
+	AwayTeamID    uint      `db:"away_team_id"`
+	HomeTeamName  string    `db:"home_team_name"`
+	AwayTeamName  string    `db:"away_team_name"`
+
+	// WhatIf is the hypothetical home rubber points set by a captain, or
+	// -1 to leave the fixture to the simulation
+	WhatIf int
+
+	HomeWinPercent float64
+	DrawPercent    float64
+	AwayWinPercent float64
+}
+
+// TeamProjection is a team's chance of finishing in each position
+type TeamProjection struct {
+	TeamStanding
+	Chances        []PositionChance // indexed by finishing position - 1
+	ExpectedPoints float64
+}
+
+// PositionChance is the chance of one finishing position, with a heat
+// level from 0 to 4 for shading the grid
+type PositionChance struct {
+	Percent float64
+	Heat    int
+}
+
+// ProjectionsPageData holds data for the projections and what-if page
+type ProjectionsPageData struct {
+	Season        *models.Season
+	Divisions     []models.Division
+	Division      *models.Division
+	Table         []TeamStanding
+	Projections   []TeamProjection
+	Positions     []int
+	Fixtures      []RemainingFixture
+	ScoreOptions  []int // home rubber points a what-if can set
+	FixturePoints int
+	WhatIf        bool
+	Runs          int
+	HomeClubID    uint
+}
+
+// HandleProjections handles GET /standings/projections. Each remaining
+// fixture can be given a what-if result with ?f{fixtureID}={home rubber
+// points}; the table shows those results and the rest are simulated.
+// Projections are cached per division until a result changes the table.
+func (h *StandingsHandler) HandleProjections(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	season, err := h.seasonFromRequest(r)
+	if err != nil {
+		log.Printf("No active season: %v", err)
+		http.Error(w, "No active season found", http.StatusNotFound)
+		return
+	}
+
+	divisions, err := h.service.divisionRepository.FindBySeason(ctx, season.ID)
+	if err != nil {
+		log.Printf("Failed to load divisions: %v", err)
+		http.Error(w, "Failed to load divisions", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(divisions, func(i, j int) bool { return divisions[i].Level < divisions[j].Level })
+
+	data := ProjectionsPageData{
+		Season:        season,
+		Divisions:     divisions,
+		Runs:          projectionRuns,
+		HomeClubID:    h.service.homeClubID,
+		FixturePoints: 2 * rubbersPerFixture,
+	}
+	for points := 2 * rubbersPerFixture; points >= 0; points-- {
+		data.ScoreOptions = append(data.ScoreOptions, points)
+	}
+
+	divisionID, _ := strconv.ParseUint(r.URL.Query().Get("division"), 10, 32)
+	for i := range divisions {
+		if divisions[i].ID == uint(divisionID) || (divisionID == 0 && i == 0) {
+			data.Division = &divisions[i]
+		}
+	}
+
+	if data.Division != nil {
+		table, _, err := h.calculateDivisionStandings(season.ID, data.Division.ID, h.service.homeClubID, 0)
+		if err != nil {
+			log.Printf("Failed to calculate standings for division %d: %v", data.Division.ID, err)
+			http.Error(w, "Failed to calculate standings", http.StatusInternalServerError)
+			return
+		}
+		fixtures, err := h.remainingFixtures(season.ID, data.Division.ID)
+		if err != nil {
+			log.Printf("Failed to load remaining fixtures for division %d: %v", data.Division.ID, err)
+			http.Error(w, "Failed to load fixtures", http.StatusInternalServerError)
+			return
+		}
+
+		for i := range fixtures {
+			fixtures[i].WhatIf = -1
+			if v := r.URL.Query().Get(fmt.Sprintf("f%d", fixtures[i].ID)); v != "" {
+				if points, err := strconv.Atoi(v); err == nil && points >= 0 && points <= 2*rubbersPerFixture {
+					fixtures[i].WhatIf = points
+					data.WhatIf = true
+				}
+			}
+		}
+
+		strengths := teamStrengths(table)
+		for i := range fixtures {
+			f := &fixtures[i]
+			f.HomeWinPercent, f.DrawPercent, f.AwayWinPercent = fixtureOdds(rubberWinChance(strengths, f.HomeTeamID, f.AwayTeamID))
+		}
+
+		data.Table = applyWhatIf(table, fixtures)
+		data.Projections = h.projections.get(data.Division.ID, projectionKey(table, fixtures), func() []TeamProjection {
+			rng := rand.New(rand.NewSource(int64(season.ID)<<32 | int64(data.Division.ID)))
+			return projectStandings(data.Table, fixtures, strengths, projectionRuns, rng)
+		})
+		data.Fixtures = fixtures
+		for p := 1; p <= len(table); p++ {
+			data.Positions = append(data.Positions, p)
+		}
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "players/projections.html")
+	if err != nil {
+		log.Printf("Error parsing projections template: %v", err)
+		renderFallbackHTML(w, "Projections", "Projections",
+			"Projections page - template error", "/standings")
+		return
+	}
+	if err := renderTemplate(w, tmpl, data); err != nil {
+		log.Printf("Error rendering projections: %v", err)
+	}
+}
+
+// remainingFixtures returns the division's scheduled fixtures in week order
+func (h *StandingsHandler) remainingFixtures(seasonID, divisionID uint) ([]RemainingFixture, error) {
+	var fixtures []RemainingFixture
+	err := h.service.db.Select(&fixtures, `
+		SELECT
+			f.id,
+			w.week_number,
+			f.scheduled_date,
+			f.home_team_id,
+			f.away_team_id,
+			ht.name AS home_team_name,
+			at2.name AS away_team_name
+		FROM fixtures f
+		JOIN weeks w ON f.week_id = w.id
+		JOIN teams ht ON f.home_team_id = ht.id
+		JOIN teams at2 ON f.away_team_id = at2.id
+		WHERE f.season_id = ? AND f.division_id = ? AND f.status = 'Scheduled'
+		ORDER BY w.week_number, f.scheduled_date, f.id
+	`, seasonID, divisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remaining fixtures: %w", err)
+	}
+	return fixtures, nil
+}
+
+// teamStrengths rates each team by its share of rubber points so far,
+// smoothed towards 50% by strengthPrior
+func teamStrengths(table []TeamStanding) map[uint]float64 {
+	prior := float64(2 * strengthPrior)
+	strengths := make(map[uint]float64, len(table))
+	for _, ts := range table {
+		strengths[ts.TeamID] = (float64(ts.RubberFor) + prior/2) / (float64(ts.RubberFor+ts.RubberAgainst) + prior)
+	}
+	return strengths
+}
+
+// rubberWinChance is the chance the home team wins any one rubber
+func rubberWinChance(strengths map[uint]float64, homeTeamID, awayTeamID uint) float64 {
+	home, away := strengths[homeTeamID], strengths[awayTeamID]
+	if home+away == 0 {
+		return 0.5
+	}
+	return home / (home + away)
+}
+
+// fixtureOdds turns a rubber win chance into percentage chances of a home
+// win (three or more rubbers), a draw (two each) and an away win
+func fixtureOdds(p float64) (homeWin, draw, awayWin float64) {
+	q := 1 - p
+	homeWin = p*p*p*p + 4*p*p*p*q
+	draw = 6 * p * p * q * q
+	awayWin = 1 - homeWin - draw
+	return 100 * homeWin, 100 * draw, 100 * awayWin
+}
+
+// applyWhatIf returns the table with every fixture's what-if result added
+func applyWhatIf(table []TeamStanding, fixtures []RemainingFixture) []TeamStanding {
+	teamMap := copyTable(table)
+	for _, f := range fixtures {
+		home, away := teamMap[f.HomeTeamID], teamMap[f.AwayTeamID]
+		if f.WhatIf < 0 || home == nil || away == nil {
+			continue
+		}
+		recordResult(home, away, f.WhatIf, 2*rubbersPerFixture-f.WhatIf)
+	}
+	return rankStandings(teamMap)
+}
+
+// projectStandings simulates the fixtures without a what-if result runs
+// times, playing each rubber with the teams' relative strengths, and counts
+// where each team finishes
+func projectStandings(table []TeamStanding, fixtures []RemainingFixture, strengths map[uint]float64, runs int, rng *rand.Rand) []TeamProjection {
+	finishes := make(map[uint][]int, len(table))
+	points := make(map[uint]int, len(table))
+	for _, ts := range table {
+		finishes[ts.TeamID] = make([]int, len(table))
+	}
+
+	for run := 0; run < runs; run++ {
+		teamMap := copyTable(table)
+		for _, f := range fixtures {
+			home, away := teamMap[f.HomeTeamID], teamMap[f.AwayTeamID]
+			if f.WhatIf >= 0 || home == nil || away == nil {
+				continue
+			}
+			p := rubberWinChance(strengths, f.HomeTeamID, f.AwayTeamID)
+			homePoints := 0
+			for rubber := 0; rubber < rubbersPerFixture; rubber++ {
+				if rng.Float64() < p {
+					homePoints += 2
+				}
+			}
+			recordResult(home, away, homePoints, 2*rubbersPerFixture-homePoints)
+		}
+		for pos, ts := range rankStandings(teamMap) {
+			finishes[ts.TeamID][pos]++
+			points[ts.TeamID] += ts.LeaguePoints
+		}
+	}
+
+	projections := make([]TeamProjection, 0, len(table))
+	for _, ts := range table {
+		projection := TeamProjection{
+			TeamStanding:   ts,
+			ExpectedPoints: float64(points[ts.TeamID]) / float64(runs),
+		}
+		for _, n := range finishes[ts.TeamID] {
+			percent := 100 * float64(n) / float64(runs)
+			projection.Chances = append(projection.Chances, PositionChance{Percent: percent, Heat: heatLevel(percent)})
+		}
+		projections = append(projections, projection)
+	}
+	return projections
+}
+
+// copyTable copies a table into a map for replaying more results. Form is
+// copied and positions dropped so no copy shares a slice with the original.
+func copyTable(table []TeamStanding) map[uint]*TeamStanding {
+	teamMap := make(map[uint]*TeamStanding, len(table))
+	for _, ts := range table {
+		ts := ts
+		ts.Form = append([]string(nil), ts.Form...)
+		ts.Positions = nil
+		ts.Movement = 0
+		teamMap[ts.TeamID] = &ts
+	}
+	return teamMap
+}
+
+// heatLevel buckets a percentage for shading
+func heatLevel(percent float64) int {
+	switch {
+	case percent == 0:
+		return 0
+	case percent < 10:
+		return 1
+	case percent < 25:
+		return 2
+	case percent < 50:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestProjectionsSimulateRemainingFixturesAndWhatIfs(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "projections.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	start := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026', 2026, ?, ?, 1)`, start, start.AddDate(0, 6, 0))
+	for w := 1; w <= 3; w++ {
+		exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (?, ?, 1, ?, ?, '')`,
+			w, w, start.AddDate(0, 0, 7*(w-1)), start.AddDate(0, 0, 7*(w-1)+6))
+	}
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'St Ann''s'), (2, 'Hove'), (3, 'Preston') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'Preston A', 3, 1, 1)`)
+	fixture := func(id, week, home, away int, status string) {
+		exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+			VALUES (?, ?, ?, 1, 1, ?, ?, '', ?, '')`, id, home, away, week, start.AddDate(0, 0, 7*(week-1)+3), status)
+	}
+	fixture(1, 1, 2, 3, "Completed")
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (1, 1, 'Mens', 'Finished', 8, 0, '')`)
+	fixture(2, 2, 1, 2, "Scheduled")
+	fixture(3, 3, 3, 1, "Scheduled")
+
+	h := NewStandingsHandler(NewService(db, 1), "")
+	table, _, err := h.calculateDivisionStandings(1, 1, 1, 0)
+	if err != nil {
+		t.Fatalf("standings: %v", err)
+	}
+	fixtures, err := h.remainingFixtures(1, 1)
+	if err != nil || len(fixtures) != 2 {
+		t.Fatalf("remaining fixtures = %+v, %v; want the two scheduled", fixtures, err)
+	}
+	for i := range fixtures {
+		fixtures[i].WhatIf = -1
+	}
+
+	// Every team finishes somewhere and every position is filled
+	strengths := teamStrengths(table)
+	projections := projectStandings(table, fixtures, strengths, 2000, rand.New(rand.NewSource(1)))
+	titleChance := make(map[string]float64)
+	positionTotals := make([]float64, len(table))
+	for _, p := range projections {
+		var total float64
+		for i, c := range p.Chances {
+			total += c.Percent
+			positionTotals[i] += c.Percent
+		}
+		if math.Abs(total-100) > 0.01 {
+			t.Errorf("%s's chances add up to %.2f%%", p.TeamName, total)
+		}
+		titleChance[p.TeamName] = p.Chances[0].Percent
+	}
+	for i, total := range positionTotals {
+		if math.Abs(total-100) > 0.01 {
+			t.Errorf("position %d is filled %.2f%% of the time", i+1, total)
+		}
+	}
+	// Hove have won 8-0 so are favourites for the title; Preston have lost
+	// 8-0 and need two unlikely results to top the table
+	if !(titleChance["Hove A"] > titleChance["St Ann's A"] && titleChance["St Ann's A"] > titleChance["Preston A"]) {
+		t.Errorf("title chances = %v; want Hove, then St Ann's, then Preston", titleChance)
+	}
+	if home, draw, away := fixtureOdds(rubberWinChance(strengths, 1, 2)); !(away > home) || math.Abs(home+draw+away-100) > 0.01 {
+		t.Errorf("St Ann's v Hove odds = %.0f/%.0f/%.0f; want Hove favoured and 100%% in all", home, draw, away)
+	}
+
+	// With both remaining results set, nothing is left to chance
+	fixtures[0].WhatIf = 8 // St Ann's beat Hove 8-0
+	fixtures[1].WhatIf = 0 // and win 8-0 at Preston
+	whatIf := applyWhatIf(table, fixtures)
+	if whatIf[0].TeamName != "St Ann's A" || whatIf[0].LeaguePoints != 6 || whatIf[0].Played != 2 {
+		t.Errorf("what-if leader = %+v; want St Ann's on 6 points from 2", whatIf[0])
+	}
+	for _, p := range projectStandings(whatIf, fixtures, strengths, 100, rand.New(rand.NewSource(1))) {
+		if p.TeamName == "St Ann's A" && p.Chances[0].Percent != 100 {
+			t.Errorf("St Ann's title chance with every result set = %.1f%%; want 100%%", p.Chances[0].Percent)
+		}
+	}
+	if table[0].Played != 1 || len(table[0].Form) != 1 {
+		t.Errorf("what-ifs changed the real table: %+v", table[0])
+	}
+
+	// The page takes what-ifs from the query string
+	h.templateDir = filepath.Join(filepath.Dir(findMigrationsPath(t)), "templates")
+	rec := httptest.NewRecorder()
+	h.HandleProjections(rec, httptest.NewRequest("GET", "/standings/projections?division=1&f2=8", nil))
+	body := rec.Body.String()
+	for _, want := range []string{`data-testid="projection-grid"`, `data-testid="whatif-table"`, `<option value="8" selected>8&ndash;0</option>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("projections page is missing %s", want)
+		}
+	}
+
+	// Page views reuse the cached projection until a result is entered
+	cachedKey := func() string {
+		t.Helper()
+		table, _, err := h.calculateDivisionStandings(1, 1, 1, 0)
+		if err != nil {
+			t.Fatalf("standings: %v", err)
+		}
+		fixtures, err := h.remainingFixtures(1, 1)
+		if err != nil {
+			t.Fatalf("remaining fixtures: %v", err)
+		}
+		for i := range fixtures {
+			fixtures[i].WhatIf = -1
+		}
+		return projectionKey(table, fixtures)
+	}
+	mustBeCached := func(key string) {
+		t.Helper()
+		h.HandleProjections(httptest.NewRecorder(), httptest.NewRequest("GET", "/standings/projections?division=1", nil))
+		h.projections.get(1, key, func() []TeamProjection {
+			t.Errorf("projection for %q was not cached", key)
+			return nil
+		})
+	}
+	before := cachedKey()
+	mustBeCached(before)
+	exec(`UPDATE fixtures SET status = 'Completed' WHERE id = 2`)
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (2, 2, 'Mens', 'Finished', 6, 2, '')`)
+	after := cachedKey()
+	if after == before {
+		t.Fatalf("entering a result left the projection key unchanged")
+	}
+	mustBeCached(after)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jim-dot-tennis/internal/auth"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/ratelimit"
+)
+
+// AvailabilityActionResult describes what a push action button recorded
+type AvailabilityActionResult struct {
+	Player  *models.Player
+	Fixture *models.Fixture
+	Status  models.AvailabilityStatus
+}
+
+// ApplyAvailabilityAction verifies a signed availability action link and
+// records the answer it carries for that exact fixture. The link is the only
+// credential: it names the player, fixture and status and cannot be altered.
+// A link minted for a player link that has since been rotated or revoked is
+// refused as expired.
+func (s *Service) ApplyAvailabilityAction(ctx context.Context, signedToken string) (*AvailabilityActionResult, error) {
+	claims, err := s.linkSigner.Verify(signedToken, auth.LinkPurposeAvailabilityAction)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtureID, err := strconv.ParseUint(claims.Resource, 10, 32)
+	if err != nil {
+		return nil, auth.ErrLinkInvalid
+	}
+	status := models.AvailabilityStatus(claims.Action)
+	switch status {
+	case models.Available, models.IfNeeded, models.Unavailable:
+	default:
+		return nil, auth.ErrLinkInvalid
+	}
+
+	current := s.playerFantasyToken(ctx, claims.Subject)
+	if current == "" || auth.TokenFingerprint(current) != claims.Token {
+		return nil, auth.ErrLinkExpired
+	}
+
+	player, err := s.playerRepository.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	fixture, err := s.fixtureRepository.FindByID(ctx, uint(fixtureID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.withSelectionAlerts(ctx, player.ID, func() error {
+		return s.availabilityRepository.UpsertPlayerFixtureAvailability(ctx, player.ID, fixture.ID, status, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AvailabilityActionResult{Player: player, Fixture: fixture, Status: status}
+	s.confirmAvailabilityAction(ctx, result)
+	return result, nil
+}
+
+// confirmAvailabilityAction pushes a short confirmation back to the player so
+// they know the button press landed
+func (s *Service) confirmAvailabilityAction(ctx context.Context, result *AvailabilityActionResult) {
+	if s.pushService == nil {
+		return
+	}
+	token := s.playerFantasyToken(ctx, result.Player.ID)
+	if token == "" {
+		return
+	}
+
+	match := result.Fixture.ScheduledDate.Format("Monday 2 January")
+	if home, err := s.teamRepository.FindByID(ctx, result.Fixture.HomeTeamID); err == nil {
+		if away, err := s.teamRepository.FindByID(ctx, result.Fixture.AwayTeamID); err == nil {
+			match = fmt.Sprintf("%s vs %s on %s", home.Name, away.Name, match)
+		}
+	}
+
+	payload := map[string]interface{}{
+		"title": "Availability updated",
+		"body":  fmt.Sprintf("You're marked %s for %s.", availabilityLabel(result.Status), match),
+		"data": map[string]string{
+			"type": "availability-confirmation",
+			"url":  s.linkSigner.NotificationLink(result.Player.ID, token, fmt.Sprintf("/fixture/%d", result.Fixture.ID)),
+		},
+	}
+	if _, err := s.pushService.SendToPlayer(token, payload); err != nil {
+		log.Printf("Failed to send availability confirmation to player %s: %v", result.Player.ID, err)
+	}
+}
+
+// HandlePushAvailabilityAction handles POST /push-action/availability/{signedToken},
+// called by the service worker when a player taps an action button on an
+// availability reminder
+func (h *AvailabilityHandler) HandlePushAvailabilityAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	signedToken := strings.TrimPrefix(r.URL.Path, "/push-action/availability/")
+	result, err := h.service.ApplyAvailabilityAction(r.Context(), signedToken)
+	switch {
+	case errors.Is(err, auth.ErrLinkExpired):
+		ratelimit.ReportFailure(r.Context())
+		http.Error(w, "This link has expired", http.StatusGone)
+		return
+	case errors.Is(err, auth.ErrLinkInvalid):
+		ratelimit.ReportFailure(r.Context())
+		http.Error(w, "Invalid link", http.StatusForbidden)
+		return
+	case err != nil:
+		logAndError(w, "Failed to record availability", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":       "success",
+		"fixtureId":    result.Fixture.ID,
+		"availability": result.Status,
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// selectionState is a player's resolved availability for one fixture they
+// have already been selected for
+type selectionState struct {
+	fixture   *models.Fixture
+	selection models.FixturePlayer
+	status    models.AvailabilityStatus
+}
+
+// withSelectionAlerts runs an availability write and, if it changes the
+// player's effective availability for any upcoming fixture they're already
+// selected for, alerts that team's captain and the fixture's day captain.
+// Alerting is best-effort: a failure there never fails the write itself.
+func (s *Service) withSelectionAlerts(ctx context.Context, playerID string, write func() error) error {
+	before := s.snapshotSelections(ctx, playerID)
+	if err := write(); err != nil {
+		return err
+	}
+	if len(before) == 0 {
+		return nil
+	}
+
+	after := s.snapshotSelections(ctx, playerID)
+	for fixtureID, prev := range before {
+		next, ok := after[fixtureID]
+		if !ok || next.status == prev.status {
+			continue
+		}
+		s.raiseSelectionAlert(ctx, prev, next.status)
+	}
+	return nil
+}
+
+// snapshotSelections resolves the player's availability for every upcoming
+// fixture they're in fixture_players for, keyed by fixture ID
+func (s *Service) snapshotSelections(ctx context.Context, playerID string) map[uint]selectionState {
+	today := time.Now().Truncate(24 * time.Hour)
+	selections, err := s.fixtureRepository.FindUpcomingSelectionsByPlayer(ctx, playerID, today)
+	if err != nil {
+		log.Printf("Failed to load selections for player %s: %v", playerID, err)
+		return nil
+	}
+
+	snapshot := make(map[uint]selectionState, len(selections))
+	for _, sel := range selections {
+		fixture, err := s.fixtureRepository.FindByID(ctx, sel.FixtureID)
+		if err != nil {
+			continue
+		}
+		snapshot[sel.FixtureID] = selectionState{
+			fixture:   fixture,
+			selection: sel,
+			status:    s.resolveFixtureAvailability(ctx, playerID, fixture),
+		}
+	}
+	return snapshot
+}
+
+// resolveFixtureAvailability applies the same precedence the captain's
+// planning views use: fixture-specific, then date exception, then the
+// general day-of-week preference
+func (s *Service) resolveFixtureAvailability(ctx context.Context, playerID string, fixture *models.Fixture) models.AvailabilityStatus {
+	resolved, err := s.availabilityRepository.ResolveFixtureAvailability(ctx, []string{playerID}, fixture.ID, fixture.SeasonID, fixture.ScheduledDate)
+	if err != nil {
+		log.Printf("Failed to resolve availability of player %s for fixture %d: %v", playerID, fixture.ID, err)
+		return models.Unknown
+	}
+	if availability, ok := resolved[playerID]; ok {
+		return availability.Status
+	}
+	return models.Unknown
+}
+
+// raiseSelectionAlert stores the alert for the team-selection screen and
+// pushes it to the team captain and day captain
+func (s *Service) raiseSelectionAlert(ctx context.Context, prev selectionState, newStatus models.AvailabilityStatus) {
+	fixture := prev.fixture
+	teamID := fixture.AwayTeamID
+	if prev.selection.IsHome {
+		teamID = fixture.HomeTeamID
+	}
+	if prev.selection.ManagingTeamID != nil {
+		teamID = *prev.selection.ManagingTeamID
+	}
+
+	alert := &models.SelectionAlert{
+		FixtureID:      fixture.ID,
+		PlayerID:       prev.selection.PlayerID,
+		TeamID:         &teamID,
+		PreviousStatus: prev.status,
+		NewStatus:      newStatus,
+	}
+	if err := s.selectionAlertRepository.Create(ctx, alert); err != nil {
+		log.Printf("Failed to record selection alert for player %s fixture %d: %v", alert.PlayerID, fixture.ID, err)
+		return
+	}
+
+	if s.pushService == nil {
+		return
+	}
+
+	player, err := s.playerRepository.FindByID(ctx, alert.PlayerID)
+	if err != nil {
+		return
+	}
+
+	recipients := map[string]bool{}
+	if captain, err := s.teamRepository.FindTeamCaptain(ctx, teamID, fixture.SeasonID); err == nil && captain.IsActive {
+		recipients[captain.PlayerID] = true
+	}
+	if fixture.DayCaptainID != nil {
+		recipients[*fixture.DayCaptainID] = true
+	}
+	// No point telling a captain about their own change.
+	delete(recipients, alert.PlayerID)
+
+	body := fmt.Sprintf("%s %s is now %s for %s", player.FirstName, player.LastName,
+		availabilityLabel(newStatus), fixture.ScheduledDate.Format("Monday 2 January"))
+	for captainID := range recipients {
+		token := s.playerFantasyToken(ctx, captainID)
+		if token == "" {
+			continue
+		}
+		payload := map[string]interface{}{
+			"title": "Selected player availability changed",
+			"body":  body,
+			"data": map[string]string{
+				"type": "selection-alert",
+				"url":  s.linkSigner.NotificationLink(captainID, token, fmt.Sprintf("/fixture/%d", fixture.ID)),
+			},
+		}
+		if _, err := s.pushService.SendToPlayer(token, payload); err != nil {
+			log.Printf("Failed to send selection alert to captain %s: %v", captainID, err)
+		}
+	}
+}
+
+// availabilityLabel renders a status for use mid-sentence in a notification
+func availabilityLabel(status models.AvailabilityStatus) string {
+	switch status {
+	case models.Available:
+		return "available"
+	case models.Unavailable:
+		return "unavailable"
+	case models.IfNeeded:
+		return "available if needed"
+	default:
+		return "unsure"
+	}
+}
+
+// playerFantasyToken returns the availability-page token for a player, or
+// "" if they don't have one yet
+func (s *Service) playerFantasyToken(ctx context.Context, playerID string) string {
+	player, err := s.playerRepository.FindByID(ctx, playerID)
+	if err != nil || player.FantasyMatchID == nil {
+		return ""
+	}
+	match, err := s.fantasyRepository.FindByID(ctx, *player.FantasyMatchID)
+	if err != nil || match == nil {
+		return ""
+	}
+	return match.AuthToken
+}
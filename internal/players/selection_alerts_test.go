@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// findMigrationsPath walks up from the test's working directory to locate the
+// repo-root migrations directory.
+func findMigrationsPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		candidate := filepath.Join(dir, "migrations")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		dir = filepath.Dir(dir)
+	}
+	t.Fatal("could not locate migrations directory")
+	return ""
+}
+
+// A selected player marking themselves Unavailable for the fixture date must
+// raise exactly one alert for that fixture; re-saving the same status must not
+// raise another, and a player who isn't selected never raises one.
+func TestAvailabilityChangeRaisesSelectionAlert(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "selection_alert_test.db")
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	fixtureDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 7)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, 'Season', 2026, ?, ?, 1)`, fixtureDate.AddDate(0, -1, 0), fixtureDate.AddDate(0, 3, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`, fixtureDate, fixtureDate.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Test League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Tuesday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'Home'), (2, 'Rivals') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'Home A', 1, 1, 1), (2, 'Rivals', 2, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('picked', 'Picked', 'Player', 1), ('bench', 'Bench', 'Player', 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (10, 1, 2, 1, 1, 1, ?, '', 'Scheduled', '')`, fixtureDate)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id) VALUES (10, 'picked', 1, 1, 1)`)
+
+	svc := NewService(db, 1)
+	date := fixtureDate.Format("2006-01-02")
+
+	if err := svc.UpdatePlayerAvailability("picked", date, "unavailable"); err != nil {
+		t.Fatalf("update picked: %v", err)
+	}
+	if err := svc.UpdatePlayerAvailability("picked", date, "unavailable"); err != nil {
+		t.Fatalf("re-save picked: %v", err)
+	}
+	if err := svc.UpdatePlayerAvailability("bench", date, "unavailable"); err != nil {
+		t.Fatalf("update bench: %v", err)
+	}
+
+	alerts, err := svc.selectionAlertRepository.FindOpenByFixture(ctx, 10)
+	if err != nil {
+		t.Fatalf("load alerts: %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1: %+v", len(alerts), alerts)
+	}
+	got := alerts[0]
+	if got.PlayerID != "picked" || got.PreviousStatus != models.Unknown || got.NewStatus != models.Unavailable {
+		t.Fatalf("alert = %+v, want picked Unknown -> Unavailable", got)
+	}
+	if got.TeamID == nil || *got.TeamID != 1 {
+		t.Fatalf("alert team = %v, want 1", got.TeamID)
+	}
+}
@@ -5,12 +5,18 @@ package players
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
+	"jim-dot-tennis/internal/auth"
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 	"jim-dot-tennis/internal/repository"
 	"jim-dot-tennis/internal/services"
+	"jim-dot-tennis/internal/webpush"
 )
 
 // Service provides business logic for player operations
@@ -30,11 +36,20 @@ type Service struct {
 	matchupRepository          repository.MatchupRepository
 	venueOverrideRepository    repository.VenueOverrideRepository
 	tennisPreferenceRepository repository.PlayerTennisPreferenceRepository
+	subOfferRepository         repository.SubOfferRepository
+	selectionAlertRepository   repository.SelectionAlertRepository
+	fixtureChangeRepository    repository.FixtureChangeRepository
+	playerTokenRepository      repository.PlayerTokenRepository
+	tournamentEntryRepository  repository.TournamentEntryRepository
+	tournamentRepository       repository.TournamentRepository
+	tournamentDrawRepository   repository.TournamentDrawRepository
+	pushService                *webpush.Service
+	linkSigner                 *auth.LinkSigner
 }
 
 // NewService creates a new players service
-func NewService(db *database.DB, homeClubID uint) *Service {
-	return &Service{
+func NewService(db *database.DB, homeClubID uint, pushService ...*webpush.Service) *Service {
+	service := &Service{
 		db:                         db,
 		homeClubID:                 homeClubID,
 		playerRepository:           repository.NewPlayerRepository(db),
@@ -50,7 +65,21 @@ func NewService(db *database.DB, homeClubID uint) *Service {
 		matchupRepository:          repository.NewMatchupRepository(db),
 		venueOverrideRepository:    repository.NewVenueOverrideRepository(db),
 		tennisPreferenceRepository: repository.NewPlayerTennisPreferenceRepository(db),
+		subOfferRepository:         repository.NewSubOfferRepository(db),
+		selectionAlertRepository:   repository.NewSelectionAlertRepository(db),
+		fixtureChangeRepository:    repository.NewFixtureChangeRepository(db),
+		playerTokenRepository:      repository.NewPlayerTokenRepository(db),
+		tournamentEntryRepository:  repository.NewTournamentEntryRepository(db),
+		tournamentRepository:       repository.NewTournamentRepository(db),
+		tournamentDrawRepository:   repository.NewTournamentDrawRepository(db),
+		linkSigner:                 auth.NewLinkSigner(db),
+	}
+
+	if len(pushService) > 0 {
+		service.pushService = pushService[0]
 	}
+
+	return service
 }
 
 // GetFantasyMatchByToken retrieves a fantasy mixed doubles match by its auth token
@@ -219,7 +248,9 @@ func (s *Service) UpdatePlayerAvailability(playerID string, dateStr string, stat
 	availStatus := s.convertFrontendStatus(status)
 
 	// Update availability
-	return s.availabilityRepository.UpsertPlayerAvailability(ctx, playerID, date, availStatus, "")
+	return s.withSelectionAlerts(ctx, playerID, func() error {
+		return s.availabilityRepository.UpsertPlayerAvailability(ctx, playerID, date, availStatus, "")
+	})
 }
 
 // BatchUpdatePlayerAvailability updates multiple availability records
@@ -240,7 +271,9 @@ func (s *Service) BatchUpdatePlayerAvailability(playerID string, updates []Avail
 		})
 	}
 
-	return s.availabilityRepository.BatchUpsertPlayerAvailability(ctx, playerID, availabilityUpdates)
+	return s.withSelectionAlerts(ctx, playerID, func() error {
+		return s.availabilityRepository.BatchUpsertPlayerAvailability(ctx, playerID, availabilityUpdates)
+	})
 }
 
 // convertFrontendStatus converts frontend status strings to backend AvailabilityStatus
@@ -525,25 +558,27 @@ func (s *Service) UpdatePlayerGeneralAvailability(playerID string, dayOfWeek str
 		return fmt.Errorf("failed to find active season: %w", err)
 	}
 
-	// If status is "clear", delete the preference instead of upserting
-	if status == "clear" {
-		// Delete by setting status to Unknown (which effectively clears it)
-		// We use Unknown as the default "not set" status
-		if err := s.availabilityRepository.UpsertPlayerGeneralAvailability(ctx, playerID, activeSeason.ID, dayOfWeek, models.Unknown, notes); err != nil {
-			return fmt.Errorf("failed to clear general availability: %w", err)
+	return s.withSelectionAlerts(ctx, playerID, func() error {
+		// If status is "clear", delete the preference instead of upserting
+		if status == "clear" {
+			// Delete by setting status to Unknown (which effectively clears it)
+			// We use Unknown as the default "not set" status
+			if err := s.availabilityRepository.UpsertPlayerGeneralAvailability(ctx, playerID, activeSeason.ID, dayOfWeek, models.Unknown, notes); err != nil {
+				return fmt.Errorf("failed to clear general availability: %w", err)
+			}
+			return nil
 		}
-		return nil
-	}
 
-	// Convert status string to AvailabilityStatus
-	availStatus := s.convertFrontendStatus(status)
+		// Convert status string to AvailabilityStatus
+		availStatus := s.convertFrontendStatus(status)
 
-	// Update via repository
-	if err := s.availabilityRepository.UpsertPlayerGeneralAvailability(ctx, playerID, activeSeason.ID, dayOfWeek, availStatus, notes); err != nil {
-		return fmt.Errorf("failed to update general availability: %w", err)
-	}
+		// Update via repository
+		if err := s.availabilityRepository.UpsertPlayerGeneralAvailability(ctx, playerID, activeSeason.ID, dayOfWeek, availStatus, notes); err != nil {
+			return fmt.Errorf("failed to update general availability: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // AvailabilityException represents a date range exception
@@ -608,15 +643,17 @@ func (s *Service) CreateAvailabilityException(playerID string, startDateStr, end
 
 	// Create exception for each day in the range
 	// This approach allows for easy deletion of individual days if needed
-	currentDate := startDate
-	for !currentDate.After(endDate) {
-		if err := s.availabilityRepository.UpsertPlayerAvailability(ctx, playerID, currentDate, availStatus, reason); err != nil {
-			return fmt.Errorf("failed to create availability exception: %w", err)
+	return s.withSelectionAlerts(ctx, playerID, func() error {
+		currentDate := startDate
+		for !currentDate.After(endDate) {
+			if err := s.availabilityRepository.UpsertPlayerAvailability(ctx, playerID, currentDate, availStatus, reason); err != nil {
+				return fmt.Errorf("failed to create availability exception: %w", err)
+			}
+			currentDate = currentDate.AddDate(0, 0, 1)
 		}
-		currentDate = currentDate.AddDate(0, 0, 1)
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // DeleteAvailabilityException deletes an availability exception by removing all days in the date range
@@ -637,24 +674,26 @@ func (s *Service) DeleteAvailabilityException(playerID string, startDateStr, end
 	// Delete exception for each day in the range
 	// Note: The repository stores exceptions with end_date = start_date + 24 hours,
 	// so we need to delete using the stored format
-	currentDate := startDate
-	for !currentDate.After(endDate) {
-		// Delete using SQL directly to match the actual storage format
-		_, err := s.db.ExecContext(ctx, `
-			DELETE FROM player_availability_exceptions
-			WHERE player_id = ?
-			AND start_date >= ?
-			AND start_date < ?
-		`, playerID, currentDate, currentDate.AddDate(0, 0, 1))
+	return s.withSelectionAlerts(ctx, playerID, func() error {
+		currentDate := startDate
+		for !currentDate.After(endDate) {
+			// Delete using SQL directly to match the actual storage format
+			_, err := s.db.ExecContext(ctx, `
+				DELETE FROM player_availability_exceptions
+				WHERE player_id = ?
+				AND start_date >= ?
+				AND start_date < ?
+			`, playerID, currentDate, currentDate.AddDate(0, 0, 1))
 
-		if err != nil {
-			// Log but continue - some days might not have exceptions
-			fmt.Printf("Warning: failed to delete exception for %s: %v\n", currentDate.Format("2006-01-02"), err)
+			if err != nil {
+				// Log but continue - some days might not have exceptions
+				fmt.Printf("Warning: failed to delete exception for %s: %v\n", currentDate.Format("2006-01-02"), err)
+			}
+			currentDate = currentDate.AddDate(0, 0, 1)
 		}
-		currentDate = currentDate.AddDate(0, 0, 1)
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // PlayerMatchRecord represents a single match record for a player
@@ -670,6 +709,8 @@ type PlayerMatchRecord struct {
 	DrawnMatch    bool      `json:"drawn_match"`
 	HomeTeamName  string    `json:"home_team_name"`
 	AwayTeamName  string    `json:"away_team_name"`
+	IsCup         bool      `json:"is_cup"`
+	RoundName     string    `json:"round_name"`
 }
 
 // PlayerMatchStats aggregates match statistics
@@ -822,6 +863,25 @@ func (s *Service) GetPlayerMatchHistory(playerID string, seasonID *uint) ([]Play
 		}
 	}
 
+	cupRecords, err := s.getCupMatchRecords(ctx, playerID, seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, record := range cupRecords {
+		stats.TotalMatches++
+		if record.WonMatch {
+			stats.Wins++
+		} else {
+			stats.Losses++
+		}
+	}
+	if len(cupRecords) > 0 {
+		records = append(records, cupRecords...)
+		sort.SliceStable(records, func(i, j int) bool {
+			return records[i].FixtureDate.After(records[j].FixtureDate)
+		})
+	}
+
 	if stats.TotalMatches > 0 {
 		stats.WinRate = float64(stats.Wins) / float64(stats.TotalMatches) * 100
 	}
@@ -829,6 +889,117 @@ func (s *Service) GetPlayerMatchHistory(playerID string, seasonID *uint) ([]Play
 	return records, stats, nil
 }
 
+// getCupMatchRecords returns the decided cup matches synced from CourtHive
+// that the player played in. Byes and walkovers are left out as no tennis
+// was played. With a season, only matches played between its start and end
+// dates are included. Side 1 is treated as home, and the score is shown
+// from the player's side.
+func (s *Service) getCupMatchRecords(ctx context.Context, playerID string, seasonID *uint) ([]PlayerMatchRecord, error) {
+	query := `
+		SELECT
+			m.id AS match_id,
+			m.played_date,
+			m.round_name,
+			m.side1_name,
+			m.side2_name,
+			m.winning_side,
+			CASE WHEN mp.side = 2 AND m.score_side2 != '' THEN m.score_side2 ELSE m.score END AS score,
+			mp.side,
+			t.name AS tournament_name,
+			COALESCE(NULLIF(d.event_name, ''), d.name) AS draw_name
+		FROM tournament_match_players mp
+		JOIN tournament_matches m ON m.id = mp.match_id
+		JOIN tournament_draws d ON d.id = m.draw_id
+		JOIN tournaments t ON t.id = d.tournament_id
+		WHERE mp.player_id = ?
+		AND m.winning_side IS NOT NULL
+		AND m.played_date IS NOT NULL
+		AND m.status NOT IN ('BYE', 'WALKOVER', 'DOUBLE_WALKOVER')
+	`
+	args := []interface{}{playerID}
+	if seasonID != nil {
+		query += ` AND EXISTS (
+			SELECT 1 FROM seasons se
+			WHERE se.id = ? AND date(m.played_date) BETWEEN date(se.start_date) AND date(se.end_date)
+		)`
+		args = append(args, *seasonID)
+	}
+
+	type cupRow struct {
+		MatchID        uint      `db:"match_id"`
+		PlayedDate     time.Time `db:"played_date"`
+		RoundName      string    `db:"round_name"`
+		Side1Name      string    `db:"side1_name"`
+		Side2Name      string    `db:"side2_name"`
+		WinningSide    int       `db:"winning_side"`
+		Score          string    `db:"score"`
+		Side           int       `db:"side"`
+		TournamentName string    `db:"tournament_name"`
+		DrawName       string    `db:"draw_name"`
+	}
+	var rows []cupRow
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to query cup match history: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	matchIDs := make([]uint, len(rows))
+	for i, row := range rows {
+		matchIDs[i] = row.MatchID
+	}
+	inQuery, inArgs, err := sqlx.In(`
+		SELECT match_id, side, participant_name, player_id
+		FROM tournament_match_players
+		WHERE match_id IN (?)
+		ORDER BY match_id, side, id
+	`, matchIDs)
+	if err != nil {
+		return nil, err
+	}
+	var players []models.TournamentMatchPlayer
+	if err := s.db.SelectContext(ctx, &players, s.db.Rebind(inQuery), inArgs...); err != nil {
+		return nil, fmt.Errorf("failed to query cup match players: %w", err)
+	}
+	playersByMatch := make(map[uint][]models.TournamentMatchPlayer)
+	for _, p := range players {
+		playersByMatch[p.MatchID] = append(playersByMatch[p.MatchID], p)
+	}
+
+	records := make([]PlayerMatchRecord, 0, len(rows))
+	for _, row := range rows {
+		// Same rule as league matches: shareable URL, so initials only
+		partnerName := ""
+		var opponentInitials []string
+		for _, p := range playersByMatch[row.MatchID] {
+			if p.PlayerID != nil && *p.PlayerID == playerID {
+				continue
+			}
+			name := initialsForName(p.ParticipantName)
+			if p.Side == row.Side {
+				partnerName = name
+			} else {
+				opponentInitials = append(opponentInitials, name)
+			}
+		}
+
+		records = append(records, PlayerMatchRecord{
+			FixtureDate:   row.PlayedDate,
+			DivisionName:  row.TournamentName,
+			MatchupType:   row.DrawName,
+			PartnerName:   partnerName,
+			OpponentNames: strings.Join(opponentInitials, " & "),
+			SetScores:     row.Score,
+			IsHome:        row.Side == 1,
+			WonMatch:      row.WinningSide == row.Side,
+			IsCup:         true,
+			RoundName:     row.RoundName,
+		})
+	}
+	return records, nil
+}
+
 // PartnerOption is a single row in the partner picker.
 // Full name is included only for the in-form picker UX; the picker is
 // rendered on a token URL but shows roster-mates at the same club — who the
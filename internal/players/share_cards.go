@@ -0,0 +1,479 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/sharecard"
+)
+
+// Share cards are public images for social media posts and link previews:
+//
+//	/standings/card/fixture/{fixtureID}.png      a completed fixture's result
+//	/standings/card/roundup/{seasonID}/{week}.png our results in one week
+//	/standings/card/team-sheet/{fixtureID}.png    our pairings for a fixture
+//
+// Each also comes as .svg. Like every shareable URL, they show players by
+// initials only.
+
+// HandleShareCard handles GET /standings/card/...
+func (h *StandingsHandler) HandleShareCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/standings/card/")
+	format, ok := sharecard.ParseFormat(path.Ext(rest))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	parts := strings.Split(strings.TrimSuffix(rest, path.Ext(rest)), "/")
+
+	var ids []uint
+	for _, p := range parts[1:] {
+		id, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+
+	var card *sharecard.Card
+	var err error
+	switch {
+	case parts[0] == "fixture" && len(ids) == 1:
+		card, err = h.fixtureResultCard(ids[0])
+	case parts[0] == "roundup" && len(ids) == 2:
+		card, err = h.weeklyRoundupCard(ids[0], int(ids[1]))
+	case parts[0] == "team-sheet" && len(ids) == 1:
+		card, err = h.teamSheetCard(ids[0])
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to build share card %s: %v", r.URL.Path, err)
+		http.Error(w, "Failed to build image", http.StatusInternalServerError)
+		return
+	}
+	if card == nil {
+		http.NotFound(w, r)
+		return
+	}
+	sharecard.Serve(w, *card, format)
+}
+
+// RoundupCardPath is the image path for our results in a season's week
+func RoundupCardPath(seasonID uint, week int) string {
+	return fmt.Sprintf("/standings/card/roundup/%d/%d.png", seasonID, week)
+}
+
+// cardFixture is the header of a fixture for its cards
+type cardFixture struct {
+	ID            uint      `db:"id"`
+	Status        string    `db:"status"`
+	ScheduledDate time.Time `db:"scheduled_date"`
+	VenueLocation string    `db:"venue_location"`
+	HomeTeamID    uint      `db:"home_team_id"`
+	HomeTeamName  string    `db:"home_team_name"`
+	AwayTeamName  string    `db:"away_team_name"`
+	HomeClubID    uint      `db:"home_club_id"`
+	AwayClubID    uint      `db:"away_club_id"`
+	DivisionName  string    `db:"division_name"`
+	WeekNumber    int       `db:"week_number"`
+	SeasonName    string    `db:"season_name"`
+}
+
+// slate is the managing team whose rubbers and selection a card shows. In a
+// derby each team keeps its own slate of the same rubbers, so the cards use
+// the home team's, as the admin derby views do; otherwise there's only one
+// slate and nothing is filtered.
+func (f *cardFixture) slate() uint {
+	if f.HomeClubID == f.AwayClubID {
+		return f.HomeTeamID
+	}
+	return 0
+}
+
+// cardRubber is a matchup with its players' names
+type cardRubber struct {
+	ID        uint               `db:"id"`
+	Type      models.MatchupType `db:"type"`
+	Status    string             `db:"status"`
+	HomeScore int                `db:"home_score"`
+	AwayScore int                `db:"away_score"`
+	HomeSet1  *int               `db:"home_set1"`
+	AwaySet1  *int               `db:"away_set1"`
+	HomeSet2  *int               `db:"home_set2"`
+	AwaySet2  *int               `db:"away_set2"`
+	HomeSet3  *int               `db:"home_set3"`
+	AwaySet3  *int               `db:"away_set3"`
+	Home      []string
+	Away      []string
+}
+
+// sets gives the set scores from the home side, e.g. "6-4 3-6 10-8"
+func (r cardRubber) sets() string {
+	var out []string
+	for _, s := range [][2]*int{{r.HomeSet1, r.AwaySet1}, {r.HomeSet2, r.AwaySet2}, {r.HomeSet3, r.AwaySet3}} {
+		if s[0] != nil && s[1] != nil {
+			out = append(out, fmt.Sprintf("%d-%d", *s[0], *s[1]))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// rubberOrder lists matchups the way a match card does
+var rubberOrder = map[models.MatchupType]int{
+	models.Mens:        1,
+	models.Womens:      2,
+	models.FirstMixed:  3,
+	models.SecondMixed: 4,
+}
+
+// loadCardFixture reads a fixture's header and its rubbers, or nil if there
+// is no such fixture
+func (h *StandingsHandler) loadCardFixture(fixtureID uint) (*cardFixture, []cardRubber, error) {
+	var f cardFixture
+	err := h.service.db.Get(&f, `
+		SELECT f.id, f.status, f.scheduled_date, f.venue_location,
+			f.home_team_id, ht.name AS home_team_name, at2.name AS away_team_name,
+			ht.club_id AS home_club_id, at2.club_id AS away_club_id,
+			d.name AS division_name, w.week_number, s.name AS season_name
+		FROM fixtures f
+		JOIN teams ht ON f.home_team_id = ht.id
+		JOIN teams at2 ON f.away_team_id = at2.id
+		JOIN divisions d ON f.division_id = d.id
+		JOIN weeks w ON f.week_id = w.id
+		JOIN seasons s ON f.season_id = s.id
+		WHERE f.id = ?
+	`, fixtureID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load fixture: %w", err)
+	}
+
+	var rubbers []cardRubber
+	if err := h.service.db.Select(&rubbers, `
+		SELECT id, type, status, home_score, away_score,
+			home_set1, away_set1, home_set2, away_set2, home_set3, away_set3
+		FROM matchups
+		WHERE fixture_id = ? AND (? = 0 OR managing_team_id IS NULL OR managing_team_id = ?)
+		ORDER BY id
+	`, fixtureID, f.slate(), f.slate()); err != nil {
+		return nil, nil, fmt.Errorf("failed to load matchups: %w", err)
+	}
+
+	// A derby's other slate holds the opposing pairs, so its players join the
+	// rubber of the same type
+	var players []struct {
+		MatchupID uint               `db:"matchup_id"`
+		Type      models.MatchupType `db:"type"`
+		IsHome    bool               `db:"is_home"`
+		Name      string             `db:"name"`
+	}
+	if err := h.service.db.Select(&players, `
+		SELECT mp.matchup_id, m.type, mp.is_home, p.first_name || ' ' || p.last_name AS name
+		FROM matchup_players mp
+		JOIN matchups m ON mp.matchup_id = m.id
+		JOIN players p ON mp.player_id = p.id
+		WHERE m.fixture_id = ?
+		ORDER BY mp.id
+	`, fixtureID); err != nil {
+		return nil, nil, fmt.Errorf("failed to load matchup players: %w", err)
+	}
+	for _, p := range players {
+		for i := range rubbers {
+			if rubbers[i].ID != p.MatchupID && (f.slate() == 0 || rubbers[i].Type != p.Type) {
+				continue
+			}
+			if p.IsHome {
+				rubbers[i].Home = append(rubbers[i].Home, p.Name)
+			} else {
+				rubbers[i].Away = append(rubbers[i].Away, p.Name)
+			}
+		}
+	}
+
+	// Match card order, with any extra rubbers after
+	for i := 1; i < len(rubbers); i++ {
+		for j := i; j > 0 && rubberRank(rubbers[j]) < rubberRank(rubbers[j-1]); j-- {
+			rubbers[j], rubbers[j-1] = rubbers[j-1], rubbers[j]
+		}
+	}
+	return &f, rubbers, nil
+}
+
+func rubberRank(r cardRubber) int {
+	if n, ok := rubberOrder[r.Type]; ok {
+		return n
+	}
+	return len(rubberOrder) + 1
+}
+
+// ourSide says whether the home club plays at home in the fixture, and
+// whether it plays in it at all
+func (h *StandingsHandler) ourSide(f *cardFixture) (home, playing bool) {
+	switch h.service.homeClubID {
+	case 0:
+		return false, false
+	case f.HomeClubID:
+		return true, true
+	case f.AwayClubID:
+		return false, true
+	}
+	return false, false
+}
+
+// toneFor colours a result from our side, or neutrally when we didn't play
+func toneFor(ours, theirs int, playing bool) sharecard.Tone {
+	switch {
+	case !playing:
+		return sharecard.ToneNeutral
+	case ours > theirs:
+		return sharecard.ToneWin
+	case ours < theirs:
+		return sharecard.ToneLoss
+	}
+	return sharecard.ToneDraw
+}
+
+// fixtureResultCard shows a completed fixture's score and every rubber
+func (h *StandingsHandler) fixtureResultCard(fixtureID uint) (*sharecard.Card, error) {
+	f, rubbers, err := h.loadCardFixture(fixtureID)
+	if err != nil || f == nil || f.Status != string(models.Completed) {
+		return nil, err
+	}
+
+	score := &sharecard.Score{Home: f.HomeTeamName, Away: f.AwayTeamName}
+	var rows []sharecard.Row
+	atHome, playing := h.ourSide(f)
+	for _, r := range rubbers {
+		if r.Status != string(models.Finished) {
+			continue
+		}
+		score.HomeScore += r.HomeScore
+		score.AwayScore += r.AwayScore
+		ourScore, theirScore := r.AwayScore, r.HomeScore
+		if atHome {
+			ourScore, theirScore = theirScore, ourScore
+		}
+		var pairs string
+		if len(r.Home) > 0 || len(r.Away) > 0 {
+			pairs = sharecard.Pair(r.Home...) + " v " + sharecard.Pair(r.Away...)
+		}
+		rows = append(rows, sharecard.Row{
+			Left:      string(r.Type),
+			Middle:    pairs,
+			Right:     r.sets(),
+			Highlight: playing && ourScore > theirScore,
+		})
+	}
+
+	ours, theirs := score.AwayScore, score.HomeScore
+	if atHome {
+		ours, theirs = theirs, ours
+	}
+	return &sharecard.Card{
+		Eyebrow:  fmt.Sprintf("%s · Week %d", f.DivisionName, f.WeekNumber),
+		Title:    f.HomeTeamName + " v " + f.AwayTeamName,
+		Subtitle: f.ScheduledDate.Format("Monday 2 January"),
+		Score:    score,
+		Rows:     rows,
+		Footer:   "Season " + f.SeasonName,
+		Tone:     toneFor(ours, theirs, playing),
+	}, nil
+}
+
+// weeklyRoundupCard lists the week's completed fixtures: ours when there is
+// a home club, otherwise the whole league's
+func (h *StandingsHandler) weeklyRoundupCard(seasonID uint, week int) (*sharecard.Card, error) {
+	var results []struct {
+		DivisionName     string `db:"division_name"`
+		SeasonName       string `db:"season_name"`
+		HomeTeamName     string `db:"home_team_name"`
+		AwayTeamName     string `db:"away_team_name"`
+		HomeClubID       uint   `db:"home_club_id"`
+		AwayClubID       uint   `db:"away_club_id"`
+		HomeRubberPoints int    `db:"home_rubber_points"`
+		AwayRubberPoints int    `db:"away_rubber_points"`
+	}
+	homeClubID := h.service.homeClubID
+	if err := h.service.db.Select(&results, `
+		SELECT d.name AS division_name, s.name AS season_name,
+			ht.name AS home_team_name, at2.name AS away_team_name,
+			ht.club_id AS home_club_id, at2.club_id AS away_club_id,
+			COALESCE(SUM(m.home_score), 0) AS home_rubber_points,
+			COALESCE(SUM(m.away_score), 0) AS away_rubber_points
+		FROM fixtures f
+		JOIN weeks w ON f.week_id = w.id
+		JOIN divisions d ON f.division_id = d.id
+		JOIN seasons s ON f.season_id = s.id
+		JOIN teams ht ON f.home_team_id = ht.id
+		JOIN teams at2 ON f.away_team_id = at2.id
+		LEFT JOIN matchups m ON m.fixture_id = f.id AND m.status = 'Finished'
+			AND (ht.club_id != at2.club_id OR m.managing_team_id IS NULL OR m.managing_team_id = f.home_team_id)
+		WHERE f.season_id = ? AND w.week_number = ? AND f.status = 'Completed'
+			AND (? = 0 OR ht.club_id = ? OR at2.club_id = ?)
+		GROUP BY f.id, d.level, d.name, s.name, f.scheduled_date, ht.name, at2.name, ht.club_id, at2.club_id
+		ORDER BY d.level, f.scheduled_date, f.id
+	`, seasonID, week, homeClubID, homeClubID, homeClubID); err != nil {
+		return nil, fmt.Errorf("failed to query week results: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	var won, drawn, lost, rubbersFor int
+	var rows []sharecard.Row
+	for _, r := range results {
+		ours, theirs := r.HomeRubberPoints, r.AwayRubberPoints
+		if r.AwayClubID == homeClubID && r.HomeClubID != homeClubID {
+			ours, theirs = theirs, ours
+		}
+		switch {
+		case ours > theirs:
+			won++
+		case ours < theirs:
+			lost++
+		default:
+			drawn++
+		}
+		rubbersFor += ours
+		rows = append(rows, sharecard.Row{
+			Left:      r.DivisionName,
+			Middle:    r.HomeTeamName + " v " + r.AwayTeamName,
+			Right:     fmt.Sprintf("%d–%d", r.HomeRubberPoints, r.AwayRubberPoints),
+			Highlight: homeClubID != 0 && ours > theirs,
+		})
+	}
+
+	card := &sharecard.Card{
+		Eyebrow: fmt.Sprintf("Week %d results", week),
+		Title:   "League results",
+		Rows:    rows,
+		Footer:  "Season " + results[0].SeasonName,
+	}
+	if homeClubID == 0 {
+		return card, nil
+	}
+	if club, err := h.service.clubRepository.FindByID(context.Background(), homeClubID); err == nil && club != nil {
+		card.Title = club.Name
+	}
+	card.Stats = []sharecard.Stat{
+		{Value: strconv.Itoa(won), Label: "won"},
+		{Value: strconv.Itoa(drawn), Label: "drawn"},
+		{Value: strconv.Itoa(lost), Label: "lost"},
+		{Value: strconv.Itoa(rubbersFor), Label: "rubber points"},
+	}
+	card.Tone = toneFor(won, lost, true)
+	return card, nil
+}
+
+// latestRoundupWeek is the last week of the season with a completed
+// fixture to show, or 0 before any results are in
+func (h *StandingsHandler) latestRoundupWeek(seasonID uint) int {
+	homeClubID := h.service.homeClubID
+	var week sql.NullInt64
+	if err := h.service.db.Get(&week, `
+		SELECT MAX(w.week_number)
+		FROM fixtures f
+		JOIN weeks w ON f.week_id = w.id
+		JOIN teams ht ON f.home_team_id = ht.id
+		JOIN teams at2 ON f.away_team_id = at2.id
+		WHERE f.season_id = ? AND f.status = 'Completed'
+			AND (? = 0 OR ht.club_id = ? OR at2.club_id = ?)
+	`, seasonID, homeClubID, homeClubID, homeClubID); err != nil {
+		log.Printf("Failed to find latest results week: %v", err)
+	}
+	return int(week.Int64)
+}
+
+// teamSheetCard shows our pairings for a fixture, with anyone selected but
+// not yet paired listed after
+func (h *StandingsHandler) teamSheetCard(fixtureID uint) (*sharecard.Card, error) {
+	f, rubbers, err := h.loadCardFixture(fixtureID)
+	if err != nil || f == nil {
+		return nil, err
+	}
+	atHome, playing := h.ourSide(f)
+	if !playing {
+		atHome = true
+	}
+
+	var selected []string
+	if err := h.service.db.Select(&selected, `
+		SELECT p.first_name || ' ' || p.last_name
+		FROM fixture_players fp
+		JOIN players p ON fp.player_id = p.id
+		WHERE fp.fixture_id = ? AND fp.is_home = ?
+			AND (? = 0 OR fp.managing_team_id IS NULL OR fp.managing_team_id = ?)
+		ORDER BY fp.position, fp.id
+	`, fixtureID, atHome, f.slate(), f.slate()); err != nil {
+		return nil, fmt.Errorf("failed to load selection: %w", err)
+	}
+
+	paired := make(map[string]bool)
+	var rows []sharecard.Row
+	for _, r := range rubbers {
+		pair := r.Away
+		if atHome {
+			pair = r.Home
+		}
+		if len(pair) == 0 {
+			continue
+		}
+		for _, name := range pair {
+			paired[name] = true
+		}
+		rows = append(rows, sharecard.Row{Left: string(r.Type), Middle: sharecard.Pair(pair...)})
+	}
+	var unpaired []string
+	for _, name := range selected {
+		if !paired[name] {
+			unpaired = append(unpaired, sharecard.Initials(name))
+		}
+	}
+	if len(unpaired) > 0 {
+		label := "Squad"
+		if len(rows) > 0 {
+			label = "Also selected"
+		}
+		rows = append(rows, sharecard.Row{Left: label, Middle: strings.Join(unpaired, ", ")})
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	team := f.AwayTeamName
+	if atHome {
+		team = f.HomeTeamName
+	}
+	subtitle := f.ScheduledDate.Format("Monday 2 January")
+	if f.VenueLocation != "" {
+		subtitle += " · " + f.VenueLocation
+	}
+	return &sharecard.Card{
+		Eyebrow:  fmt.Sprintf("Team sheet · %s · Week %d", f.DivisionName, f.WeekNumber),
+		Title:    f.HomeTeamName + " v " + f.AwayTeamName,
+		Subtitle: subtitle,
+		Rows:     rows,
+		Footer:   team + " · Season " + f.SeasonName,
+	}, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestShareCardsShowResultsAndTeamSheetsByInitials(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "cards.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	start := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026', 2026, ?, ?, 1)`, start, start.AddDate(0, 6, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES
+		(1, 1, 1, ?, ?, ''), (2, 2, 1, ?, ?, '')`, start, start.AddDate(0, 0, 6), start.AddDate(0, 0, 7), start.AddDate(0, 0, 13))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name, address, website, phone_number) VALUES
+		(1, 'St Ann''s', '', '', ''), (2, 'Hove', '', '', ''), (3, 'Preston', '', '', '')`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'Preston A', 3, 1, 1), (4, 'St Ann''s B', 1, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES
+		('ann', 'Ann', 'Able', 1), ('bob', 'Bob', 'Baker', 1), ('cat', 'Cat', 'Cole', 1), ('dan', 'Dan', 'Dean', 1),
+		('h1', 'Hal', 'Hove', 2), ('h2', 'Hetty', 'Hove', 2)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes) VALUES
+		(1, 2, 1, 1, 1, 1, ?, 'Hove Park', 'Completed', ''),
+		(2, 2, 3, 1, 1, 1, ?, '', 'Completed', ''),
+		(3, 1, 3, 1, 1, 2, ?, 'Preston Park', 'Scheduled', ''),
+		(4, 1, 4, 1, 1, 1, ?, '', 'Completed', ''),
+		(5, 1, 4, 1, 1, 2, ?, '', 'Scheduled', '')`,
+		start.AddDate(0, 0, 3), start.AddDate(0, 0, 3), start.AddDate(0, 0, 10), start.AddDate(0, 0, 4), start.AddDate(0, 0, 11))
+
+	// We won away at Hove 5-3 in week 1
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes, home_set1, away_set1, home_set2, away_set2) VALUES
+		(1, 1, '1st Mixed', 'Finished', 1, 1, '', 6, 4, 3, 6),
+		(2, 1, 'Mens', 'Finished', 0, 2, '', 2, 6, 4, 6),
+		(3, 1, 'Womens', 'Finished', 2, 0, '', 6, 1, 6, 2),
+		(4, 1, '2nd Mixed', 'Finished', 0, 2, '', 3, 6, 3, 6),
+		(5, 2, 'Mens', 'Finished', 2, 0, '', 6, 0, 6, 0)`)
+	for _, mp := range []struct {
+		matchup int
+		player  string
+		home    bool
+	}{{2, "ann", false}, {2, "bob", false}, {2, "h1", true}, {2, "h2", true}} {
+		exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (?, ?, ?)`, mp.matchup, mp.player, mp.home)
+	}
+
+	// Next week's team sheet: Ann and Bob paired, Cat selected
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (6, 3, 'Mens', 'Pending', 0, 0, '')`)
+	exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (6, 'bob', 1), (6, 'ann', 1)`)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position) VALUES (3, 'ann', 1, 1), (3, 'bob', 1, 2), (3, 'cat', 1, 3)`)
+
+	// A derby keeps a slate per team: the A team's pair on one, the B
+	// team's on the other, with the score mirrored
+	exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes, managing_team_id) VALUES
+		(7, 4, 'Mens', 'Finished', 2, 0, '', 1), (8, 4, 'Mens', 'Finished', 2, 0, '', 4)`)
+	exec(`INSERT INTO matchup_players (matchup_id, player_id, is_home) VALUES (7, 'ann', 1), (7, 'bob', 1), (8, 'cat', 0), (8, 'dan', 0)`)
+	exec(`INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id) VALUES
+		(5, 'ann', 1, 1, 1), (5, 'cat', 1, 1, 4)`)
+
+	h := NewStandingsHandler(NewService(db, 1), filepath.Join(filepath.Dir(findMigrationsPath(t)), "templates"))
+	card := func(path string) (int, string, string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.HandleShareCard(rec, httptest.NewRequest("GET", path, nil))
+		return rec.Code, rec.Header().Get("Content-Type"), rec.Body.String()
+	}
+	expect := func(name, body string, want ...string) {
+		t.Helper()
+		for _, w := range want {
+			if !strings.Contains(body, w) {
+				t.Errorf("%s card is missing %s", name, w)
+			}
+		}
+	}
+
+	code, contentType, body := card("/standings/card/fixture/1.svg")
+	if code != 200 || contentType != "image/svg+xml" {
+		t.Fatalf("fixture card = %d %s", code, contentType)
+	}
+	expect("result", body, "Hove A v St Ann&#39;s A", "3–5", "DIVISION 1 · WEEK 1",
+		"H.H. &amp; H.H. v A.A. &amp; B.B.", "2-6 4-6", `fill="#27ae60"`)
+	if strings.Contains(body, "Able") {
+		t.Errorf("result card carries a full name")
+	}
+	// Match card order puts the Mens first
+	mens, mixed := strings.Index(body, ">Mens<"), strings.Index(body, ">1st Mixed<")
+	if mixed < 0 || mens > mixed {
+		t.Errorf("result card doesn't list all four rubbers in order")
+	}
+
+	_, _, body = card("/standings/card/roundup/1/1.svg")
+	expect("roundup", body, "WEEK 1 RESULTS", ">St Ann&#39;s<", "Hove A v St Ann&#39;s A", ">won<")
+	if strings.Contains(body, "Preston") {
+		t.Errorf("roundup shows a fixture we didn't play in")
+	}
+
+	_, _, body = card("/standings/card/fixture/4.svg")
+	expect("derby result", body, "St Ann&#39;s A v St Ann&#39;s B", "2–0", "A.A. &amp; B.B. v C.C. &amp; D.D.")
+	if strings.Count(body, ">Mens<") != 1 {
+		t.Errorf("derby result card shows both slates' rubbers")
+	}
+	_, _, body = card("/standings/card/roundup/1/1.svg")
+	if strings.Contains(body, "4–0") {
+		t.Errorf("roundup counts both slates of a derby")
+	}
+	_, _, body = card("/standings/card/team-sheet/5.svg")
+	if !strings.Contains(body, ">A.A.<") || strings.Contains(body, "C.C.") {
+		t.Errorf("derby team sheet mixes both teams' selections")
+	}
+
+	_, _, body = card("/standings/card/team-sheet/3.svg")
+	expect("team sheet", body, "TEAM SHEET", "St Ann&#39;s A v Preston A", "Preston Park", "B.B. &amp; A.A.", "Also selected", ">C.C.<")
+
+	if code, contentType, body = card("/standings/card/fixture/1.png"); code != 200 || contentType != "image/png" || !strings.HasPrefix(body, "\x89PNG") {
+		t.Errorf("png fixture card = %d %s", code, contentType)
+	}
+	for _, path := range []string{"/standings/card/fixture/3.png", "/standings/card/fixture/99.png", "/standings/card/roundup/1/2.png", "/standings/card/fixture/1.gif", "/standings/card/nope/1.png"} {
+		if code, _, _ := card(path); code != 404 {
+			t.Errorf("%s = %d; want 404", path, code)
+		}
+	}
+
+	// The standings page unfurls with the latest week's roundup, linked on
+	// the configured site URL whatever Host the request claims
+	t.Setenv("APP_BASE_URL", "http://tennis.example/")
+	req := httptest.NewRequest("GET", "/standings", nil)
+	req.Host = "attacker.example"
+	rec := httptest.NewRecorder()
+	h.HandleStandings(rec, req)
+	expect("standings page", rec.Body.String(),
+		`<meta property="og:image" content="http://tennis.example/standings/card/roundup/1/1.png">`,
+		`<meta property="og:url" content="http://tennis.example/standings">`,
+		`<meta property="og:title" content="St Ann&#39;s league standings">`)
+
+	// Without a site URL the preview links are relative
+	t.Setenv("APP_BASE_URL", "")
+	rec = httptest.NewRecorder()
+	h.HandleStandings(rec, req)
+	if body := rec.Body.String(); strings.Contains(body, "attacker.example") ||
+		!strings.Contains(body, `<meta property="og:image" content="/standings/card/roundup/1/1.png">`) {
+		t.Errorf("standings page without APP_BASE_URL should link previews relatively")
+	}
+}
@@ -11,11 +11,15 @@ import (
 	"strconv"
 
 	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/services"
+	"jim-dot-tennis/internal/sharecard"
 )
 
 // StandingsHandler handles the public league standings page
 type StandingsHandler struct {
 	service     *Service
+	headToHead  *services.HeadToHeadService
+	projections *projectionCache
 	templateDir string
 }
 
@@ -23,6 +27,8 @@ type StandingsHandler struct {
 func NewStandingsHandler(service *Service, templateDir string) *StandingsHandler {
 	return &StandingsHandler{
 		service:     service,
+		headToHead:  services.NewHeadToHeadService(service.db),
+		projections: newProjectionCache(),
 		templateDir: templateDir,
 	}
 }
@@ -41,6 +47,9 @@ type TeamStanding struct {
 	RubberFor     int
 	RubberAgainst int
 	LeaguePoints  int
+	Form          []string // last five results, oldest first
+	Positions     []int    // league position after each week played
+	Movement      int      // places gained since the previous week
 }
 
 // DivisionStandings represents standings for a single division
@@ -49,16 +58,22 @@ type DivisionStandings struct {
 	DivisionName string
 	Level        int
 	Teams        []TeamStanding
+	HomeTeamID   uint // our first team in the division, for head-to-head links
+	Weeks        []int
+	Movers       []TeamStanding
+	Chart        *PositionChart
 }
 
 // StandingsPageData holds all data for the standings template
 type StandingsPageData struct {
 	Divisions        []DivisionStandings
 	ActiveDivisionID uint
+	Week             int // snapshot week; 0 for the latest table
 	Seasons          []models.Season
 	ActiveSeason     *models.Season
 	HomeClubID       uint
 	HomeClubName     string
+	Share            sharecard.Meta // link preview, with the week's roundup card
 }
 
 // HandleStandings handles GET /standings
@@ -70,27 +85,11 @@ func (h *StandingsHandler) HandleStandings(w http.ResponseWriter, r *http.Reques
 
 	ctx := r.Context()
 
-	// Determine season
-	var season *models.Season
-	seasonIDParam := r.URL.Query().Get("season")
-	if seasonIDParam != "" {
-		sid, err := strconv.ParseUint(seasonIDParam, 10, 32)
-		if err == nil {
-			s, err := h.service.seasonRepository.FindByID(ctx, uint(sid))
-			if err == nil {
-				season = s
-			}
-		}
-	}
-
-	if season == nil {
-		s, err := h.service.seasonRepository.FindActive(ctx)
-		if err != nil {
-			log.Printf("No active season: %v", err)
-			http.Error(w, "No active season found", http.StatusNotFound)
-			return
-		}
-		season = s
+	season, err := h.seasonFromRequest(r)
+	if err != nil {
+		log.Printf("No active season: %v", err)
+		http.Error(w, "No active season found", http.StatusNotFound)
+		return
 	}
 
 	// Get all seasons for the selector
@@ -111,20 +110,33 @@ func (h *StandingsHandler) HandleStandings(w http.ResponseWriter, r *http.Reques
 	// Get home club ID from config
 	homeClubID := h.service.homeClubID
 
+	// Show the table as it stood after a given week when asked
+	week, _ := strconv.Atoi(r.URL.Query().Get("week"))
+
 	// Calculate standings for each division
 	var divisionStandings []DivisionStandings
 	for _, div := range divisions {
-		standings, err := h.calculateDivisionStandings(season.ID, div.ID, homeClubID)
+		standings, weeks, err := h.calculateDivisionStandings(season.ID, div.ID, homeClubID, week)
 		if err != nil {
 			log.Printf("Failed to calculate standings for division %d: %v", div.ID, err)
 			continue
 		}
-		divisionStandings = append(divisionStandings, DivisionStandings{
+		ds := DivisionStandings{
 			DivisionID:   div.ID,
 			DivisionName: div.Name,
 			Level:        div.Level,
 			Teams:        standings,
-		})
+			Weeks:        weeks,
+			Movers:       biggestMovers(standings),
+			Chart:        buildPositionChart(weeks, standings),
+		}
+		for _, t := range standings {
+			if t.IsHomeClub {
+				ds.HomeTeamID = t.TeamID
+				break
+			}
+		}
+		divisionStandings = append(divisionStandings, ds)
 	}
 
 	// Sort divisions by level
@@ -156,10 +168,23 @@ func (h *StandingsHandler) HandleStandings(w http.ResponseWriter, r *http.Reques
 	data := StandingsPageData{
 		Divisions:        divisionStandings,
 		ActiveDivisionID: activeDivisionID,
+		Week:             week,
 		Seasons:          allSeasons,
 		ActiveSeason:     season,
 		HomeClubID:       homeClubID,
 		HomeClubName:     homeClubName,
+		Share: sharecard.Meta{
+			Title:       homeClubName + " league standings",
+			Description: "Season " + season.Name + " tables, form and results",
+			URL:         sharecard.AbsoluteURL(r.URL.RequestURI()),
+		},
+	}
+	cardWeek := week
+	if cardWeek == 0 {
+		cardWeek = h.latestRoundupWeek(season.ID)
+	}
+	if cardWeek > 0 {
+		data.Share.Image = sharecard.AbsoluteURL(RoundupCardPath(season.ID, cardWeek))
 	}
 
 	// Check for HTMX partial request
@@ -189,14 +214,27 @@ func (h *StandingsHandler) HandleStandings(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// calculateDivisionStandings computes team standings for a division
-func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, homeClubID uint) ([]TeamStanding, error) {
-	ctx := fmt.Sprintf("season:%d,division:%d", seasonID, divisionID)
-	_ = ctx // We use context.Background() for DB queries
+// seasonFromRequest returns the season named by ?season=, or the active one
+func (h *StandingsHandler) seasonFromRequest(r *http.Request) (*models.Season, error) {
+	ctx := r.Context()
+	if sid, err := strconv.ParseUint(r.URL.Query().Get("season"), 10, 32); err == nil {
+		if season, err := h.service.seasonRepository.FindByID(ctx, uint(sid)); err == nil {
+			return season, nil
+		}
+	}
+	return h.service.seasonRepository.FindActive(ctx)
+}
 
+// calculateDivisionStandings computes team standings for a division by
+// replaying completed fixtures in week order. Passing uptoWeek > 0 stops
+// the replay after that week, giving the table as it stood then. Along
+// with the table it returns the weeks replayed, and each team carries its
+// position after every one of them and its form going into the table.
+func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, homeClubID uint, uptoWeek int) ([]TeamStanding, []int, error) {
 	// Use a raw SQL query for efficiency
 	// For each completed fixture in this division+season, sum up rubber points from matchups
 	type fixtureResult struct {
+		WeekNumber       int    `db:"week_number"`
 		HomeTeamID       uint   `db:"home_team_id"`
 		AwayTeamID       uint   `db:"away_team_id"`
 		HomeTeamName     string `db:"home_team_name"`
@@ -212,6 +250,7 @@ func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, home
 	var results []fixtureResult
 	err := h.service.db.Select(&results, `
 		SELECT
+			w.week_number,
 			f.home_team_id,
 			f.away_team_id,
 			ht.name AS home_team_name,
@@ -223,16 +262,18 @@ func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, home
 			COALESCE(SUM(m.home_score), 0) AS home_rubber_points,
 			COALESCE(SUM(m.away_score), 0) AS away_rubber_points
 		FROM fixtures f
+		JOIN weeks w ON f.week_id = w.id
 		JOIN teams ht ON f.home_team_id = ht.id
 		JOIN teams at2 ON f.away_team_id = at2.id
 		JOIN clubs hc ON ht.club_id = hc.id
 		JOIN clubs ac ON at2.club_id = ac.id
 		LEFT JOIN matchups m ON m.fixture_id = f.id
 		WHERE f.season_id = ? AND f.division_id = ? AND f.status = 'Completed'
-		GROUP BY f.id, f.home_team_id, f.away_team_id, ht.name, at2.name, ht.club_id, at2.club_id, hc.name, ac.name
+		GROUP BY f.id, w.week_number, f.scheduled_date, f.home_team_id, f.away_team_id, ht.name, at2.name, ht.club_id, at2.club_id, hc.name, ac.name
+		ORDER BY w.week_number, f.scheduled_date, f.id
 	`, seasonID, divisionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query fixture results: %w", err)
+		return nil, nil, fmt.Errorf("failed to query fixture results: %w", err)
 	}
 
 	// Accumulate standings per team
@@ -250,59 +291,91 @@ func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, home
 		}
 	}
 
+	// Include teams with no completed fixtures so every week ranks them all
+	bgCtx := context.Background()
+	teams, err := h.service.teamRepository.FindByDivisionAndSeason(bgCtx, divisionID, seasonID)
+	if err == nil {
+		for _, t := range teams {
+			clubName := ""
+			if club, clubErr := h.service.clubRepository.FindByID(bgCtx, t.ClubID); clubErr == nil {
+				clubName = club.Name
+			}
+			ensureTeam(t.ID, t.ClubID, t.Name, clubName)
+		}
+	}
 	for _, r := range results {
 		ensureTeam(r.HomeTeamID, r.HomeClubID, r.HomeTeamName, r.HomeClubName)
 		ensureTeam(r.AwayTeamID, r.AwayClubID, r.AwayTeamName, r.AwayClubName)
+	}
 
-		home := teamMap[r.HomeTeamID]
-		away := teamMap[r.AwayTeamID]
-
-		home.Played++
-		away.Played++
-		home.RubberFor += r.HomeRubberPoints
-		home.RubberAgainst += r.AwayRubberPoints
-		away.RubberFor += r.AwayRubberPoints
-		away.RubberAgainst += r.HomeRubberPoints
-
-		if r.HomeRubberPoints > r.AwayRubberPoints {
-			home.Won++
-			home.LeaguePoints += 3
-			away.Lost++
-		} else if r.AwayRubberPoints > r.HomeRubberPoints {
-			away.Won++
-			away.LeaguePoints += 3
-			home.Lost++
-		} else {
-			home.Drawn++
-			away.Drawn++
-			home.LeaguePoints += 1
-			away.LeaguePoints += 1
+	var weeks []int
+	for i, r := range results {
+		if uptoWeek > 0 && r.WeekNumber > uptoWeek {
+			break
 		}
-	}
 
-	// Also include teams with no completed fixtures
-	bgCtx := context.Background()
-	teams, err := h.service.teamRepository.FindByDivisionAndSeason(bgCtx, divisionID, seasonID)
-	if err == nil {
-		for _, t := range teams {
-			if _, ok := teamMap[t.ID]; !ok {
-				club, clubErr := h.service.clubRepository.FindByID(bgCtx, t.ClubID)
-				clubName := ""
-				if clubErr == nil {
-					clubName = club.Name
-				}
-				teamMap[t.ID] = &TeamStanding{
-					TeamID:     t.ID,
-					ClubID:     t.ClubID,
-					TeamName:   t.Name,
-					ClubName:   clubName,
-					IsHomeClub: t.ClubID == homeClubID,
-				}
+		recordResult(teamMap[r.HomeTeamID], teamMap[r.AwayTeamID], r.HomeRubberPoints, r.AwayRubberPoints)
+
+		// Snapshot the table at the end of each week
+		if i == len(results)-1 || results[i+1].WeekNumber != r.WeekNumber {
+			weeks = append(weeks, r.WeekNumber)
+			for pos, ts := range rankStandings(teamMap) {
+				teamMap[ts.TeamID].Positions = append(teamMap[ts.TeamID].Positions, pos+1)
 			}
 		}
 	}
 
-	// Convert to slice and sort
+	standings := rankStandings(teamMap)
+	for i := range standings {
+		if n := len(standings[i].Positions); n >= 2 {
+			standings[i].Movement = standings[i].Positions[n-2] - standings[i].Positions[n-1]
+		}
+	}
+	return standings, weeks, nil
+}
+
+// recordResult adds a fixture's rubber points to both teams' rows: three
+// league points for a win and one each for a draw
+func recordResult(home, away *TeamStanding, homeRubberPoints, awayRubberPoints int) {
+	home.Played++
+	away.Played++
+	home.RubberFor += homeRubberPoints
+	home.RubberAgainst += awayRubberPoints
+	away.RubberFor += awayRubberPoints
+	away.RubberAgainst += homeRubberPoints
+
+	if homeRubberPoints > awayRubberPoints {
+		home.Won++
+		home.LeaguePoints += 3
+		away.Lost++
+		home.addForm("W")
+		away.addForm("L")
+	} else if awayRubberPoints > homeRubberPoints {
+		away.Won++
+		away.LeaguePoints += 3
+		home.Lost++
+		home.addForm("L")
+		away.addForm("W")
+	} else {
+		home.Drawn++
+		away.Drawn++
+		home.LeaguePoints += 1
+		away.LeaguePoints += 1
+		home.addForm("D")
+		away.addForm("D")
+	}
+}
+
+// addForm records a result, keeping the last five
+func (ts *TeamStanding) addForm(result string) {
+	ts.Form = append(ts.Form, result)
+	if len(ts.Form) > 5 {
+		ts.Form = ts.Form[len(ts.Form)-5:]
+	}
+}
+
+// rankStandings sorts teams by league points, then rubber difference, then name
+func rankStandings(teamMap map[uint]*TeamStanding) []TeamStanding {
 	var standings []TeamStanding
 	for _, ts := range teamMap {
 		standings = append(standings, *ts)
@@ -321,5 +394,5 @@ func (h *StandingsHandler) calculateDivisionStandings(seasonID, divisionID, home
 		return standings[i].TeamName < standings[j].TeamName
 	})
 
-	return standings, nil
+	return standings
 }
@@ -0,0 +1,135 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Position chart layout, in SVG user units
+const (
+	chartLeft      = 40
+	chartRight     = 150 // room for team labels
+	chartTop       = 20
+	chartBottom    = 30
+	chartWeekWidth = 50
+	chartRowHeight = 28
+)
+
+// chartColours are used in turn for each team's line
+var chartColours = []string{
+	"#2c5530", "#e67e22", "#2980b9", "#8e44ad", "#c0392b",
+	"#16a085", "#d35400", "#7f8c8d", "#27ae60", "#f1c40f",
+}
+
+// PositionChart is an SVG line chart of league position after each week
+type PositionChart struct {
+	Width     int
+	Height    int
+	PlotRight int
+	Weeks     []ChartWeek
+	Positions []ChartPosition
+	Lines     []ChartLine
+}
+
+// ChartWeek is a labelled column on the chart
+type ChartWeek struct {
+	Number int
+	X      int
+}
+
+// ChartPosition is a labelled row on the chart
+type ChartPosition struct {
+	Number int
+	Y      int
+}
+
+// ChartLine is one team's path through the table
+type ChartLine struct {
+	TeamName   string
+	Colour     string
+	Points     string
+	LabelX     int
+	LabelY     int
+	IsHomeClub bool
+}
+
+// buildPositionChart lays out a chart of each team's position after each
+// week. It returns nil until there are two weeks to join up.
+func buildPositionChart(weeks []int, standings []TeamStanding) *PositionChart {
+	if len(weeks) < 2 || len(standings) == 0 {
+		return nil
+	}
+
+	chart := &PositionChart{
+		Width:  chartLeft + chartWeekWidth*(len(weeks)-1) + chartRight,
+		Height: chartTop + chartRowHeight*(len(standings)-1) + chartBottom,
+	}
+	chart.PlotRight = chart.Width - chartRight
+	x := func(i int) int { return chartLeft + chartWeekWidth*i }
+	y := func(position int) int { return chartTop + chartRowHeight*(position-1) }
+
+	for i, w := range weeks {
+		chart.Weeks = append(chart.Weeks, ChartWeek{Number: w, X: x(i)})
+	}
+	for p := 1; p <= len(standings); p++ {
+		chart.Positions = append(chart.Positions, ChartPosition{Number: p, Y: y(p)})
+	}
+
+	// Colour teams by name so each keeps its colour as the table changes
+	names := make([]string, 0, len(standings))
+	for _, ts := range standings {
+		names = append(names, ts.TeamName)
+	}
+	sort.Strings(names)
+	colours := make(map[string]string, len(names))
+	for i, name := range names {
+		colours[name] = chartColours[i%len(chartColours)]
+	}
+
+	for _, ts := range standings {
+		points := make([]string, len(ts.Positions))
+		for i, p := range ts.Positions {
+			points[i] = fmt.Sprintf("%d,%d", x(i), y(p))
+		}
+		last := len(ts.Positions) - 1
+		chart.Lines = append(chart.Lines, ChartLine{
+			TeamName:   ts.TeamName,
+			Colour:     colours[ts.TeamName],
+			Points:     strings.Join(points, " "),
+			LabelX:     x(last) + 8,
+			LabelY:     y(ts.Positions[last]) + 4,
+			IsHomeClub: ts.IsHomeClub,
+		})
+	}
+	return chart
+}
+
+// biggestMovers returns up to three teams that moved furthest in the table
+// in the latest week, climbers before fallers on a tie
+func biggestMovers(standings []TeamStanding) []TeamStanding {
+	var movers []TeamStanding
+	for _, ts := range standings {
+		if ts.Movement != 0 {
+			movers = append(movers, ts)
+		}
+	}
+	abs := func(n int) int {
+		if n < 0 {
+			return -n
+		}
+		return n
+	}
+	sort.SliceStable(movers, func(i, j int) bool {
+		if abs(movers[i].Movement) != abs(movers[j].Movement) {
+			return abs(movers[i].Movement) > abs(movers[j].Movement)
+		}
+		return movers[i].Movement > movers[j].Movement
+	})
+	if len(movers) > 3 {
+		movers = movers[:3]
+	}
+	return movers
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestStandingsReplayWeekByWeek(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "standings.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	start := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026', 2026, ?, ?, 1)`, start, start.AddDate(0, 6, 0))
+	for w := 1; w <= 4; w++ {
+		exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (?, ?, 1, ?, ?, '')`,
+			w, w, start.AddDate(0, 0, 7*(w-1)), start.AddDate(0, 0, 7*(w-1)+6))
+	}
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Parks League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Thursday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'St Ann''s'), (2, 'Hove'), (3, 'Preston') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES
+		(1, 'St Ann''s A', 1, 1, 1), (2, 'Hove A', 2, 1, 1), (3, 'Preston A', 3, 1, 1)`)
+
+	fixture := func(id, week, home, away int, status string, homeScore, awayScore int) {
+		exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+			VALUES (?, ?, ?, 1, 1, ?, ?, '', ?, '')`, id, home, away, week, start.AddDate(0, 0, 7*(week-1)+3), status)
+		exec(`INSERT INTO matchups (id, fixture_id, type, status, home_score, away_score, notes) VALUES (?, ?, 'Mens', 'Finished', ?, ?, '')`,
+			id, id, homeScore, awayScore)
+	}
+	fixture(1, 1, 2, 3, "Completed", 6, 2) // Hove top after week 1
+	fixture(2, 2, 1, 2, "Completed", 6, 2) // we go top
+	fixture(3, 3, 3, 1, "Completed", 8, 0) // Preston leap from bottom to top
+	fixture(4, 4, 2, 1, "Scheduled", 0, 0)
+
+	h := NewStandingsHandler(NewService(db, 1), "")
+	standings, weeks, err := h.calculateDivisionStandings(1, 1, 1, 0)
+	if err != nil {
+		t.Fatalf("standings: %v", err)
+	}
+	if !reflect.DeepEqual(weeks, []int{1, 2, 3}) {
+		t.Errorf("weeks = %v; want 1-3", weeks)
+	}
+
+	type row struct {
+		name      string
+		positions []int
+		form      []string
+		movement  int
+	}
+	var got []row
+	for _, ts := range standings {
+		got = append(got, row{ts.TeamName, ts.Positions, ts.Form, ts.Movement})
+	}
+	want := []row{
+		{"Preston A", []int{3, 3, 1}, []string{"L", "W"}, 2},
+		{"Hove A", []int{1, 2, 2}, []string{"W", "L"}, 0},
+		{"St Ann's A", []int{2, 1, 3}, []string{"W", "L"}, -2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("standings = %+v; want %+v", got, want)
+	}
+
+	var movers []string
+	for _, ts := range biggestMovers(standings) {
+		movers = append(movers, ts.TeamName)
+	}
+	if !reflect.DeepEqual(movers, []string{"Preston A", "St Ann's A"}) {
+		t.Errorf("movers = %v; want Preston then St Ann's", movers)
+	}
+
+	// The week 2 snapshot is the table as it stood then
+	snapshot, weeks, err := h.calculateDivisionStandings(1, 1, 1, 2)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if len(weeks) != 2 || snapshot[0].TeamName != "St Ann's A" || snapshot[0].Movement != 1 || snapshot[0].Played != 1 {
+		t.Errorf("week 2 snapshot = %+v over weeks %v; want St Ann's top, up one, after one match", snapshot[0], weeks)
+	}
+
+	// The public page shows the form guide, movers and chart
+	h.templateDir = filepath.Join(filepath.Dir(findMigrationsPath(t)), "templates")
+	rec := httptest.NewRecorder()
+	h.HandleStandings(rec, httptest.NewRequest("GET", "/standings?division=1", nil))
+	body := rec.Body.String()
+	for _, want := range []string{`class="form-badge form-W"`, `data-testid="biggest-movers"`, `data-testid="position-chart"`, `&week=3`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("standings page is missing %s", want)
+		}
+	}
+}
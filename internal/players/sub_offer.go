@@ -0,0 +1,189 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package players
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// SubOfferDetail is what a recipient sees when they open a sub offer from
+// their push notification
+type SubOfferDetail struct {
+	Offer        *models.SubOffer
+	Recipient    *models.SubOfferRecipient
+	Fixture      *models.Fixture
+	TeamName     string
+	OpponentName string
+	IsHome       bool
+	FilledByMe   bool
+}
+
+// GetSubOfferDetail loads an offer for one of its recipients. Players who
+// weren't sent the offer get an error so offer IDs can't be enumerated.
+func (s *Service) GetSubOfferDetail(ctx context.Context, offerID uint, playerID string) (*SubOfferDetail, error) {
+	offer, err := s.subOfferRepository.FindByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := s.subOfferRepository.FindRecipient(ctx, offerID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("player %s was not offered sub offer %d: %w", playerID, offerID, err)
+	}
+	fixture, err := s.fixtureRepository.FindByID(ctx, offer.FixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &SubOfferDetail{
+		Offer:     offer,
+		Recipient: recipient,
+		Fixture:   fixture,
+		IsHome:    fixture.HomeTeamID == offer.TeamID,
+	}
+	if offer.FilledByPlayerID != nil && *offer.FilledByPlayerID == playerID {
+		detail.FilledByMe = true
+	}
+	if team, err := s.teamRepository.FindByID(ctx, offer.TeamID); err == nil {
+		detail.TeamName = team.Name
+	}
+	oppID := fixture.AwayTeamID
+	if !detail.IsHome {
+		oppID = fixture.HomeTeamID
+	}
+	if opp, err := s.teamRepository.FindByID(ctx, oppID); err == nil {
+		detail.OpponentName = opp.Name
+	}
+	return detail, nil
+}
+
+// AcceptSubOffer tries to claim the spot for playerID. The first accept wins
+// and is added to the fixture selection; everyone else still waiting is told
+// the spot has gone. Returns false if someone beat them to it.
+func (s *Service) AcceptSubOffer(ctx context.Context, offerID uint, playerID string) (bool, error) {
+	detail, err := s.GetSubOfferDetail(ctx, offerID, playerID)
+	if err != nil {
+		return false, err
+	}
+	if detail.Offer.Status != models.SubOfferOpen {
+		if err := s.subOfferRepository.RecordResponse(ctx, offerID, playerID, models.SubOfferTooLate); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	won, err := s.subOfferRepository.Claim(ctx, offerID, playerID, detail.IsHome)
+	if err != nil || !won {
+		return won, err
+	}
+
+	s.notifySubOfferFilled(ctx, detail, playerID)
+	return true, nil
+}
+
+// DeclineSubOffer records that the player can't fill in
+func (s *Service) DeclineSubOffer(ctx context.Context, offerID uint, playerID string) error {
+	if _, err := s.subOfferRepository.FindRecipient(ctx, offerID, playerID); err != nil {
+		return err
+	}
+	return s.subOfferRepository.RecordResponse(ctx, offerID, playerID, models.SubOfferDeclined)
+}
+
+// notifySubOfferFilled lets recipients who were pushed the offer and haven't
+// answered yet know they no longer need to
+func (s *Service) notifySubOfferFilled(ctx context.Context, detail *SubOfferDetail, winnerID string) {
+	if s.pushService == nil {
+		return
+	}
+	recipients, err := s.subOfferRepository.FindRecipients(ctx, detail.Offer.ID)
+	if err != nil {
+		log.Printf("Failed to load sub offer %d recipients: %v", detail.Offer.ID, err)
+		return
+	}
+	for _, rcpt := range recipients {
+		if rcpt.PlayerID == winnerID || !rcpt.Notified || rcpt.Response != models.SubOfferPending {
+			continue
+		}
+		token := s.playerFantasyToken(ctx, rcpt.PlayerID)
+		if token == "" {
+			continue
+		}
+		payload := map[string]interface{}{
+			"title": "Spot filled",
+			"body":  fmt.Sprintf("Thanks — the %s spot on %s has been filled.", detail.TeamName, detail.Fixture.ScheduledDate.Format("Monday 2 January")),
+			"data": map[string]string{
+				"type": "sub-offer-filled",
+				"url":  s.linkSigner.NotificationLink(rcpt.PlayerID, token, fmt.Sprintf("/sub-offer/%d", detail.Offer.ID)),
+			},
+		}
+		if _, err := s.pushService.SendToPlayer(token, payload); err != nil {
+			log.Printf("Failed to send sub offer filled notice to player %s: %v", rcpt.PlayerID, err)
+		}
+	}
+}
+
+// handleSubOffer shows a sub offer (GET) or records the player's answer (POST)
+// for /my-availability/{token}/sub-offer/{offerID}
+func (h *AvailabilityHandler) handleSubOffer(w http.ResponseWriter, r *http.Request, player *models.Player, authToken, offerIDStr string) {
+	offerID, err := strconv.ParseUint(offerIDStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid offer ID", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+
+	message := ""
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form", http.StatusBadRequest)
+			return
+		}
+		switch r.FormValue("response") {
+		case "accept":
+			won, err := h.service.AcceptSubOffer(ctx, uint(offerID), player.ID)
+			if err != nil {
+				logAndError(w, "Failed to accept sub offer", err, http.StatusNotFound)
+				return
+			}
+			if won {
+				message = "You're in! You've been added to the team for this fixture."
+			} else {
+				message = "Sorry, the spot has already been filled."
+			}
+		case "decline":
+			if err := h.service.DeclineSubOffer(ctx, uint(offerID), player.ID); err != nil {
+				logAndError(w, "Failed to decline sub offer", err, http.StatusNotFound)
+				return
+			}
+			message = "No problem — thanks for letting us know."
+		default:
+			http.Error(w, "Invalid response", http.StatusBadRequest)
+			return
+		}
+	}
+
+	detail, err := h.service.GetSubOfferDetail(ctx, uint(offerID), player.ID)
+	if err != nil {
+		logAndError(w, "Sub offer not found", err, http.StatusNotFound)
+		return
+	}
+
+	tmpl, err := parseTemplate(h.templateDir, "players/sub_offer.html")
+	if err != nil {
+		logAndError(w, "Failed to load sub offer page", err, http.StatusInternalServerError)
+		return
+	}
+	if err := renderTemplate(w, tmpl, map[string]interface{}{
+		"Player":    player,
+		"AuthToken": authToken,
+		"Detail":    detail,
+		"Message":   message,
+	}); err != nil {
+		logAndError(w, err.Error(), err, http.StatusInternalServerError)
+	}
+}
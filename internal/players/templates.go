@@ -8,10 +8,21 @@ import (
 	"net/http"
 	"path/filepath"
 	"time"
+
+	"jim-dot-tennis/internal/templates"
 )
 
-// parseTemplate loads and parses a template file
+// playerTemplates caches the parsed player pages; templates.LoadAll parses
+// them all at startup
+var playerTemplates = templates.NewSet("players", buildTemplate, "players/*.html")
+
+// parseTemplate returns a parsed player template
 func parseTemplate(templateDir, templateName string) (*template.Template, error) {
+	return playerTemplates.Get(templateDir, templateName)
+}
+
+// buildTemplate loads and parses a template file
+func buildTemplate(templateDir, templateName string) (*template.Template, error) {
 	templatePath := filepath.Join(templateDir, templateName)
 
 	// Create template with common functions
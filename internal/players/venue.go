@@ -188,6 +188,9 @@ func (s *Service) GenerateFixtureICal(fixtureID uint) (string, error) {
 		weekNumber,
 		resolution.Club,
 	)
+	if seq, err := s.fixtureChangeRepository.CountByFixture(ctx, fixture.ID); err == nil {
+		event.Sequence = seq
+	}
 
 	return services.GenerateICalEvent(event), nil
 }
@@ -0,0 +1,133 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultTrustedProxies are the networks Caddy reaches the app from in the
+// Docker deployment, plus loopback for local development
+var defaultTrustedProxies = []string{
+	"127.0.0.0/8", "::1/128",
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7",
+}
+
+// DefaultGroups returns the route groups the site is protected with:
+//
+//   - login: the login, 2FA, forgot-password and set-password forms, per IP
+//     and per username. This sits in front of the per-username-and-IP
+//     lockout in the auth service, catching one IP spraying many usernames
+//     and many IPs working on one account.
+//   - player-links: pages behind a player's private link and the signed
+//     links sent in notifications, per IP and per token, with backoff for
+//     clients whose tokens keep failing to resolve.
+//   - push-api: the push subscription API, whose status endpoint reveals
+//     whether a player token exists.
+//   - projections: the public league projections, which simulate the rest
+//     of the season for every what-if not already cached, per IP.
+//
+// The limits are generous enough for a player clicking through their
+// availability pages on HTMX; each can be changed with the environment, see
+// ApplyEnv.
+func DefaultGroups(clientIP *ClientIP) []Group {
+	return []Group{
+		{
+			Name:    "login",
+			Paths:   []string{"/login", "/login/2fa", "/forgot-password", "/password/set/"},
+			Methods: []string{http.MethodPost},
+			Rules: []Rule{
+				{By: "ip", Key: clientIP.Key, Limit: Limit{Requests: 30, Window: 10 * time.Minute}},
+				{By: "username", Key: FormUsername, Limit: Limit{Requests: 10, Window: 10 * time.Minute}},
+			},
+		},
+		{
+			Name:  "player-links",
+			Paths: []string{"/my-availability/", "/my-profile/", "/go/", "/push-action/"},
+			Rules: []Rule{
+				{By: "ip", Key: clientIP.Key, Limit: Limit{Requests: 600, Window: 10 * time.Minute}},
+				{By: "token", Key: PathToken("/my-availability/", "/my-profile/", "/go/", "/push-action/availability/"), Limit: Limit{Requests: 300, Window: 10 * time.Minute}},
+			},
+			CountFailures: true,
+		},
+		{
+			Name:  "push-api",
+			Paths: []string{"/api/push/"},
+			Rules: []Rule{
+				{By: "ip", Key: clientIP.Key, Limit: Limit{Requests: 60, Window: 10 * time.Minute}},
+				{By: "token", Key: QueryToken("playerToken"), Limit: Limit{Requests: 30, Window: 10 * time.Minute}},
+			},
+		},
+		{
+			Name:  "projections",
+			Paths: []string{"/standings/projections"},
+			Rules: []Rule{
+				{By: "ip", Key: clientIP.Key, Limit: Limit{Requests: 300, Window: 10 * time.Minute}},
+			},
+		},
+	}
+}
+
+// ApplyEnv overrides group limits from the environment. Each rule reads
+// RATE_LIMIT_<GROUP>_<BY>, e.g. RATE_LIMIT_LOGIN_USERNAME=5/15m or
+// RATE_LIMIT_PLAYER_LINKS_IP=1000/10m; "off" removes the rule.
+func ApplyEnv(groups []Group) ([]Group, error) {
+	result := make([]Group, 0, len(groups))
+	for _, group := range groups {
+		rules := make([]Rule, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			name := envName(group.Name, rule.By)
+			value := strings.TrimSpace(os.Getenv(name))
+			switch {
+			case value == "":
+			case strings.EqualFold(value, "off"):
+				continue
+			default:
+				limit, err := ParseLimit(value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				rule.Limit = limit
+			}
+			rules = append(rules, rule)
+		}
+		group.Rules = rules
+		result = append(result, group)
+	}
+	return result, nil
+}
+
+// envName is the environment variable overriding one rule
+func envName(group, by string) string {
+	name := "RATE_LIMIT_" + group + "_" + by
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// TrustedProxiesFromEnv reads RATE_LIMIT_TRUSTED_PROXIES, a comma-separated
+// list of CIDRs whose X-Forwarded-For headers are believed. Unset, it trusts
+// loopback and private networks; "none" trusts nobody, for when the app is
+// exposed without a proxy.
+func TrustedProxiesFromEnv() ([]*net.IPNet, error) {
+	value := strings.TrimSpace(os.Getenv("RATE_LIMIT_TRUSTED_PROXIES"))
+	if strings.EqualFold(value, "none") {
+		return nil, nil
+	}
+	cidrs := defaultTrustedProxies
+	if value != "" {
+		cidrs = strings.Split(value, ",")
+	}
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("RATE_LIMIT_TRUSTED_PROXIES: %w", err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
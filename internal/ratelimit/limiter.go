@@ -0,0 +1,243 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+// Package ratelimit throttles abusive clients of the public and token
+// authenticated endpoints. Counters are kept in memory, which is enough for
+// the single app node this site runs on; a restart forgets them.
+package ratelimit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle counters are dropped
+const sweepInterval = time.Minute
+
+// Limit allows Requests requests in any Window-long stretch of time
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParseLimit reads a limit written as "requests/window", e.g. "30/10m"
+func ParseLimit(s string) (Limit, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q: want requests/window, e.g. 30/10m", s)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("rate limit %q: requests must be a positive number", s)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("rate limit %q: window must be a positive duration", s)
+	}
+	return Limit{Requests: n, Window: d}, nil
+}
+
+// String formats the limit the way ParseLimit reads it
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Window)
+}
+
+// Backoff blocks a client that keeps failing, such as one guessing player
+// link tokens. The first Free failures are forgiven; each one after that
+// doubles the block, starting at Base and capped at Max. Failures are
+// forgotten once the client has gone Reset without another.
+type Backoff struct {
+	Free  int
+	Base  time.Duration
+	Max   time.Duration
+	Reset time.Duration
+}
+
+// DefaultBackoff suits token lookups: a player mistyping a link a few times
+// is never blocked, but a guesser is soon waiting minutes between attempts
+var DefaultBackoff = Backoff{
+	Free:  5,
+	Base:  30 * time.Second,
+	Max:   15 * time.Minute,
+	Reset: time.Hour,
+}
+
+// delay returns how long to block after the given number of failures
+func (b Backoff) delay(failures int) time.Duration {
+	over := failures - b.Free
+	if over <= 0 {
+		return 0
+	}
+	d := b.Base
+	for i := 1; i < over && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// Key identifies one counter: a client, as seen by one rule of one group
+type Key struct {
+	Group string // route group, e.g. "player-links"
+	By    string // what the client is identified by: "ip", "token" or "username"
+	Value string
+}
+
+func (k Key) String() string {
+	return k.Group + "|" + k.By + "|" + k.Value
+}
+
+// counter is the sliding log and failure history for one key
+type counter struct {
+	limit        Limit
+	hits         []time.Time
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// prune drops hits that have slid out of the window
+func (c *counter) prune(now time.Time) {
+	cutoff := now.Add(-c.limit.Window)
+	i := 0
+	for i < len(c.hits) && !c.hits[i].After(cutoff) {
+		i++
+	}
+	c.hits = c.hits[i:]
+}
+
+// Limiter keeps a sliding-window counter per key. It is safe for
+// concurrent use.
+type Limiter struct {
+	mu        sync.Mutex
+	counters  map[Key]*counter
+	backoff   Backoff
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewLimiter creates an empty in-memory limiter
+func NewLimiter(backoff Backoff) *Limiter {
+	return &Limiter{
+		counters: make(map[Key]*counter),
+		backoff:  backoff,
+		now:      time.Now,
+	}
+}
+
+// Allow counts a request against key and reports whether it is within the
+// limit. When it isn't, the request is not counted and retryAfter says when
+// the client may try again.
+func (l *Limiter) Allow(key Key, limit Limit) (ok bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	c := l.counters[key]
+	if c == nil {
+		c = &counter{}
+		l.counters[key] = c
+	}
+	c.limit = limit
+
+	if now.Before(c.blockedUntil) {
+		return false, c.blockedUntil.Sub(now)
+	}
+	c.prune(now)
+	if len(c.hits) >= limit.Requests {
+		return false, c.hits[0].Add(limit.Window).Sub(now)
+	}
+	c.hits = append(c.hits, now)
+	return true, 0
+}
+
+// Fail records a failed attempt by key, blocking it under the backoff
+// policy once it has used up its free failures
+func (l *Limiter) Fail(key Key) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	c := l.counters[key]
+	if c == nil {
+		return
+	}
+	if now.Sub(c.lastFailure) > l.backoff.Reset {
+		c.failures = 0
+	}
+	c.failures++
+	c.lastFailure = now
+	if d := l.backoff.delay(c.failures); d > 0 {
+		c.blockedUntil = now.Add(d)
+	}
+}
+
+// Clear forgets everything about key, lifting any block
+func (l *Limiter) Clear(key Key) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.counters, key)
+}
+
+// Client describes a key the limiter is currently holding back
+type Client struct {
+	Key
+	Limit      Limit
+	Hits       int
+	Failures   int
+	Blocked    bool // in a backoff block rather than just at the limit
+	RetryAfter time.Duration
+}
+
+// Throttled lists the clients that would be refused right now, longest
+// wait first
+func (l *Limiter) Throttled() []Client {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var clients []Client
+	for key, c := range l.counters {
+		c.prune(now)
+		client := Client{Key: key, Limit: c.limit, Hits: len(c.hits), Failures: c.failures}
+		switch {
+		case now.Before(c.blockedUntil):
+			client.Blocked = true
+			client.RetryAfter = c.blockedUntil.Sub(now)
+		case len(c.hits) >= c.limit.Requests:
+			client.RetryAfter = c.hits[0].Add(c.limit.Window).Sub(now)
+		default:
+			continue
+		}
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		if clients[i].RetryAfter != clients[j].RetryAfter {
+			return clients[i].RetryAfter > clients[j].RetryAfter
+		}
+		return clients[i].Key.String() < clients[j].Key.String()
+	})
+	return clients
+}
+
+// sweep drops counters with nothing left to remember, so one-off visitors
+// don't accumulate. Callers must hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, c := range l.counters {
+		c.prune(now)
+		if len(c.hits) == 0 && !now.Before(c.blockedUntil) && now.Sub(c.lastFailure) > l.backoff.Reset {
+			delete(l.counters, key)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tokenKeyLength is how many hex digits of a token's hash are used as its
+// key. Player link tokens are built from player names and often share a
+// leading part, so the hash is shortened rather than the token; either way
+// whole tokens stay out of memory and off the admin page.
+const tokenKeyLength = 12
+
+// KeyFunc picks the value a rule counts a request under. An empty value
+// means the rule doesn't apply to the request.
+type KeyFunc func(r *http.Request) string
+
+// Rule limits a route group per IP, per token or per username
+type Rule struct {
+	By    string // "ip", "token" or "username"; shown on the admin page
+	Key   KeyFunc
+	Limit Limit
+}
+
+// Group applies rules to a set of routes. A path ending in "/" matches
+// everything under it, as with http.ServeMux; any other path matches exactly.
+type Group struct {
+	Name    string
+	Paths   []string
+	Methods []string // empty means every method
+	Rules   []Rule
+
+	// CountFailures makes requests whose handler calls ReportFailure, such
+	// as one with an unknown or expired token, count towards the client's
+	// backoff
+	CountFailures bool
+}
+
+// matches reports whether the group covers a request
+func (g Group) matches(r *http.Request) bool {
+	if len(g.Methods) > 0 && !containsString(g.Methods, r.Method) {
+		return false
+	}
+	for _, path := range g.Paths {
+		if path == r.URL.Path || (strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware enforces route group limits with a Limiter
+type Middleware struct {
+	limiter *Limiter
+	groups  []Group
+}
+
+// NewMiddleware creates the middleware. Groups are tried in order and the
+// first one matching a request applies.
+func NewMiddleware(limiter *Limiter, groups ...Group) *Middleware {
+	return &Middleware{limiter: limiter, groups: groups}
+}
+
+// Limiter returns the limiter behind the middleware, for the admin view
+func (m *Middleware) Limiter() *Limiter {
+	return m.limiter
+}
+
+// Protect wraps a handler so requests over a limit get 429 Too Many Requests
+// with a Retry-After header
+func (m *Middleware) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group, ok := m.groupFor(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var keys []Key
+		for _, rule := range group.Rules {
+			value := rule.Key(r)
+			if value == "" {
+				continue
+			}
+			key := Key{Group: group.Name, By: rule.By, Value: value}
+			if allowed, retryAfter := m.limiter.Allow(key, rule.Limit); !allowed {
+				slog.WarnContext(r.Context(), "rate limited",
+					"method", r.Method, "path", r.URL.Path, "group", group.Name,
+					"by", rule.By, "client", value, "limit", rule.Limit.String())
+				tooManyRequests(w, retryAfter)
+				return
+			}
+			keys = append(keys, key)
+		}
+
+		if !group.CountFailures {
+			next.ServeHTTP(w, r)
+			return
+		}
+		report := &failureReport{}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), failureReportKey{}, report)))
+		if report.failed {
+			for _, key := range keys {
+				m.limiter.Fail(key)
+			}
+		}
+	})
+}
+
+// failureReport is where a handler notes that a request's lookup failed
+type failureReport struct {
+	failed bool
+}
+
+type failureReportKey struct{}
+
+// ReportFailure tells the middleware that the request's token didn't
+// resolve, counting it towards the client's backoff. Other errors, such as
+// a valid token asking for a fixture that doesn't exist, shouldn't report.
+// Outside a group counting failures it does nothing.
+func ReportFailure(ctx context.Context) {
+	if report, ok := ctx.Value(failureReportKey{}).(*failureReport); ok {
+		report.failed = true
+	}
+}
+
+// groupFor finds the group covering a request
+func (m *Middleware) groupFor(r *http.Request) (Group, bool) {
+	for _, group := range m.groups {
+		if group.matches(r) {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
+
+// tooManyRequests refuses a request, telling the client when to come back
+func tooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	http.Error(w, fmt.Sprintf("Too many requests. Please try again in %s.", waitDescription(seconds)), http.StatusTooManyRequests)
+}
+
+// waitDescription phrases a wait for people rather than machines
+func waitDescription(seconds int) string {
+	if seconds < 60 {
+		return "a moment"
+	}
+	minutes := (seconds + 59) / 60
+	if minutes == 1 {
+		return "a minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// ClientIP resolves the address a request came from. The app normally sits
+// behind Caddy, so the connection's own address is the proxy's; when it is
+// one of the trusted proxies, the nearest untrusted X-Forwarded-For entry is
+// used instead.
+type ClientIP struct {
+	trusted []*net.IPNet
+}
+
+// NewClientIP creates a resolver trusting the given proxy networks
+func NewClientIP(trustedProxies []*net.IPNet) *ClientIP {
+	return &ClientIP{trusted: trustedProxies}
+}
+
+// Key is a KeyFunc keying on the client's IP
+func (c *ClientIP) Key(r *http.Request) string {
+	ip := remoteIP(r.RemoteAddr)
+	if !c.isTrusted(ip) {
+		return ip
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+		if !c.isTrusted(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// isTrusted reports whether an address belongs to a trusted proxy
+func (c *ClientIP) isTrusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range c.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP strips the port from a connection address
+func remoteIP(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+// PathToken is a KeyFunc keying on the path segment that follows one of the
+// prefixes, e.g. the token in /my-availability/{token}/...
+func PathToken(prefixes ...string) KeyFunc {
+	return func(r *http.Request) string {
+		for _, prefix := range prefixes {
+			if rest, ok := strings.CutPrefix(r.URL.Path, prefix); ok {
+				token, _, _ := strings.Cut(rest, "/")
+				return tokenKey(token)
+			}
+		}
+		return ""
+	}
+}
+
+// QueryToken is a KeyFunc keying on a token passed as a query parameter
+func QueryToken(param string) KeyFunc {
+	return func(r *http.Request) string {
+		return tokenKey(r.URL.Query().Get(param))
+	}
+}
+
+// FormUsername is a KeyFunc keying on the username a login form submits
+func FormUsername(r *http.Request) string {
+	return strings.ToLower(strings.TrimSpace(r.PostFormValue("username")))
+}
+
+// tokenKey shortens a token to the hash prefix used as its key
+func tokenKey(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])[:tokenKeyLength]
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A sliding window refuses the request over the limit until the oldest hit
+// slides out; repeated failures add a doubling block on top.
+func TestLimiterWindowAndBackoff(t *testing.T) {
+	now := time.Date(2026, 5, 1, 19, 0, 0, 0, time.UTC)
+	l := NewLimiter(Backoff{Free: 2, Base: time.Minute, Max: 3 * time.Minute, Reset: time.Hour})
+	l.now = func() time.Time { return now }
+
+	key := Key{Group: "login", By: "ip", Value: "203.0.113.9"}
+	limit := Limit{Requests: 2, Window: 10 * time.Minute}
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow(key, limit); !ok {
+			t.Fatalf("request %d refused within the limit", i+1)
+		}
+		now = now.Add(time.Minute)
+	}
+	if ok, retryAfter := l.Allow(key, limit); ok || retryAfter != 8*time.Minute {
+		t.Fatalf("third request: ok %v, retry after %s; want refused for 8m", ok, retryAfter)
+	}
+	if throttled := l.Throttled(); len(throttled) != 1 || throttled[0].Blocked || throttled[0].Hits != 2 {
+		t.Fatalf("throttled = %+v, want one client at its limit", throttled)
+	}
+	now = now.Add(8 * time.Minute)
+	if ok, _ := l.Allow(key, limit); !ok {
+		t.Fatal("request refused after the oldest hit left the window")
+	}
+
+	for i, want := range []time.Duration{0, 0, time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		l.Fail(key)
+		_, retryAfter := l.Allow(key, Limit{Requests: 100, Window: time.Minute})
+		if want == 0 {
+			if retryAfter != 0 {
+				t.Fatalf("failure %d blocked for %s, want free", i+1, retryAfter)
+			}
+			continue
+		}
+		if retryAfter != want {
+			t.Fatalf("failure %d blocked for %s, want %s", i+1, retryAfter, want)
+		}
+	}
+	if throttled := l.Throttled(); len(throttled) != 1 || !throttled[0].Blocked || throttled[0].Failures != 5 {
+		t.Fatalf("throttled = %+v, want one blocked client with 5 failures", throttled)
+	}
+
+	l.Clear(key)
+	if ok, _ := l.Allow(key, limit); !ok || len(l.Throttled()) != 0 {
+		t.Error("client still throttled after being cleared")
+	}
+}
+
+// The middleware answers 429 with Retry-After, counts tokens separately from
+// IPs, and backs off a client whose token lookups keep failing.
+func TestMiddlewareProtect(t *testing.T) {
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+	clientIP := NewClientIP([]*net.IPNet{private})
+	l := NewLimiter(Backoff{Free: 1, Base: time.Minute, Max: time.Hour, Reset: time.Hour})
+
+	groups := []Group{
+		{
+			Name:    "login",
+			Paths:   []string{"/login"},
+			Methods: []string{http.MethodPost},
+			Rules: []Rule{
+				{By: "username", Key: FormUsername, Limit: Limit{Requests: 1, Window: time.Hour}},
+			},
+		},
+		{
+			Name:  "player-links",
+			Paths: []string{"/my-availability/"},
+			Rules: []Rule{
+				{By: "ip", Key: clientIP.Key, Limit: Limit{Requests: 100, Window: time.Hour}},
+				{By: "token", Key: PathToken("/my-availability/"), Limit: Limit{Requests: 2, Window: time.Hour}},
+			},
+			CountFailures: true,
+		},
+	}
+	handler := NewMiddleware(l, groups...).Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/my-availability/Unknown") {
+			ReportFailure(r.Context())
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(req *http.Request, remoteAddr string) *httptest.ResponseRecorder {
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	login := func(username string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(url.Values{"username": {username}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	if rec := serve(login("James"), "203.0.113.1:5000"); rec.Code != http.StatusOK {
+		t.Fatalf("first login: status %d", rec.Code)
+	}
+	rec := serve(login(" james "), "198.51.100.7:5000")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "3600" {
+		t.Fatalf("same username from another IP: status %d, Retry-After %q; want 429, 3600", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := serve(httptest.NewRequest(http.MethodGet, "/login", nil), "203.0.113.1:5000"); rec.Code != http.StatusOK {
+		t.Errorf("GET outside the group's methods: status %d", rec.Code)
+	}
+
+	// Two players behind the proxy are told apart by X-Forwarded-For
+	player := func(path, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Forwarded-For", "1.2.3.4, "+forwardedFor)
+		return serve(req, "10.0.0.2:40000")
+	}
+	for i := 0; i < 2; i++ {
+		if rec := player("/my-availability/Sabalenka_Djokovic_Gauff_Sinner", "203.0.113.5"); rec.Code != http.StatusOK {
+			t.Fatalf("player request %d: status %d", i+1, rec.Code)
+		}
+	}
+	if rec := player("/my-availability/Sabalenka_Djokovic_Gauff_Sinner/fixture", "203.0.113.5"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("token over its limit: status %d, want 429", rec.Code)
+	}
+	if rec := player("/my-availability/Sabalenka_Djokovic_Gauff_Alcaraz", "203.0.113.5"); rec.Code != http.StatusOK {
+		t.Errorf("another token sharing a prefix: status %d, want 200", rec.Code)
+	}
+
+	// A guesser gets one free miss, then is blocked even for real links
+	if rec := player("/my-availability/Unknown_1", "192.0.2.50"); rec.Code != http.StatusNotFound {
+		t.Fatalf("first miss: status %d", rec.Code)
+	}
+	if rec := player("/my-availability/Unknown_2", "192.0.2.50"); rec.Code != http.StatusNotFound {
+		t.Fatalf("second miss: status %d", rec.Code)
+	}
+	if rec := player("/my-availability/Murray_Raducanu_Norrie_Boulter", "192.0.2.50"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("guesser after backoff: status %d, want 429", rec.Code)
+	}
+
+	var blocked []string
+	for _, client := range l.Throttled() {
+		blocked = append(blocked, client.String())
+	}
+	if want := "player-links|ip|192.0.2.50"; !containsString(blocked, want) {
+		t.Errorf("throttled = %v, want it to include %s", blocked, want)
+	}
+	for _, key := range blocked {
+		if strings.Contains(key, "Sabalenka") {
+			t.Errorf("throttled key %q exposes a token", key)
+		}
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv("RATE_LIMIT_PLAYER_LINKS_TOKEN", "5/1m")
+	t.Setenv("RATE_LIMIT_PUSH_API_IP", "off")
+	groups, err := ApplyEnv(DefaultGroups(NewClientIP(nil)))
+	if err != nil {
+		t.Fatalf("apply env: %v", err)
+	}
+	for _, group := range groups {
+		for _, rule := range group.Rules {
+			if group.Name == "player-links" && rule.By == "token" && rule.Limit != (Limit{Requests: 5, Window: time.Minute}) {
+				t.Errorf("player link token limit = %s, want 5/1m0s", rule.Limit)
+			}
+			if group.Name == "push-api" && rule.By == "ip" {
+				t.Error("push-api ip rule not switched off")
+			}
+		}
+	}
+
+	t.Setenv("RATE_LIMIT_LOGIN_IP", "lots")
+	if _, err := ApplyEnv(DefaultGroups(NewClientIP(nil))); err == nil {
+		t.Error("malformed limit accepted")
+	}
+}
+
+// Only a token that fails to resolve is a guess. A malformed request, or a
+// valid token asking for a fixture or sub offer that doesn't exist, must not
+// put the client into backoff.
+func TestDefaultGroupsCountOnlyReportedFailures(t *testing.T) {
+	l := NewLimiter(Backoff{Free: 1, Base: time.Hour, Max: time.Hour, Reset: time.Hour})
+	handler := NewMiddleware(l, DefaultGroups(NewClientIP(nil))...).Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/go/"):
+			http.Error(w, "Malformed", http.StatusBadRequest)
+		case strings.HasPrefix(r.URL.Path, "/my-availability/Unknown_Token"):
+			ReportFailure(r.Context())
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	get := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "203.0.113.20:5000"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := get("/go/not-a-signed-link"); code != http.StatusBadRequest {
+			t.Fatalf("malformed request %d: status %d, want 400", i+1, code)
+		}
+		if code := get("/my-availability/Real_Token/fixture/999"); code != http.StatusNotFound {
+			t.Fatalf("missing fixture %d: status %d, want 404", i+1, code)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		get("/my-availability/Unknown_Token")
+	}
+	if code := get("/my-availability/Unknown_Token"); code != http.StatusTooManyRequests {
+		t.Errorf("after two unknown tokens: status %d, want 429", code)
+	}
+}
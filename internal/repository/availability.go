@@ -6,6 +6,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 )
@@ -28,6 +30,7 @@ type AvailabilityRepository interface {
 
 	// Batch operations
 	BatchUpsertPlayerAvailability(ctx context.Context, playerID string, availabilities []AvailabilityUpdate) error
+	ResolveFixtureAvailability(ctx context.Context, playerIDs []string, fixtureID, seasonID uint, date time.Time) (map[string]ResolvedAvailability, error)
 }
 
 // AvailabilityUpdate represents a single availability update
@@ -37,6 +40,15 @@ type AvailabilityUpdate struct {
 	Reason string
 }
 
+// ResolvedAvailability is a player's availability for one fixture, taken
+// from the first of fixture-specific, date exception and general day-of-week
+// availability that the player has set
+type ResolvedAvailability struct {
+	PlayerID string                    `db:"player_id"`
+	Status   models.AvailabilityStatus `db:"status"`
+	Notes    string                    `db:"notes"`
+}
+
 // availabilityRepository implements AvailabilityRepository
 type availabilityRepository struct {
 	db *database.DB
@@ -231,3 +243,50 @@ func (r *availabilityRepository) BatchUpsertPlayerAvailability(ctx context.Conte
 
 	return tx.Commit()
 }
+
+// ResolveFixtureAvailability resolves the availability of several players
+// for one fixture in a single query, keyed by player ID. Players who have
+// set nothing that applies are Unknown.
+func (r *availabilityRepository) ResolveFixtureAvailability(ctx context.Context, playerIDs []string, fixtureID, seasonID uint, date time.Time) (map[string]ResolvedAvailability, error) {
+	result := make(map[string]ResolvedAvailability, len(playerIDs))
+	if len(playerIDs) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT p.id AS player_id,
+			CASE
+				WHEN fa.id IS NOT NULL THEN fa.status
+				WHEN ex.id IS NOT NULL THEN ex.status
+				WHEN ga.id IS NOT NULL THEN ga.status
+				ELSE 'Unknown'
+			END AS status,
+			CASE
+				WHEN fa.id IS NOT NULL THEN COALESCE(fa.notes, '')
+				WHEN ex.id IS NOT NULL THEN COALESCE(ex.reason, '')
+				WHEN ga.id IS NOT NULL THEN COALESCE(ga.notes, '')
+				ELSE ''
+			END AS notes
+		FROM players p
+		LEFT JOIN player_fixture_availability fa ON fa.player_id = p.id AND fa.fixture_id = ?
+		LEFT JOIN player_availability_exceptions ex ON ex.id = (
+			SELECT e.id FROM player_availability_exceptions e
+			WHERE e.player_id = p.id AND e.start_date <= ? AND e.end_date >= ?
+			ORDER BY e.created_at DESC
+			LIMIT 1
+		)
+		LEFT JOIN player_general_availability ga
+			ON ga.player_id = p.id AND ga.season_id = ? AND ga.day_of_week = ?
+		WHERE p.id IN (?)
+	`, fixtureID, date, date, seasonID, date.Weekday().String(), playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	var rows []ResolvedAvailability
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.PlayerID] = row
+	}
+	return result, nil
+}
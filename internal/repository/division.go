@@ -6,6 +6,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 )
@@ -15,6 +17,7 @@ type DivisionRepository interface {
 	// Basic CRUD operations
 	FindAll(ctx context.Context) ([]models.Division, error)
 	FindByID(ctx context.Context, id uint) (*models.Division, error)
+	FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Division, error)
 	Create(ctx context.Context, division *models.Division) error
 	Update(ctx context.Context, division *models.Division) error
 	Delete(ctx context.Context, id uint) error
@@ -76,6 +79,31 @@ func (r *divisionRepository) FindByID(ctx context.Context, id uint) (*models.Div
 	return &division, nil
 }
 
+// FindByIDs retrieves several divisions in one query, keyed by ID. IDs with no
+// matching row are left out of the map.
+func (r *divisionRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Division, error) {
+	result := make(map[uint]*models.Division, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT id, name, level, play_day, league_id, season_id, max_teams_per_club, created_at, updated_at
+		FROM divisions
+		WHERE id IN (?)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var rows []models.Division
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result[rows[i].ID] = &rows[i]
+	}
+	return result, nil
+}
+
 // Create inserts a new division record
 func (r *divisionRepository) Create(ctx context.Context, division *models.Division) error {
 	now := time.Now()
@@ -63,6 +63,7 @@ type FixtureRepository interface {
 	// Fixture Player Selection methods
 	FindSelectedPlayers(ctx context.Context, fixtureID uint) ([]models.FixturePlayer, error)
 	FindSelectedPlayersByTeam(ctx context.Context, fixtureID, managingTeamID uint) ([]models.FixturePlayer, error)
+	FindUpcomingSelectionsByPlayer(ctx context.Context, playerID string, from time.Time) ([]models.FixturePlayer, error)
 	GetSelectedPlayerCountByFixture(ctx context.Context, fixtureID uint, managingTeamID *uint) (int, error)
 	AddSelectedPlayer(ctx context.Context, fixturePlayer *models.FixturePlayer) error
 	RemoveSelectedPlayer(ctx context.Context, fixtureID uint, playerID string) error
@@ -567,6 +568,20 @@ func (r *fixtureRepository) FindSelectedPlayersByTeam(ctx context.Context, fixtu
 	return players, err
 }
 
+// FindUpcomingSelectionsByPlayer retrieves the player's fixture_players rows
+// for fixtures scheduled on or after from
+func (r *fixtureRepository) FindUpcomingSelectionsByPlayer(ctx context.Context, playerID string, from time.Time) ([]models.FixturePlayer, error) {
+	var players []models.FixturePlayer
+	err := r.db.SelectContext(ctx, &players, `
+		SELECT fp.id, fp.fixture_id, fp.player_id, fp.is_home, fp.position, fp.managing_team_id, fp.created_at, fp.updated_at
+		FROM fixture_players fp
+		INNER JOIN fixtures f ON f.id = fp.fixture_id
+		WHERE fp.player_id = ? AND f.scheduled_date >= ?
+		ORDER BY f.scheduled_date ASC
+	`, playerID, from)
+	return players, err
+}
+
 // RemoveSelectedPlayerByTeam removes a player from the fixture selection for a specific team
 func (r *fixtureRepository) RemoveSelectedPlayerByTeam(ctx context.Context, fixtureID, managingTeamID uint, playerID string) error {
 	_, err := r.db.ExecContext(ctx, `
@@ -0,0 +1,74 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// FixtureChangeRepository defines data access for the fixture change log
+type FixtureChangeRepository interface {
+	Create(ctx context.Context, change *models.FixtureChange) error
+	SetNotifiedCount(ctx context.Context, id uint, count int) error
+	FindByFixture(ctx context.Context, fixtureID uint) ([]models.FixtureChange, error)
+	CountByFixture(ctx context.Context, fixtureID uint) (int, error)
+}
+
+type fixtureChangeRepository struct {
+	db *database.DB
+}
+
+// NewFixtureChangeRepository creates a new fixture change repository
+func NewFixtureChangeRepository(db *database.DB) FixtureChangeRepository {
+	return &fixtureChangeRepository{db: db}
+}
+
+const fixtureChangeColumns = `id, fixture_id, change_type, old_value, new_value, notified_count, created_at`
+
+// Create appends a change to the log
+func (r *fixtureChangeRepository) Create(ctx context.Context, change *models.FixtureChange) error {
+	change.CreatedAt = time.Now()
+
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO fixture_changes (fixture_id, change_type, old_value, new_value, notified_count, created_at)
+		VALUES (:fixture_id, :change_type, :old_value, :new_value, :notified_count, :created_at)
+	`, change)
+	if err != nil {
+		return err
+	}
+
+	if id, err := result.LastInsertId(); err == nil {
+		change.ID = uint(id)
+	}
+	return nil
+}
+
+// SetNotifiedCount records how many players were reached about a change
+func (r *fixtureChangeRepository) SetNotifiedCount(ctx context.Context, id uint, count int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE fixture_changes SET notified_count = ? WHERE id = ?`, count, id)
+	return err
+}
+
+// FindByFixture returns a fixture's change log, oldest first
+func (r *fixtureChangeRepository) FindByFixture(ctx context.Context, fixtureID uint) ([]models.FixtureChange, error) {
+	var changes []models.FixtureChange
+	err := r.db.SelectContext(ctx, &changes, `
+		SELECT `+fixtureChangeColumns+`
+		FROM fixture_changes
+		WHERE fixture_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, fixtureID)
+	return changes, err
+}
+
+// CountByFixture returns how many times a fixture has changed; used as the
+// iCal SEQUENCE number
+func (r *fixtureChangeRepository) CountByFixture(ctx context.Context, fixtureID uint) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM fixture_changes WHERE fixture_id = ?`, fixtureID)
+	return count, err
+}
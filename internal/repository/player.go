@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 )
@@ -17,6 +19,7 @@ type PlayerRepository interface {
 	FindAll(ctx context.Context) ([]models.Player, error)
 	FindAllIncludingInactive(ctx context.Context) ([]models.Player, error)
 	FindByID(ctx context.Context, id string) (*models.Player, error)
+	FindByIDs(ctx context.Context, ids []string) (map[string]*models.Player, error)
 	Create(ctx context.Context, player *models.Player) error
 	Update(ctx context.Context, player *models.Player) error
 	Delete(ctx context.Context, id string) error
@@ -129,6 +132,31 @@ func (r *playerRepository) FindByID(ctx context.Context, id string) (*models.Pla
 	return &player, nil
 }
 
+// FindByIDs retrieves several players in one query, keyed by ID. IDs with no
+// matching row are left out of the map.
+func (r *playerRepository) FindByIDs(ctx context.Context, ids []string) (map[string]*models.Player, error) {
+	result := make(map[string]*models.Player, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT id, first_name, last_name, preferred_name, gender, reporting_privacy, club_id, fantasy_match_id, is_active, created_at, updated_at
+		FROM players
+		WHERE id IN (?)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var rows []models.Player
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result[rows[i].ID] = &rows[i]
+	}
+	return result, nil
+}
+
 // Create inserts a new player record
 func (r *playerRepository) Create(ctx context.Context, player *models.Player) error {
 	now := time.Now()
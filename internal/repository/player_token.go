@@ -0,0 +1,117 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// PlayerTokenRepository defines data access for retired player links and the
+// per-device access log
+type PlayerTokenRepository interface {
+	Retire(ctx context.Context, retired *models.RetiredPlayerToken) error
+	FindRetired(ctx context.Context, token string) (*models.RetiredPlayerToken, error)
+	FindRetiredByPlayer(ctx context.Context, playerID string) ([]models.RetiredPlayerToken, error)
+	EndGrace(ctx context.Context, id uint, at time.Time) error
+	RecordAccess(ctx context.Context, playerID, token, userAgent, ip string) error
+	FindAccessesByPlayer(ctx context.Context, playerID string) ([]models.PlayerTokenAccess, error)
+}
+
+type playerTokenRepository struct {
+	db *database.DB
+}
+
+// NewPlayerTokenRepository creates a new player token repository
+func NewPlayerTokenRepository(db *database.DB) PlayerTokenRepository {
+	return &playerTokenRepository{db: db}
+}
+
+const retiredPlayerTokenColumns = `id, player_id, token, replaced_by_token, reason, retired_by, grace_until, created_at`
+
+const playerTokenAccessColumns = `id, player_id, token, user_agent, ip, access_count, first_seen_at, last_seen_at`
+
+// Retire records a token that has been rotated out
+func (r *playerTokenRepository) Retire(ctx context.Context, retired *models.RetiredPlayerToken) error {
+	retired.CreatedAt = time.Now()
+	retired.GraceUntil = retired.GraceUntil.UTC()
+
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO retired_player_tokens (player_id, token, replaced_by_token, reason, retired_by, grace_until, created_at)
+		VALUES (:player_id, :token, :replaced_by_token, :reason, :retired_by, :grace_until, :created_at)
+	`, retired)
+	if err != nil {
+		return err
+	}
+
+	if id, err := result.LastInsertId(); err == nil {
+		retired.ID = uint(id)
+	}
+	return nil
+}
+
+// FindRetired looks up a retired token
+func (r *playerTokenRepository) FindRetired(ctx context.Context, token string) (*models.RetiredPlayerToken, error) {
+	var retired models.RetiredPlayerToken
+	err := r.db.GetContext(ctx, &retired, `
+		SELECT `+retiredPlayerTokenColumns+`
+		FROM retired_player_tokens
+		WHERE token = ?
+	`, token)
+	if err != nil {
+		return nil, err
+	}
+	return &retired, nil
+}
+
+// FindRetiredByPlayer returns a player's retired tokens, most recent first
+func (r *playerTokenRepository) FindRetiredByPlayer(ctx context.Context, playerID string) ([]models.RetiredPlayerToken, error) {
+	var retired []models.RetiredPlayerToken
+	err := r.db.SelectContext(ctx, &retired, `
+		SELECT `+retiredPlayerTokenColumns+`
+		FROM retired_player_tokens
+		WHERE player_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, playerID)
+	return retired, err
+}
+
+// EndGrace stops a retired token redirecting, if its grace period runs past at
+func (r *playerTokenRepository) EndGrace(ctx context.Context, id uint, at time.Time) error {
+	at = at.UTC()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE retired_player_tokens SET grace_until = ? WHERE id = ? AND grace_until > ?
+	`, at, id, at)
+	return err
+}
+
+// RecordAccess notes that a device opened a player link, bumping its count
+// and last-seen time if it has been seen before
+func (r *playerTokenRepository) RecordAccess(ctx context.Context, playerID, token, userAgent, ip string) error {
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO player_token_accesses (player_id, token, user_agent, ip, access_count, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, 1, ?, ?)
+		ON CONFLICT (token, user_agent) DO UPDATE SET
+			ip = excluded.ip,
+			access_count = player_token_accesses.access_count + 1,
+			last_seen_at = excluded.last_seen_at
+	`, playerID, token, userAgent, ip, now, now)
+	return err
+}
+
+// FindAccessesByPlayer returns every device that has used any of a player's
+// links, most recently seen first
+func (r *playerTokenRepository) FindAccessesByPlayer(ctx context.Context, playerID string) ([]models.PlayerTokenAccess, error) {
+	var accesses []models.PlayerTokenAccess
+	err := r.db.SelectContext(ctx, &accesses, `
+		SELECT `+playerTokenAccessColumns+`
+		FROM player_token_accesses
+		WHERE player_id = ?
+		ORDER BY last_seen_at DESC, id DESC
+	`, playerID)
+	return accesses, err
+}
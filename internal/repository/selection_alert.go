@@ -0,0 +1,68 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// SelectionAlertRepository defines data access for selected-player availability alerts
+type SelectionAlertRepository interface {
+	Create(ctx context.Context, alert *models.SelectionAlert) error
+	FindOpenByFixture(ctx context.Context, fixtureID uint) ([]models.SelectionAlert, error)
+	Acknowledge(ctx context.Context, fixtureID uint, playerID string) error
+}
+
+type selectionAlertRepository struct {
+	db *database.DB
+}
+
+// NewSelectionAlertRepository creates a new selection alert repository
+func NewSelectionAlertRepository(db *database.DB) SelectionAlertRepository {
+	return &selectionAlertRepository{db: db}
+}
+
+const selectionAlertColumns = `id, fixture_id, player_id, team_id, previous_status, new_status, acknowledged_at, created_at`
+
+// Create records a new alert
+func (r *selectionAlertRepository) Create(ctx context.Context, alert *models.SelectionAlert) error {
+	alert.CreatedAt = time.Now()
+
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO selection_alerts (fixture_id, player_id, team_id, previous_status, new_status, created_at)
+		VALUES (:fixture_id, :player_id, :team_id, :previous_status, :new_status, :created_at)
+	`, alert)
+	if err != nil {
+		return err
+	}
+
+	if id, err := result.LastInsertId(); err == nil {
+		alert.ID = uint(id)
+	}
+	return nil
+}
+
+// FindOpenByFixture returns unacknowledged alerts for a fixture, newest first
+func (r *selectionAlertRepository) FindOpenByFixture(ctx context.Context, fixtureID uint) ([]models.SelectionAlert, error) {
+	var alerts []models.SelectionAlert
+	err := r.db.SelectContext(ctx, &alerts, `
+		SELECT `+selectionAlertColumns+`
+		FROM selection_alerts
+		WHERE fixture_id = ? AND acknowledged_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`, fixtureID)
+	return alerts, err
+}
+
+// Acknowledge clears every open alert for a player in a fixture
+func (r *selectionAlertRepository) Acknowledge(ctx context.Context, fixtureID uint, playerID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE selection_alerts SET acknowledged_at = ?
+		WHERE fixture_id = ? AND player_id = ? AND acknowledged_at IS NULL
+	`, time.Now(), fixtureID, playerID)
+	return err
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// SubOfferRepository defines data access for substitute call-outs
+type SubOfferRepository interface {
+	Create(ctx context.Context, offer *models.SubOffer, recipientIDs []string) error
+	FindByID(ctx context.Context, id uint) (*models.SubOffer, error)
+	FindOpenByFixtureAndTeam(ctx context.Context, fixtureID, teamID uint) (*models.SubOffer, error)
+	FindRecipients(ctx context.Context, offerID uint) ([]models.SubOfferRecipient, error)
+	FindRecipient(ctx context.Context, offerID uint, playerID string) (*models.SubOfferRecipient, error)
+	MarkNotified(ctx context.Context, offerID uint, playerID string) error
+	RecordResponse(ctx context.Context, offerID uint, playerID string, response models.SubOfferResponse) error
+	Cancel(ctx context.Context, id uint) error
+
+	// Claim atomically fills an open offer for playerID and adds them to the
+	// fixture selection for the offer's team. Returns false (and records a
+	// TooLate response) when someone else got there first.
+	Claim(ctx context.Context, offerID uint, playerID string, isHome bool) (bool, error)
+}
+
+type subOfferRepository struct {
+	db *database.DB
+}
+
+// NewSubOfferRepository creates a new sub offer repository
+func NewSubOfferRepository(db *database.DB) SubOfferRepository {
+	return &subOfferRepository{db: db}
+}
+
+const subOfferColumns = `id, fixture_id, team_id, gender, status, filled_by_player_id, created_by_user_id,
+	filled_at, created_at, updated_at`
+
+const subOfferRecipientColumns = `id, sub_offer_id, player_id, response, notified, responded_at, created_at`
+
+// Create inserts the offer and one pending recipient row per player
+func (r *subOfferRepository) Create(ctx context.Context, offer *models.SubOffer, recipientIDs []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	offer.Status = models.SubOfferOpen
+	offer.CreatedAt = now
+	offer.UpdatedAt = now
+
+	result, err := tx.NamedExecContext(ctx, `
+		INSERT INTO sub_offers (fixture_id, team_id, gender, status, created_by_user_id, created_at, updated_at)
+		VALUES (:fixture_id, :team_id, :gender, :status, :created_by_user_id, :created_at, :updated_at)
+	`, offer)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	offer.ID = uint(id)
+
+	for _, playerID := range recipientIDs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO sub_offer_recipients (sub_offer_id, player_id, response, created_at)
+			VALUES (?, ?, ?, ?)
+		`, offer.ID, playerID, models.SubOfferPending, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByID retrieves a sub offer by its ID
+func (r *subOfferRepository) FindByID(ctx context.Context, id uint) (*models.SubOffer, error) {
+	var offer models.SubOffer
+	err := r.db.GetContext(ctx, &offer, `
+		SELECT `+subOfferColumns+`
+		FROM sub_offers
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// FindOpenByFixtureAndTeam returns the current open offer for a team's
+// fixture, or nil if there isn't one
+func (r *subOfferRepository) FindOpenByFixtureAndTeam(ctx context.Context, fixtureID, teamID uint) (*models.SubOffer, error) {
+	var offer models.SubOffer
+	err := r.db.GetContext(ctx, &offer, `
+		SELECT `+subOfferColumns+`
+		FROM sub_offers
+		WHERE fixture_id = ? AND team_id = ? AND status = ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, fixtureID, teamID, models.SubOfferOpen)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// FindRecipients lists everyone an offer was broadcast to
+func (r *subOfferRepository) FindRecipients(ctx context.Context, offerID uint) ([]models.SubOfferRecipient, error) {
+	var recipients []models.SubOfferRecipient
+	err := r.db.SelectContext(ctx, &recipients, `
+		SELECT `+subOfferRecipientColumns+`
+		FROM sub_offer_recipients
+		WHERE sub_offer_id = ?
+		ORDER BY id ASC
+	`, offerID)
+	return recipients, err
+}
+
+// FindRecipient retrieves a single recipient row for an offer
+func (r *subOfferRepository) FindRecipient(ctx context.Context, offerID uint, playerID string) (*models.SubOfferRecipient, error) {
+	var recipient models.SubOfferRecipient
+	err := r.db.GetContext(ctx, &recipient, `
+		SELECT `+subOfferRecipientColumns+`
+		FROM sub_offer_recipients
+		WHERE sub_offer_id = ? AND player_id = ?
+	`, offerID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return &recipient, nil
+}
+
+// MarkNotified records that the offer push reached at least one of the player's devices
+func (r *subOfferRepository) MarkNotified(ctx context.Context, offerID uint, playerID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sub_offer_recipients SET notified = 1
+		WHERE sub_offer_id = ? AND player_id = ?
+	`, offerID, playerID)
+	return err
+}
+
+// RecordResponse stores a recipient's response
+func (r *subOfferRepository) RecordResponse(ctx context.Context, offerID uint, playerID string, response models.SubOfferResponse) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sub_offer_recipients SET response = ?, responded_at = ?
+		WHERE sub_offer_id = ? AND player_id = ?
+	`, response, time.Now(), offerID, playerID)
+	return err
+}
+
+// Cancel withdraws an open offer
+func (r *subOfferRepository) Cancel(ctx context.Context, id uint) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sub_offers SET status = ?, updated_at = ?
+		WHERE id = ? AND status = ?
+	`, models.SubOfferCancelled, time.Now(), id, models.SubOfferOpen)
+	return err
+}
+
+// Claim fills the offer and adds the player to the fixture selection in a
+// single transaction. The conditional UPDATE on status = 'Open' is what makes
+// first-accept-wins safe when two players tap 'Accept' at the same moment.
+func (r *subOfferRepository) Claim(ctx context.Context, offerID uint, playerID string, isHome bool) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE sub_offers
+		SET status = ?, filled_by_player_id = ?, filled_at = ?, updated_at = ?
+		WHERE id = ? AND status = ?
+	`, models.SubOfferFilled, playerID, now, now, offerID, models.SubOfferOpen)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE sub_offer_recipients SET response = ?, responded_at = ?
+			WHERE sub_offer_id = ? AND player_id = ?
+		`, models.SubOfferTooLate, now, offerID, playerID); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	var offer models.SubOffer
+	if err := tx.GetContext(ctx, &offer, `SELECT `+subOfferColumns+` FROM sub_offers WHERE id = ?`, offerID); err != nil {
+		return false, err
+	}
+
+	var position int
+	if err := tx.GetContext(ctx, &position, `
+		SELECT COUNT(*) FROM fixture_players WHERE fixture_id = ? AND managing_team_id = ?
+	`, offer.FixtureID, offer.TeamID); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO fixture_players (fixture_id, player_id, is_home, position, managing_team_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, offer.FixtureID, playerID, isHome, position+1, offer.TeamID, now, now); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sub_offer_recipients SET response = ?, responded_at = ?
+		WHERE sub_offer_id = ? AND player_id = ?
+	`, models.SubOfferAccepted, now, offerID, playerID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
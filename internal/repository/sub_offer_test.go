@@ -0,0 +1,89 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Two recipients accept the same sub offer: the first claim must fill the
+// offer and land in fixture_players, the second must be told it's too late
+// and must NOT be added to the selection.
+func TestSubOfferClaimFirstAcceptWins(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sub_offer_test.db")
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+
+	start := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+	exec(`INSERT INTO seasons (id, name, year, start_date, end_date, is_active) VALUES (1, '2026 Season', 2026, ?, ?, 1)`, start, start.AddDate(0, 5, 0))
+	exec(`INSERT INTO weeks (id, week_number, season_id, start_date, end_date, name) VALUES (1, 1, 1, ?, ?, 'Week 1')`, start, start.AddDate(0, 0, 6))
+	exec(`INSERT INTO leagues (id, name, type, year, region) VALUES (1, 'Test League', 'Parks', 2026, 'Brighton')`)
+	exec(`INSERT INTO divisions (id, name, level, play_day, league_id, season_id) VALUES (1, 'Division 1', 1, 'Tuesday', 1, 1)`)
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'Home'), (2, 'Rivals') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO teams (id, name, club_id, division_id, season_id) VALUES (1, 'Home A', 1, 1, 1), (2, 'Rivals', 2, 1, 1)`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('p1', 'First', 'Taker', 1), ('p2', 'Second', 'Taker', 1)`)
+	exec(`INSERT INTO fixtures (id, home_team_id, away_team_id, division_id, season_id, week_id, scheduled_date, venue_location, status, notes)
+		VALUES (10, 1, 2, 1, 1, 1, ?, '', 'Scheduled', '')`, start)
+
+	repo := NewSubOfferRepository(db)
+	offer := &models.SubOffer{FixtureID: 10, TeamID: 1}
+	if err := repo.Create(ctx, offer, []string{"p1", "p2"}); err != nil {
+		t.Fatalf("create offer: %v", err)
+	}
+
+	won, err := repo.Claim(ctx, offer.ID, "p1", true)
+	if err != nil || !won {
+		t.Fatalf("first claim: won=%v err=%v", won, err)
+	}
+	won, err = repo.Claim(ctx, offer.ID, "p2", true)
+	if err != nil || won {
+		t.Fatalf("second claim: won=%v err=%v, want lost", won, err)
+	}
+
+	got, err := repo.FindByID(ctx, offer.ID)
+	if err != nil {
+		t.Fatalf("reload offer: %v", err)
+	}
+	if got.Status != models.SubOfferFilled || got.FilledByPlayerID == nil || *got.FilledByPlayerID != "p1" {
+		t.Fatalf("offer = %+v, want Filled by p1", got)
+	}
+
+	var selected []string
+	if err := db.SelectContext(ctx, &selected, `SELECT player_id FROM fixture_players WHERE fixture_id = 10 AND managing_team_id = 1`); err != nil {
+		t.Fatalf("load selection: %v", err)
+	}
+	if len(selected) != 1 || selected[0] != "p1" {
+		t.Fatalf("selection = %v, want [p1]", selected)
+	}
+
+	late, err := repo.FindRecipient(ctx, offer.ID, "p2")
+	if err != nil {
+		t.Fatalf("load p2 recipient: %v", err)
+	}
+	if late.Response != models.SubOfferTooLate {
+		t.Fatalf("p2 response = %s, want TooLate", late.Response)
+	}
+}
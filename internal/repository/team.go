@@ -7,6 +7,8 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 )
@@ -16,6 +18,7 @@ type TeamRepository interface {
 	// Basic CRUD operations
 	FindAll(ctx context.Context) ([]models.Team, error)
 	FindByID(ctx context.Context, id uint) (*models.Team, error)
+	FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Team, error)
 	Create(ctx context.Context, team *models.Team) error
 	Update(ctx context.Context, team *models.Team) error
 	Delete(ctx context.Context, id uint) error
@@ -94,6 +97,31 @@ func (r *teamRepository) FindByID(ctx context.Context, id uint) (*models.Team, e
 	return &team, nil
 }
 
+// FindByIDs retrieves several teams in one query, keyed by ID. IDs with no
+// matching row are left out of the map.
+func (r *teamRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Team, error) {
+	result := make(map[uint]*models.Team, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT id, name, club_id, division_id, season_id, active, created_at, updated_at
+		FROM teams
+		WHERE id IN (?)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var rows []models.Team
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result[rows[i].ID] = &rows[i]
+	}
+	return result, nil
+}
+
 // Create inserts a new team record
 func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
 	now := time.Now()
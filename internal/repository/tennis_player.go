@@ -70,6 +70,7 @@ type FantasyMixedDoublesRepository interface {
 
 	// Utility methods
 	GenerateRandomMatches(ctx context.Context, count int) error
+	CreateRandomMatch(ctx context.Context) (*models.FantasyMixedDoubles, error)
 	GenerateAuthToken(teamAWoman, teamAMan, teamBWoman, teamBMan *models.ProTennisPlayer) string
 }
 
@@ -624,6 +625,68 @@ func (r *fantasyMixedDoublesRepository) GenerateRandomMatches(ctx context.Contex
 	return nil
 }
 
+// CreateRandomMatch creates one new active match from randomly chosen ATP and
+// WTA players, retrying if the drawn pairing's token is already taken. Used
+// to issue a fresh private link when a player's link is rotated.
+func (r *fantasyMixedDoublesRepository) CreateRandomMatch(ctx context.Context) (*models.FantasyMixedDoubles, error) {
+	const maxAttempts = 5
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var atpPlayers, wtaPlayers []models.ProTennisPlayer
+		err := r.db.SelectContext(ctx, &atpPlayers, `
+			SELECT id, first_name, last_name, common_name, nationality, gender, 
+			       current_rank, highest_rank, year_pro, wikipedia_url, hand, 
+			       birth_date, birth_place, tour, created_at, updated_at
+			FROM tennis_players 
+			WHERE tour = 'ATP'
+			ORDER BY RANDOM()
+			LIMIT 2
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ATP players: %w", err)
+		}
+		err = r.db.SelectContext(ctx, &wtaPlayers, `
+			SELECT id, first_name, last_name, common_name, nationality, gender, 
+			       current_rank, highest_rank, year_pro, wikipedia_url, hand, 
+			       birth_date, birth_place, tour, created_at, updated_at
+			FROM tennis_players 
+			WHERE tour = 'WTA'
+			ORDER BY RANDOM()
+			LIMIT 2
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get WTA players: %w", err)
+		}
+		if len(atpPlayers) < 2 || len(wtaPlayers) < 2 {
+			return nil, fmt.Errorf("not enough ATP and WTA players to create a match")
+		}
+
+		authToken := r.GenerateAuthToken(&wtaPlayers[0], &atpPlayers[0], &wtaPlayers[1], &atpPlayers[1])
+		var taken int
+		if err := r.db.GetContext(ctx, &taken, `SELECT COUNT(*) FROM fantasy_mixed_doubles WHERE auth_token = ?`, authToken); err != nil {
+			return nil, err
+		}
+		if taken > 0 {
+			continue
+		}
+
+		match := &models.FantasyMixedDoubles{
+			TeamAWomanID: wtaPlayers[0].ID,
+			TeamAManID:   atpPlayers[0].ID,
+			TeamBWomanID: wtaPlayers[1].ID,
+			TeamBManID:   atpPlayers[1].ID,
+			AuthToken:    authToken,
+			IsActive:     true,
+		}
+		if err := r.Create(ctx, match); err != nil {
+			return nil, err
+		}
+		return match, nil
+	}
+
+	return nil, fmt.Errorf("failed to draw an unused match after %d attempts", maxAttempts)
+}
+
 // GenerateAuthToken creates an authentication token from four tennis players
 func (r *fantasyMixedDoublesRepository) GenerateAuthToken(teamAWoman, teamAMan, teamBWoman, teamBMan *models.ProTennisPlayer) string {
 	// Regular expression to match any character that is not alphanumeric or hyphen
@@ -0,0 +1,156 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// TournamentDrawRepository defines the interface for cup draw data access
+type TournamentDrawRepository interface {
+	FindByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentDraw, error)
+	ReplaceForTournament(ctx context.Context, tournamentID uint, draws []models.TournamentDraw) error
+}
+
+type tournamentDrawRepository struct {
+	db *database.DB
+}
+
+func NewTournamentDrawRepository(db *database.DB) TournamentDrawRepository {
+	return &tournamentDrawRepository{db: db}
+}
+
+// FindByTournament returns a tournament's draws with their matches, in round
+// and draw order, and the players on each match
+func (r *tournamentDrawRepository) FindByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentDraw, error) {
+	var draws []models.TournamentDraw
+	if err := r.db.SelectContext(ctx, &draws, `
+		SELECT id, tournament_id, courthive_draw_id, name, draw_type, event_name, event_type, created_at
+		FROM tournament_draws
+		WHERE tournament_id = ?
+		ORDER BY event_name ASC, name ASC, id ASC
+	`, tournamentID); err != nil {
+		return nil, err
+	}
+	if len(draws) == 0 {
+		return draws, nil
+	}
+
+	drawIDs := make([]uint, len(draws))
+	drawIndex := make(map[uint]int, len(draws))
+	for i, draw := range draws {
+		drawIDs[i] = draw.ID
+		drawIndex[draw.ID] = i
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT id, draw_id, courthive_match_up_id, round_number, round_position, round_name,
+		       side1_name, side2_name, winning_side, score, score_side2, status, played_date
+		FROM tournament_matches
+		WHERE draw_id IN (?)
+		ORDER BY round_number ASC, round_position ASC, id ASC
+	`, drawIDs)
+	if err != nil {
+		return nil, err
+	}
+	var matches []models.TournamentMatch
+	if err := r.db.SelectContext(ctx, &matches, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(`
+		SELECT mp.id, mp.match_id, mp.side, mp.participant_name, mp.player_id
+		FROM tournament_match_players mp
+		JOIN tournament_matches m ON m.id = mp.match_id
+		WHERE m.draw_id IN (?)
+		ORDER BY mp.match_id ASC, mp.side ASC, mp.id ASC
+	`, drawIDs)
+	if err != nil {
+		return nil, err
+	}
+	var players []models.TournamentMatchPlayer
+	if err := r.db.SelectContext(ctx, &players, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	playersByMatch := make(map[uint][]models.TournamentMatchPlayer)
+	for _, p := range players {
+		playersByMatch[p.MatchID] = append(playersByMatch[p.MatchID], p)
+	}
+
+	for _, match := range matches {
+		match.Players = playersByMatch[match.ID]
+		i := drawIndex[match.DrawID]
+		draws[i].Matches = append(draws[i].Matches, match)
+	}
+	return draws, nil
+}
+
+// ReplaceForTournament swaps a tournament's stored draws for the given ones,
+// with their matches and players. Run it in a unit of work so readers never
+// see the tournament with its draws half written.
+func (r *tournamentDrawRepository) ReplaceForTournament(ctx context.Context, tournamentID uint, draws []models.TournamentDraw) error {
+	// Matches and their players go with the draw (ON DELETE CASCADE)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tournament_draws WHERE tournament_id = ?`, tournamentID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range draws {
+		draw := &draws[i]
+		draw.TournamentID = tournamentID
+		draw.CreatedAt = now
+		result, err := r.db.NamedExecContext(ctx, `
+			INSERT INTO tournament_draws (tournament_id, courthive_draw_id, name, draw_type, event_name, event_type, created_at)
+			VALUES (:tournament_id, :courthive_draw_id, :name, :draw_type, :event_name, :event_type, :created_at)
+		`, draw)
+		if err != nil {
+			return err
+		}
+		drawID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		draw.ID = uint(drawID)
+
+		for j := range draw.Matches {
+			match := &draw.Matches[j]
+			match.DrawID = draw.ID
+			result, err := r.db.NamedExecContext(ctx, `
+				INSERT INTO tournament_matches (draw_id, courthive_match_up_id, round_number, round_position, round_name,
+				                                side1_name, side2_name, winning_side, score, score_side2, status, played_date)
+				VALUES (:draw_id, :courthive_match_up_id, :round_number, :round_position, :round_name,
+				        :side1_name, :side2_name, :winning_side, :score, :score_side2, :status, :played_date)
+			`, match)
+			if err != nil {
+				return err
+			}
+			matchID, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			match.ID = uint(matchID)
+
+			for k := range match.Players {
+				player := &match.Players[k]
+				player.MatchID = match.ID
+				result, err := r.db.NamedExecContext(ctx, `
+					INSERT INTO tournament_match_players (match_id, side, participant_name, player_id)
+					VALUES (:match_id, :side, :participant_name, :player_id)
+				`, player)
+				if err != nil {
+					return err
+				}
+				if id, err := result.LastInsertId(); err == nil {
+					player.ID = uint(id)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+)
+
+// TournamentEntryRepository defines the interface for tournament event and entry data access
+type TournamentEntryRepository interface {
+	// Events
+	FindEventsByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentEvent, error)
+	FindOpenEvents(ctx context.Context, now time.Time) ([]models.TournamentEvent, error)
+	FindEventByID(ctx context.Context, id uint) (*models.TournamentEvent, error)
+	CreateEvent(ctx context.Context, event *models.TournamentEvent) error
+	DeleteEvent(ctx context.Context, id uint) error
+
+	// Entries
+	FindByID(ctx context.Context, id uint) (*models.TournamentEntry, error)
+	FindByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentEntry, error)
+	FindByPlayer(ctx context.Context, playerID string) ([]models.TournamentEntry, error)
+	IsEntered(ctx context.Context, eventID uint, playerID string) (bool, error)
+	Create(ctx context.Context, entry *models.TournamentEntry) error
+	UpdateStatus(ctx context.Context, id uint, status models.TournamentEntryStatus) error
+	UpdateSyncStatus(ctx context.Context, id uint, status models.TournamentEntrySyncStatus, syncError string) error
+}
+
+type tournamentEntryRepository struct {
+	db *database.DB
+}
+
+func NewTournamentEntryRepository(db *database.DB) TournamentEntryRepository {
+	return &tournamentEntryRepository{db: db}
+}
+
+const tournamentEventColumns = `
+	e.id, e.tournament_id, e.name, e.event_type, e.gender, e.entry_deadline,
+	e.courthive_event_id, e.created_at, t.name AS tournament_name`
+
+const tournamentEntryColumns = `
+	en.id, en.event_id, en.player_id, en.partner_id, en.status, en.sync_status, en.sync_error,
+	en.synced_at, en.created_at, en.updated_at,
+	p.first_name || ' ' || p.last_name AS player_name,
+	COALESCE(pp.first_name || ' ' || pp.last_name, '') AS partner_name,
+	e.name AS event_name`
+
+func (r *tournamentEntryRepository) FindEventsByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentEvent, error) {
+	var events []models.TournamentEvent
+	err := r.db.SelectContext(ctx, &events, `
+		SELECT `+tournamentEventColumns+`
+		FROM tournament_events e
+		JOIN tournaments t ON t.id = e.tournament_id
+		WHERE e.tournament_id = ?
+		ORDER BY e.entry_deadline ASC, e.name ASC
+	`, tournamentID)
+	return events, err
+}
+
+// FindOpenEvents returns the events of visible tournaments still taking entries
+func (r *tournamentEntryRepository) FindOpenEvents(ctx context.Context, now time.Time) ([]models.TournamentEvent, error) {
+	var events []models.TournamentEvent
+	err := r.db.SelectContext(ctx, &events, `
+		SELECT `+tournamentEventColumns+`
+		FROM tournament_events e
+		JOIN tournaments t ON t.id = e.tournament_id
+		WHERE t.is_visible = 1 AND e.entry_deadline > ?
+		ORDER BY e.entry_deadline ASC, t.name ASC, e.name ASC
+	`, now)
+	return events, err
+}
+
+func (r *tournamentEntryRepository) FindEventByID(ctx context.Context, id uint) (*models.TournamentEvent, error) {
+	var event models.TournamentEvent
+	err := r.db.GetContext(ctx, &event, `
+		SELECT `+tournamentEventColumns+`
+		FROM tournament_events e
+		JOIN tournaments t ON t.id = e.tournament_id
+		WHERE e.id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *tournamentEntryRepository) CreateEvent(ctx context.Context, event *models.TournamentEvent) error {
+	event.CreatedAt = time.Now()
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO tournament_events (tournament_id, name, event_type, gender, entry_deadline, courthive_event_id, created_at)
+		VALUES (:tournament_id, :name, :event_type, :gender, :entry_deadline, :courthive_event_id, :created_at)
+	`, event)
+	if err != nil {
+		return err
+	}
+	if id, err := result.LastInsertId(); err == nil {
+		event.ID = uint(id)
+	}
+	return nil
+}
+
+// DeleteEvent removes an event and its entries
+func (r *tournamentEntryRepository) DeleteEvent(ctx context.Context, id uint) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM tournament_events WHERE id = ?`, id)
+	return err
+}
+
+func (r *tournamentEntryRepository) FindByID(ctx context.Context, id uint) (*models.TournamentEntry, error) {
+	var entry models.TournamentEntry
+	err := r.db.GetContext(ctx, &entry, `
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries en
+		JOIN tournament_events e ON e.id = en.event_id
+		JOIN players p ON p.id = en.player_id
+		LEFT JOIN players pp ON pp.id = en.partner_id
+		WHERE en.id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *tournamentEntryRepository) FindByTournament(ctx context.Context, tournamentID uint) ([]models.TournamentEntry, error) {
+	var entries []models.TournamentEntry
+	err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries en
+		JOIN tournament_events e ON e.id = en.event_id
+		JOIN players p ON p.id = en.player_id
+		LEFT JOIN players pp ON pp.id = en.partner_id
+		WHERE e.tournament_id = ?
+		ORDER BY e.name ASC, en.created_at ASC, en.id ASC
+	`, tournamentID)
+	return entries, err
+}
+
+// FindByPlayer returns the entries a player made or was entered into as a partner
+func (r *tournamentEntryRepository) FindByPlayer(ctx context.Context, playerID string) ([]models.TournamentEntry, error) {
+	var entries []models.TournamentEntry
+	err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries en
+		JOIN tournament_events e ON e.id = en.event_id
+		JOIN players p ON p.id = en.player_id
+		LEFT JOIN players pp ON pp.id = en.partner_id
+		WHERE en.player_id = ? OR en.partner_id = ?
+		ORDER BY e.entry_deadline ASC, en.id ASC
+	`, playerID, playerID)
+	return entries, err
+}
+
+// IsEntered reports whether a player is on a pending or approved entry in
+// the event, either as the entrant or as a partner
+func (r *tournamentEntryRepository) IsEntered(ctx context.Context, eventID uint, playerID string) (bool, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM tournament_entries
+		WHERE event_id = ? AND status IN ('Pending', 'Approved')
+		AND (player_id = ? OR partner_id = ?)
+	`, eventID, playerID, playerID)
+	return count > 0, err
+}
+
+func (r *tournamentEntryRepository) Create(ctx context.Context, entry *models.TournamentEntry) error {
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	if entry.Status == "" {
+		entry.Status = models.EntryPending
+	}
+	if entry.SyncStatus == "" {
+		entry.SyncStatus = models.EntryNotSynced
+	}
+
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO tournament_entries (event_id, player_id, partner_id, status, sync_status, sync_error, created_at, updated_at)
+		VALUES (:event_id, :player_id, :partner_id, :status, :sync_status, :sync_error, :created_at, :updated_at)
+	`, entry)
+	if err != nil {
+		return err
+	}
+	if id, err := result.LastInsertId(); err == nil {
+		entry.ID = uint(id)
+	}
+	return nil
+}
+
+func (r *tournamentEntryRepository) UpdateStatus(ctx context.Context, id uint, status models.TournamentEntryStatus) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_entries SET status = ?, updated_at = ? WHERE id = ?
+	`, string(status), time.Now(), id)
+	return err
+}
+
+// UpdateSyncStatus records the outcome of exporting an entry to CourtHive.
+// synced_at is set when the entry reached CourtHive.
+func (r *tournamentEntryRepository) UpdateSyncStatus(ctx context.Context, id uint, status models.TournamentEntrySyncStatus, syncError string) error {
+	now := time.Now()
+	var syncedAt *time.Time
+	if status == models.EntrySynced {
+		syncedAt = &now
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_entries
+		SET sync_status = ?, sync_error = ?, synced_at = COALESCE(?, synced_at), updated_at = ?
+		WHERE id = ?
+	`, string(status), syncError, syncedAt, now, id)
+	return err
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+
+	"jim-dot-tennis/internal/database"
+)
+
+// UnitOfWork runs several repository calls atomically. Repository methods
+// called with the context passed to fn take part in one transaction, which
+// is committed when fn returns nil and rolled back when it returns an error.
+// Methods that open a transaction of their own return an error if called
+// inside one.
+type UnitOfWork interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NewUnitOfWork creates a unit of work over the given database
+func NewUnitOfWork(db *database.DB) UnitOfWork {
+	return db
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Writes made by different repositories inside one unit of work are all
+// committed together or, when the work fails, none of them are.
+func TestUnitOfWorkCommitsOrRollsBackTogether(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "unit_of_work_test.db")
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(db)
+	seasons := NewSeasonRepository(db)
+	weeks := NewWeekRepository(db)
+
+	start := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+	createSeason := func(ctx context.Context, name string) error {
+		season := &models.Season{Name: name, Year: 2026, StartDate: start, EndDate: start.AddDate(0, 5, 0)}
+		if err := seasons.Create(ctx, season); err != nil {
+			return err
+		}
+		return weeks.Create(ctx, &models.Week{
+			WeekNumber: 1, SeasonID: season.ID, StartDate: start, EndDate: start.AddDate(0, 0, 6), Name: "Week 1",
+		})
+	}
+	countRows := func(table string) int {
+		t.Helper()
+		var n int
+		if err := db.GetContext(ctx, &n, "SELECT COUNT(*) FROM "+table); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		return n
+	}
+
+	failure := errors.New("week 2 clashes")
+	err = uow.WithTx(ctx, func(ctx context.Context) error {
+		if err := createSeason(ctx, "Abandoned"); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("failed unit of work: err = %v; want %v", err, failure)
+	}
+	if s, w := countRows("seasons"), countRows("weeks"); s != 0 || w != 0 {
+		t.Errorf("after rollback: %d seasons, %d weeks; want none", s, w)
+	}
+
+	// A nested WithTx joins the outer transaction rather than committing early
+	err = uow.WithTx(ctx, func(ctx context.Context) error {
+		return uow.WithTx(ctx, func(ctx context.Context) error {
+			return createSeason(ctx, "2026 Season")
+		})
+	})
+	if err != nil {
+		t.Fatalf("unit of work: %v", err)
+	}
+	if s, w := countRows("seasons"), countRows("weeks"); s != 1 || w != 1 {
+		t.Errorf("after commit: %d seasons, %d weeks; want 1 of each", s, w)
+	}
+
+	// Repository methods with a transaction of their own refuse to run
+	// inside one instead of waiting on SQLite's single writer
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+	}
+	exec(`INSERT INTO clubs (id, name) VALUES (1, 'Home') ON CONFLICT DO NOTHING`)
+	exec(`INSERT INTO players (id, first_name, last_name, club_id) VALUES ('p1', 'First', 'Player', 1)`)
+	availability := NewAvailabilityRepository(db)
+	err = uow.WithTx(ctx, func(ctx context.Context) error {
+		return availability.UpsertPlayerAvailability(ctx, "p1", start, models.Unavailable, "Away")
+	})
+	if err == nil {
+		t.Error("nested BeginTxx inside a unit of work succeeded; want an error")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"jim-dot-tennis/internal/database"
 	"jim-dot-tennis/internal/models"
 )
@@ -15,6 +17,7 @@ type WeekRepository interface {
 	// Basic CRUD operations
 	FindAll(ctx context.Context) ([]models.Week, error)
 	FindByID(ctx context.Context, id uint) (*models.Week, error)
+	FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Week, error)
 	Create(ctx context.Context, week *models.Week) error
 	Update(ctx context.Context, week *models.Week) error
 	Delete(ctx context.Context, id uint) error
@@ -72,6 +75,31 @@ func (r *weekRepository) FindByID(ctx context.Context, id uint) (*models.Week, e
 	return &week, nil
 }
 
+// FindByIDs retrieves several weeks in one query, keyed by ID. IDs with no
+// matching row are left out of the map.
+func (r *weekRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]*models.Week, error) {
+	result := make(map[uint]*models.Week, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT id, week_number, season_id, start_date, end_date, name, is_active, created_at, updated_at
+		FROM weeks
+		WHERE id IN (?)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var rows []models.Week
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result[rows[i].ID] = &rows[i]
+	}
+	return result, nil
+}
+
 // Create inserts a new week record
 func (r *weekRepository) Create(ctx context.Context, week *models.Week) error {
 	now := time.Now()
@@ -0,0 +1,319 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+)
+
+// HeadToHeadService builds rivalry records between two teams or two clubs
+// from completed fixtures across every season. A team is followed between
+// seasons by its name and club, since each season has its own team row.
+type HeadToHeadService struct {
+	db *database.DB
+}
+
+// NewHeadToHeadService creates a new head-to-head service
+func NewHeadToHeadService(db *database.DB) *HeadToHeadService {
+	return &HeadToHeadService{db: db}
+}
+
+// HeadToHeadMeeting is one completed fixture between the two sides, scored
+// from side A's point of view
+type HeadToHeadMeeting struct {
+	FixtureID    uint
+	Date         time.Time
+	SeasonName   string
+	DivisionName string
+	HomeTeamName string
+	AwayTeamName string
+	AAtHome      bool
+	RubbersA     int
+	RubbersB     int
+	Result       string // W, D or L for side A
+}
+
+// Margin is the winning margin in rubber points
+func (m HeadToHeadMeeting) Margin() int {
+	if m.RubbersA > m.RubbersB {
+		return m.RubbersA - m.RubbersB
+	}
+	return m.RubbersB - m.RubbersA
+}
+
+// HeadToHeadStreak is a run of consecutive results. Holder is the side on
+// the run, or empty for a run of draws.
+type HeadToHeadStreak struct {
+	Holder string
+	Result string
+	Length int
+}
+
+// HeadToHead is the record between side A and side B
+type HeadToHead struct {
+	SideA   string
+	SideB   string
+	ClubAID uint
+	ClubBID uint
+
+	Played   int
+	WonA     int
+	WonB     int
+	Drawn    int
+	RubbersA int
+	RubbersB int
+
+	Meetings    []HeadToHeadMeeting // newest first
+	BiggestWinA *HeadToHeadMeeting
+	BiggestWinB *HeadToHeadMeeting
+
+	CurrentStreak  HeadToHeadStreak
+	LongestStreakA int // most consecutive wins by side A
+	LongestStreakB int
+}
+
+type headToHeadRow struct {
+	FixtureID        uint      `db:"fixture_id"`
+	ScheduledDate    time.Time `db:"scheduled_date"`
+	SeasonName       string    `db:"season_name"`
+	DivisionName     string    `db:"division_name"`
+	HomeTeamName     string    `db:"home_team_name"`
+	AwayTeamName     string    `db:"away_team_name"`
+	HomeClubID       uint      `db:"home_club_id"`
+	AwayClubID       uint      `db:"away_club_id"`
+	HomeClubName     string    `db:"home_club_name"`
+	AwayClubName     string    `db:"away_club_name"`
+	HomeRubberPoints int       `db:"home_rubber_points"`
+	AwayRubberPoints int       `db:"away_rubber_points"`
+}
+
+// completedFixtures returns completed fixtures matching where, newest first,
+// with their summed rubber points
+func (s *HeadToHeadService) completedFixtures(ctx context.Context, where string, args ...interface{}) ([]headToHeadRow, error) {
+	var rows []headToHeadRow
+	err := s.db.SelectContext(ctx, &rows, `
+		SELECT
+			f.id AS fixture_id,
+			f.scheduled_date,
+			s.name AS season_name,
+			d.name AS division_name,
+			ht.name AS home_team_name,
+			at2.name AS away_team_name,
+			ht.club_id AS home_club_id,
+			at2.club_id AS away_club_id,
+			hc.name AS home_club_name,
+			ac.name AS away_club_name,
+			COALESCE(SUM(m.home_score), 0) AS home_rubber_points,
+			COALESCE(SUM(m.away_score), 0) AS away_rubber_points
+		FROM fixtures f
+		JOIN teams ht ON f.home_team_id = ht.id
+		JOIN teams at2 ON f.away_team_id = at2.id
+		JOIN clubs hc ON ht.club_id = hc.id
+		JOIN clubs ac ON at2.club_id = ac.id
+		JOIN seasons s ON f.season_id = s.id
+		JOIN divisions d ON f.division_id = d.id
+		LEFT JOIN matchups m ON m.fixture_id = f.id
+		WHERE f.status = 'Completed' AND (`+where+`)
+		GROUP BY f.id, f.scheduled_date, s.name, d.name, ht.name, at2.name, ht.club_id, at2.club_id, hc.name, ac.name
+		ORDER BY f.scheduled_date DESC, f.id DESC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query head-to-head fixtures: %w", err)
+	}
+	return rows, nil
+}
+
+// Teams returns the record between two teams, following each by name and
+// club into every season it has played
+func (s *HeadToHeadService) Teams(ctx context.Context, teamAID, teamBID uint) (*HeadToHead, error) {
+	type teamKey struct {
+		Name   string `db:"name"`
+		ClubID uint   `db:"club_id"`
+	}
+	var a, b teamKey
+	if err := s.db.GetContext(ctx, &a, `SELECT name, club_id FROM teams WHERE id = ?`, teamAID); err != nil {
+		return nil, fmt.Errorf("failed to find team %d: %w", teamAID, err)
+	}
+	if err := s.db.GetContext(ctx, &b, `SELECT name, club_id FROM teams WHERE id = ?`, teamBID); err != nil {
+		return nil, fmt.Errorf("failed to find team %d: %w", teamBID, err)
+	}
+	if a == b {
+		return nil, errors.New("a team has no head-to-head record with itself")
+	}
+
+	rows, err := s.completedFixtures(ctx,
+		`(ht.name = ? AND ht.club_id = ? AND at2.name = ? AND at2.club_id = ?)
+		OR (ht.name = ? AND ht.club_id = ? AND at2.name = ? AND at2.club_id = ?)`,
+		a.Name, a.ClubID, b.Name, b.ClubID, b.Name, b.ClubID, a.Name, a.ClubID)
+	if err != nil {
+		return nil, err
+	}
+
+	h2h := &HeadToHead{SideA: a.Name, SideB: b.Name, ClubAID: a.ClubID, ClubBID: b.ClubID}
+	for _, row := range rows {
+		h2h.add(row, row.HomeTeamName == a.Name && row.HomeClubID == a.ClubID)
+	}
+	h2h.finish()
+	return h2h, nil
+}
+
+// Clubs returns the record between every team of one club and every team
+// of another
+func (s *HeadToHeadService) Clubs(ctx context.Context, clubAID, clubBID uint) (*HeadToHead, error) {
+	if clubAID == clubBID {
+		return nil, errors.New("a club has no head-to-head record with itself")
+	}
+	var names []struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+	if err := s.db.SelectContext(ctx, &names, `SELECT id, name FROM clubs WHERE id IN (?, ?)`, clubAID, clubBID); err != nil {
+		return nil, fmt.Errorf("failed to find clubs: %w", err)
+	}
+	if len(names) != 2 {
+		return nil, fmt.Errorf("clubs %d and %d not found", clubAID, clubBID)
+	}
+
+	rows, err := s.completedFixtures(ctx,
+		`(ht.club_id = ? AND at2.club_id = ?) OR (ht.club_id = ? AND at2.club_id = ?)`,
+		clubAID, clubBID, clubBID, clubAID)
+	if err != nil {
+		return nil, err
+	}
+
+	h2h := &HeadToHead{ClubAID: clubAID, ClubBID: clubBID}
+	for _, n := range names {
+		if n.ID == clubAID {
+			h2h.SideA = n.Name
+		} else {
+			h2h.SideB = n.Name
+		}
+	}
+	for _, row := range rows {
+		h2h.add(row, row.HomeClubID == clubAID)
+	}
+	h2h.finish()
+	return h2h, nil
+}
+
+// ClubRivalries returns clubID's record against each club it has played,
+// most meetings first
+func (s *HeadToHeadService) ClubRivalries(ctx context.Context, clubID uint) ([]HeadToHead, error) {
+	rows, err := s.completedFixtures(ctx,
+		`(ht.club_id = ? OR at2.club_id = ?) AND ht.club_id != at2.club_id`, clubID, clubID)
+	if err != nil {
+		return nil, err
+	}
+
+	byClub := make(map[uint]*HeadToHead)
+	var order []uint
+	for _, row := range rows {
+		aAtHome := row.HomeClubID == clubID
+		sideA, opponentID, opponent := row.AwayClubName, row.HomeClubID, row.HomeClubName
+		if aAtHome {
+			sideA, opponentID, opponent = row.HomeClubName, row.AwayClubID, row.AwayClubName
+		}
+		h2h, ok := byClub[opponentID]
+		if !ok {
+			h2h = &HeadToHead{SideA: sideA, SideB: opponent, ClubAID: clubID, ClubBID: opponentID}
+			byClub[opponentID] = h2h
+			order = append(order, opponentID)
+		}
+		h2h.add(row, aAtHome)
+	}
+
+	rivalries := make([]HeadToHead, 0, len(order))
+	for _, id := range order {
+		byClub[id].finish()
+		rivalries = append(rivalries, *byClub[id])
+	}
+	sort.SliceStable(rivalries, func(i, j int) bool {
+		if rivalries[i].Played != rivalries[j].Played {
+			return rivalries[i].Played > rivalries[j].Played
+		}
+		return rivalries[i].SideB < rivalries[j].SideB
+	})
+	return rivalries, nil
+}
+
+// add records a fixture; rows arrive newest first
+func (h *HeadToHead) add(row headToHeadRow, aAtHome bool) {
+	m := HeadToHeadMeeting{
+		FixtureID:    row.FixtureID,
+		Date:         row.ScheduledDate,
+		SeasonName:   row.SeasonName,
+		DivisionName: row.DivisionName,
+		HomeTeamName: row.HomeTeamName,
+		AwayTeamName: row.AwayTeamName,
+		AAtHome:      aAtHome,
+		RubbersA:     row.AwayRubberPoints,
+		RubbersB:     row.HomeRubberPoints,
+	}
+	if aAtHome {
+		m.RubbersA, m.RubbersB = row.HomeRubberPoints, row.AwayRubberPoints
+	}
+
+	h.Played++
+	h.RubbersA += m.RubbersA
+	h.RubbersB += m.RubbersB
+	switch {
+	case m.RubbersA > m.RubbersB:
+		m.Result = "W"
+		h.WonA++
+	case m.RubbersB > m.RubbersA:
+		m.Result = "L"
+		h.WonB++
+	default:
+		m.Result = "D"
+		h.Drawn++
+	}
+	h.Meetings = append(h.Meetings, m)
+}
+
+// finish works out biggest wins and streaks once every meeting is added.
+// Ties for biggest win go to the most recent meeting.
+func (h *HeadToHead) finish() {
+	for i := range h.Meetings {
+		m := &h.Meetings[i]
+		switch m.Result {
+		case "W":
+			if h.BiggestWinA == nil || m.Margin() > h.BiggestWinA.Margin() {
+				h.BiggestWinA = m
+			}
+		case "L":
+			if h.BiggestWinB == nil || m.Margin() > h.BiggestWinB.Margin() {
+				h.BiggestWinB = m
+			}
+		}
+	}
+
+	run := 0
+	for i, m := range h.Meetings {
+		if i > 0 && m.Result == h.Meetings[i-1].Result {
+			run++
+		} else {
+			run = 1
+		}
+		if i == run-1 {
+			h.CurrentStreak = HeadToHeadStreak{Result: m.Result, Length: run}
+		}
+		switch {
+		case m.Result == "W" && run > h.LongestStreakA:
+			h.LongestStreakA = run
+		case m.Result == "L" && run > h.LongestStreakB:
+			h.LongestStreakB = run
+		}
+	}
+	switch h.CurrentStreak.Result {
+	case "W":
+		h.CurrentStreak.Holder = h.SideA
+	case "L":
+		h.CurrentStreak.Holder = h.SideB
+	}
+}
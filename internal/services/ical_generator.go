@@ -21,6 +21,8 @@ type ICalEvent struct {
 	Longitude   *float64
 	Description string // Division, week, teams, venue details
 	URL         string // Google Maps URL or club website
+	Sequence    int    // Bumped on every date/status/venue change so calendars replace the old event
+	Cancelled   bool   // Emits STATUS:CANCELLED so calendars strike the event out
 }
 
 // GenerateICalEvent generates an .ics file content string for a fixture
@@ -36,6 +38,7 @@ func GenerateICalEvent(event ICalEvent) string {
 
 	// Stable UID so re-downloads update not duplicate
 	b.WriteString(fmt.Sprintf("UID:fixture-%d@jim.tennis\r\n", event.FixtureID))
+	b.WriteString(fmt.Sprintf("SEQUENCE:%d\r\n", event.Sequence))
 
 	// Timestamps in UTC
 	b.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICalTime(time.Now())))
@@ -60,6 +63,12 @@ func GenerateICalEvent(event ICalEvent) string {
 		b.WriteString(fmt.Sprintf("URL:%s\r\n", event.URL))
 	}
 
+	if event.Cancelled {
+		b.WriteString("STATUS:CANCELLED\r\n")
+	} else {
+		b.WriteString("STATUS:CONFIRMED\r\n")
+	}
+
 	b.WriteString("CATEGORIES:Tennis\r\n")
 	b.WriteString("END:VEVENT\r\n")
 	b.WriteString("END:VCALENDAR\r\n")
@@ -76,6 +85,12 @@ func BuildICalEventFromFixture(
 	venueClub *models.Club,
 ) ICalEvent {
 	summary := fmt.Sprintf("Tennis: %s vs %s", homeTeamName, awayTeamName)
+	switch fixture.Status {
+	case models.Postponed:
+		summary = "POSTPONED - " + summary
+	case models.Cancelled:
+		summary = "CANCELLED - " + summary
+	}
 
 	// Build location string from venue club data, prefixed with club name
 	location := buildLocationString(venueClub)
@@ -105,6 +120,7 @@ func BuildICalEventFromFixture(
 		Location:    location,
 		Description: description,
 		URL:         url,
+		Cancelled:   fixture.Status == models.Cancelled,
 	}
 
 	if venueClub != nil {
@@ -0,0 +1,50 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+func TestICalEventReflectsFixtureChanges(t *testing.T) {
+	fixture := &models.Fixture{
+		ID:            42,
+		ScheduledDate: time.Date(2026, 5, 12, 18, 30, 0, 0, time.UTC),
+		Status:        models.Scheduled,
+	}
+	club := &models.Club{Name: "St Ann's"}
+
+	cases := []struct {
+		name     string
+		status   models.FixtureStatus
+		sequence int
+		want     []string
+	}{
+		{"scheduled", models.Scheduled, 0, []string{"SEQUENCE:0", "STATUS:CONFIRMED", "SUMMARY:Tennis: Home A vs Away B"}},
+		{"rescheduled", models.Scheduled, 2, []string{"SEQUENCE:2", "STATUS:CONFIRMED"}},
+		{"postponed", models.Postponed, 1, []string{"SEQUENCE:1", "STATUS:CONFIRMED", "SUMMARY:POSTPONED - Tennis"}},
+		{"cancelled", models.Cancelled, 3, []string{"SEQUENCE:3", "STATUS:CANCELLED", "SUMMARY:CANCELLED - Tennis"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := *fixture
+			f.Status = c.status
+			event := BuildICalEventFromFixture(&f, "Home A", "Away B", "Division 1", 3, club)
+			event.Sequence = c.sequence
+
+			ics := GenerateICalEvent(event)
+			if !strings.Contains(ics, "UID:fixture-42@jim.tennis\r\n") {
+				t.Errorf("UID must stay stable across changes, got:\n%s", ics)
+			}
+			for _, want := range c.want {
+				if !strings.Contains(ics, want) {
+					t.Errorf("expected %q in:\n%s", want, ics)
+				}
+			}
+		})
+	}
+}
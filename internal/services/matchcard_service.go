@@ -29,6 +29,7 @@ type MatchCardService struct {
 	playerRepo     repository.PlayerRepository
 	divisionRepo   repository.DivisionRepository
 	seasonRepo     repository.SeasonRepository
+	uow            repository.UnitOfWork
 	parser         *MatchCardParser
 	matcher        *PlayerMatcher
 	httpClient     *http.Client
@@ -69,6 +70,7 @@ func NewMatchCardService(
 	playerRepo repository.PlayerRepository,
 	divisionRepo repository.DivisionRepository,
 	seasonRepo repository.SeasonRepository,
+	uow repository.UnitOfWork,
 	homeClubID uint,
 ) *MatchCardService {
 	return &MatchCardService{
@@ -80,6 +82,7 @@ func NewMatchCardService(
 		playerRepo:     playerRepo,
 		divisionRepo:   divisionRepo,
 		seasonRepo:     seasonRepo,
+		uow:            uow,
 		parser:         NewMatchCardParser(),
 		matcher:        NewPlayerMatcher(playerRepo),
 		nonceExtractor: NewNonceExtractor(),
@@ -246,8 +249,24 @@ func (s *MatchCardService) fetchMatchCards(config ImportConfig, week int) ([]byt
 	return body, nil
 }
 
-// processMatchCard processes a single match card
+// processMatchCard processes a single match card. The fixture, its matchups
+// and their players are written in one transaction, so a card that fails part
+// way through leaves the fixture as it was.
 func (s *MatchCardService) processMatchCard(ctx context.Context, config ImportConfig, matchCard MatchCardData) (*ImportResult, error) {
+	var result *ImportResult
+	err := s.uow.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.importMatchCard(ctx, config, matchCard)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importMatchCard does the work of processMatchCard inside its transaction
+func (s *MatchCardService) importMatchCard(ctx context.Context, config ImportConfig, matchCard MatchCardData) (*ImportResult, error) {
 	result := &ImportResult{
 		UnmatchedPlayers: []string{},
 		Errors:           []string{},
@@ -360,56 +379,32 @@ func (s *MatchCardService) processMatchCard(ctx context.Context, config ImportCo
 		fmt.Printf("Marked fixture %d as Completed (match card data is authoritative)\n", fixture.ID)
 	}
 
-	// Process matchups from the match card
+	// Process matchups from the match card. A matchup that cannot be written
+	// fails the whole card rather than leaving it half imported.
 	for _, matchupData := range matchCard.Matchups {
+		var matchupResults []*ImportResult
 		if isDerby {
 			// For derby matches, process matchups for both teams
-			// Process matchup for home team
 			homeResult, err := s.processMatchupForTeam(ctx, config, fixture, matchupData, homeTeamID, "home")
 			if err != nil {
-				errMsg := fmt.Sprintf("Error processing %s matchup for home team: %v", matchupData.Type, err)
-				result.Errors = append(result.Errors, errMsg)
-				if config.Verbose {
-					fmt.Printf("  %s\n", errMsg)
-				}
-			} else {
-				// Aggregate home team results
-				result.CreatedMatchups += homeResult.CreatedMatchups
-				result.UpdatedMatchups += homeResult.UpdatedMatchups
-				result.MatchedPlayers += homeResult.MatchedPlayers
-				result.UnmatchedPlayers = append(result.UnmatchedPlayers, homeResult.UnmatchedPlayers...)
-				result.Errors = append(result.Errors, homeResult.Errors...)
+				return nil, fmt.Errorf("failed to process %s matchup for home team: %w", matchupData.Type, err)
 			}
-
-			// Process matchup for away team
 			awayResult, err := s.processMatchupForTeam(ctx, config, fixture, matchupData, awayTeamID, "away")
 			if err != nil {
-				errMsg := fmt.Sprintf("Error processing %s matchup for away team: %v", matchupData.Type, err)
-				result.Errors = append(result.Errors, errMsg)
-				if config.Verbose {
-					fmt.Printf("  %s\n", errMsg)
-				}
-			} else {
-				// Aggregate away team results
-				result.CreatedMatchups += awayResult.CreatedMatchups
-				result.UpdatedMatchups += awayResult.UpdatedMatchups
-				result.MatchedPlayers += awayResult.MatchedPlayers
-				result.UnmatchedPlayers = append(result.UnmatchedPlayers, awayResult.UnmatchedPlayers...)
-				result.Errors = append(result.Errors, awayResult.Errors...)
+				return nil, fmt.Errorf("failed to process %s matchup for away team: %w", matchupData.Type, err)
 			}
+			matchupResults = append(matchupResults, homeResult, awayResult)
 		} else {
 			// For regular matches, process matchup normally
 			matchupResult, err := s.processMatchup(ctx, config, fixture, matchupData)
 			if err != nil {
-				errMsg := fmt.Sprintf("Error processing matchup %s: %v", matchupData.Type, err)
-				result.Errors = append(result.Errors, errMsg)
-				if config.Verbose {
-					fmt.Printf("  %s\n", errMsg)
-				}
-				continue
+				return nil, fmt.Errorf("failed to process matchup %s: %w", matchupData.Type, err)
 			}
+			matchupResults = append(matchupResults, matchupResult)
+		}
 
-			// Aggregate matchup results
+		// Aggregate matchup results
+		for _, matchupResult := range matchupResults {
 			result.CreatedMatchups += matchupResult.CreatedMatchups
 			result.UpdatedMatchups += matchupResult.UpdatedMatchups
 			result.MatchedPlayers += matchupResult.MatchedPlayers
@@ -0,0 +1,208 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+	"jim-dot-tennis/internal/models"
+	"jim-dot-tennis/internal/repository"
+	"jim-dot-tennis/internal/webpush"
+)
+
+// ErrPlayerHasNoLink is returned when rotating the link of a player who has
+// never been given one
+var ErrPlayerHasNoLink = errors.New("player has no private link to rotate")
+
+// LinkRotatedByPlayer is recorded as RetiredBy when players rotate their own link
+const LinkRotatedByPlayer = "player"
+
+// PlayerLinkRotator issues a player a new private link (a fresh fantasy
+// pairing and auth token) and retires the old one. During the grace period
+// the old link redirects to the new one; a zero grace revokes it outright.
+type PlayerLinkRotator struct {
+	db                    *database.DB
+	playerRepository      repository.PlayerRepository
+	fantasyRepository     repository.FantasyMixedDoublesRepository
+	playerTokenRepository repository.PlayerTokenRepository
+	pushService           *webpush.Service // optional
+	now                   func() time.Time
+}
+
+// NewPlayerLinkRotator creates a new player link rotator. pushService may be nil.
+func NewPlayerLinkRotator(
+	db *database.DB,
+	playerRepo repository.PlayerRepository,
+	fantasyRepo repository.FantasyMixedDoublesRepository,
+	playerTokenRepo repository.PlayerTokenRepository,
+	pushService *webpush.Service,
+) *PlayerLinkRotator {
+	return &PlayerLinkRotator{
+		db:                    db,
+		playerRepository:      playerRepo,
+		fantasyRepository:     fantasyRepo,
+		playerTokenRepository: playerTokenRepo,
+		pushService:           pushService,
+		now:                   time.Now,
+	}
+}
+
+// Rotate gives a player a new link and retires their current one for the
+// given grace period. The whole swap is one unit of work, so a failure part
+// way through leaves the player on their old link.
+//
+// With a grace period the player's push subscriptions follow them to the new
+// token, as this is routine housekeeping. A zero grace is treated as a leak:
+// every earlier link stops working immediately and devices subscribed under
+// the old token are dropped, since a leaked link's subscribers can't be told
+// apart from the player's own. keepEndpoint, when a player rotates their own
+// link, names the push endpoint of the device they did it from; that one is
+// theirs, so it moves to the new link rather than being dropped.
+func (r *PlayerLinkRotator) Rotate(ctx context.Context, playerID string, grace time.Duration, reason, retiredBy, keepEndpoint string) (*models.FantasyMixedDoubles, error) {
+	var newMatch *models.FantasyMixedDoubles
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		player, err := r.playerRepository.FindByID(ctx, playerID)
+		if err != nil {
+			return err
+		}
+		if player.FantasyMatchID == nil {
+			return ErrPlayerHasNoLink
+		}
+		oldMatch, err := r.fantasyRepository.FindByID(ctx, *player.FantasyMatchID)
+		if err != nil {
+			return err
+		}
+
+		newMatch, err = r.fantasyRepository.CreateRandomMatch(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create new link: %w", err)
+		}
+
+		player.FantasyMatchID = &newMatch.ID
+		if err := r.playerRepository.Update(ctx, player); err != nil {
+			return fmt.Errorf("failed to assign new link: %w", err)
+		}
+
+		// Deactivate the old pairing so it is never handed out to anyone else
+		oldMatch.IsActive = false
+		if err := r.fantasyRepository.Update(ctx, oldMatch); err != nil {
+			return fmt.Errorf("failed to deactivate old link: %w", err)
+		}
+
+		now := r.now()
+		if grace <= 0 {
+			earlier, err := r.playerTokenRepository.FindRetiredByPlayer(ctx, playerID)
+			if err != nil {
+				return err
+			}
+			for _, t := range earlier {
+				if t.InGrace(now) {
+					if err := r.playerTokenRepository.EndGrace(ctx, t.ID, now); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		retired := &models.RetiredPlayerToken{
+			PlayerID:        playerID,
+			Token:           oldMatch.AuthToken,
+			ReplacedByToken: newMatch.AuthToken,
+			Reason:          reason,
+			RetiredBy:       retiredBy,
+			GraceUntil:      now.Add(grace),
+		}
+		if err := r.playerTokenRepository.Retire(ctx, retired); err != nil {
+			return fmt.Errorf("failed to record retired link: %w", err)
+		}
+
+		if r.pushService == nil {
+			return nil
+		}
+		if grace <= 0 {
+			if _, err := r.pushService.DeleteSubscriptionsByPlayerToken(ctx, oldMatch.AuthToken, keepEndpoint); err != nil {
+				return fmt.Errorf("failed to drop push subscriptions for revoked link: %w", err)
+			}
+		}
+		if _, err := r.pushService.ReassignPlayerToken(ctx, oldMatch.AuthToken, newMatch.AuthToken); err != nil {
+			return fmt.Errorf("failed to move push subscriptions to new link: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newMatch, nil
+}
+
+// Revoke ends the grace period of a retired link so it stops redirecting
+func (r *PlayerLinkRotator) Revoke(ctx context.Context, retiredID uint) error {
+	return r.playerTokenRepository.EndGrace(ctx, retiredID, r.now())
+}
+
+// DescribeDevice turns a User-Agent into a short label such as
+// "Safari on iPhone" for the link access log. Link preview bots are named
+// explicitly, as they are the clearest sign a link has been pasted into a chat.
+func DescribeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	for _, bot := range []struct{ match, label string }{
+		{"whatsapp", "WhatsApp link preview"},
+		{"telegrambot", "Telegram link preview"},
+		{"facebookexternalhit", "Facebook/Messenger link preview"},
+		{"slackbot", "Slack link preview"},
+		{"discordbot", "Discord link preview"},
+		{"twitterbot", "X/Twitter link preview"},
+		{"signal", "Signal link preview"},
+	} {
+		if strings.Contains(ua, bot.match) {
+			return bot.label
+		}
+	}
+	if strings.Contains(ua, "bot") || strings.Contains(ua, "crawler") || strings.Contains(ua, "spider") {
+		return "Bot or crawler"
+	}
+
+	browser := "Browser"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "samsungbrowser"):
+		browser = "Samsung Internet"
+	case strings.Contains(ua, "firefox") || strings.Contains(ua, "fxios"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome") || strings.Contains(ua, "crios"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari"):
+		browser = "Safari"
+	}
+
+	device := ""
+	switch {
+	case strings.Contains(ua, "iphone"):
+		device = "iPhone"
+	case strings.Contains(ua, "ipad"):
+		device = "iPad"
+	case strings.Contains(ua, "android"):
+		device = "Android"
+	case strings.Contains(ua, "windows"):
+		device = "Windows"
+	case strings.Contains(ua, "mac os") || strings.Contains(ua, "macintosh"):
+		device = "Mac"
+	case strings.Contains(ua, "linux"):
+		device = "Linux"
+	}
+
+	if device == "" {
+		return browser
+	}
+	return browser + " on " + device
+}
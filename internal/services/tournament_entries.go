@@ -0,0 +1,70 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package services
+
+import (
+	"time"
+
+	"jim-dot-tennis/internal/models"
+)
+
+// EntryError is a reason a player cannot enter a tournament event. Its
+// message is written for the player and safe to show them.
+type EntryError struct {
+	Reason string
+}
+
+func (e *EntryError) Error() string {
+	return e.Reason
+}
+
+// CheckEntryEligibility checks that player, with partner for doubles, may
+// enter event at now: the deadline has not passed, singles and doubles have
+// the right number of players, and the players suit the event's gender.
+func CheckEntryEligibility(event *models.TournamentEvent, player, partner *models.Player, now time.Time) error {
+	if !now.Before(event.EntryDeadline) {
+		return &EntryError{Reason: "Entries for " + event.Name + " have closed."}
+	}
+
+	switch event.EventType {
+	case models.TournamentSingles:
+		if partner != nil {
+			return &EntryError{Reason: event.Name + " is a singles event, so there is no partner to enter."}
+		}
+	case models.TournamentDoubles:
+		if partner == nil {
+			return &EntryError{Reason: "Choose a partner to enter " + event.Name + "."}
+		}
+		if partner.ID == player.ID {
+			return &EntryError{Reason: "You can't partner yourself."}
+		}
+		if !partner.IsActive {
+			return &EntryError{Reason: "Your partner is no longer an active member."}
+		}
+	}
+
+	players := []*models.Player{player}
+	if partner != nil {
+		players = append(players, partner)
+	}
+	switch event.Gender {
+	case models.TournamentEventMen, models.TournamentEventWomen:
+		for _, p := range players {
+			if string(p.Gender) != string(event.Gender) {
+				return &EntryError{Reason: event.Name + " is a " + string(event.Gender) + "'s event."}
+			}
+		}
+	case models.TournamentEventMixed:
+		if partner == nil || !isMixedPair(player.Gender, partner.Gender) {
+			return &EntryError{Reason: event.Name + " is for pairs of one man and one woman."}
+		}
+	}
+	return nil
+}
+
+// isMixedPair reports whether two players make a mixed doubles pair. A
+// player whose gender is Unknown cannot be checked, so does not qualify.
+func isMixedPair(a, b models.PlayerGender) bool {
+	return (a == models.PlayerGenderMen && b == models.PlayerGenderWomen) ||
+		(a == models.PlayerGenderWomen && b == models.PlayerGenderMen)
+}
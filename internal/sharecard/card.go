@@ -0,0 +1,224 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+// Package sharecard renders results, Wrapped highlights and team sheets as
+// fixed-size images for posting on social media and for link previews.
+// A Card describes what to say; the package lays it out once and draws the
+// same layout as either SVG or PNG.
+package sharecard
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// Cards are the size OpenGraph and Twitter previews expect
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// Tone colours the card's accent bar: a win, draw or loss for our side, or
+// neutral when the card isn't about one result
+type Tone int
+
+const (
+	ToneNeutral Tone = iota
+	ToneWin
+	ToneDraw
+	ToneLoss
+)
+
+// Card is one shareable image
+type Card struct {
+	Eyebrow  string // small line above the title, e.g. "Division 2 · Week 5"
+	Title    string
+	Subtitle string
+	Score    *Score // a fixture's scoreline, shown large
+	Stats    []Stat // headline numbers, at most four are shown
+	Rows     []Row  // lines of detail, trimmed to what fits
+	Footer   string
+	Tone     Tone
+}
+
+// Score is a fixture's scoreline between two sides
+type Score struct {
+	Home      string
+	Away      string
+	HomeScore int
+	AwayScore int
+}
+
+// Stat is a headline number with its label
+type Stat struct {
+	Value string
+	Label string
+}
+
+// Row is a line of detail: a label on the left, the main text in the middle
+// and a score or count on the right
+type Row struct {
+	Left      string
+	Middle    string
+	Right     string
+	Highlight bool // e.g. our own fixtures in a roundup
+}
+
+// Initials renders a name as initials ("Ann Able" → "A.A.") from its first
+// and last words. Cards are public once shared, so they never carry
+// players' full names.
+func Initials(name string) string {
+	words := strings.Fields(name)
+	var out string
+	for i, w := range words {
+		if i != 0 && i != len(words)-1 {
+			continue
+		}
+		for _, r := range w {
+			out += string(r) + "."
+			break
+		}
+	}
+	return out
+}
+
+// Pair joins two players' initials for a doubles line
+func Pair(names ...string) string {
+	var parts []string
+	for _, n := range names {
+		if i := Initials(n); i != "" {
+			parts = append(parts, i)
+		}
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Palette
+var (
+	colourBackground = color.RGBA{0x2c, 0x3e, 0x50, 0xff}
+	colourPanel      = color.RGBA{0x34, 0x49, 0x5e, 0xff}
+	colourText       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colourSoft       = color.RGBA{0xec, 0xf0, 0xf1, 0xff}
+	colourMuted      = color.RGBA{0x95, 0xa5, 0xa6, 0xff}
+	toneColours      = map[Tone]color.RGBA{
+		ToneNeutral: {0x34, 0x98, 0xdb, 0xff},
+		ToneWin:     {0x27, 0xae, 0x60, 0xff},
+		ToneDraw:    {0xf3, 0x9c, 0x12, 0xff},
+		ToneLoss:    {0xe7, 0x4c, 0x3c, 0xff},
+	}
+)
+
+// Layout grid
+const (
+	margin    = 64.0
+	rowHeight = 44.0
+	rowsLimit = 566.0 // rows stop above the footer
+	brand     = "jim.tennis"
+)
+
+type anchor int
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// element is one drawing instruction: a filled rectangle when text is
+// empty, otherwise a line of text with its baseline at y
+type element struct {
+	x, y, w, h float64
+	radius     float64
+	text       string
+	size       float64
+	bold       bool
+	anchor     anchor
+	colour     color.RGBA
+}
+
+// measurer reports the rendered width of text, so long names can be cut to fit
+type measurer interface {
+	width(text string, size float64, bold bool) float64
+}
+
+// layout places the card's content on the canvas
+func layout(card Card, m measurer) []element {
+	accent := toneColours[card.Tone]
+	els := []element{
+		{w: Width, h: Height, colour: colourBackground},
+		{w: 16, h: Height, colour: accent},
+	}
+	text := func(x, y, size float64, bold bool, a anchor, c color.RGBA, s string, maxWidth float64) {
+		if s = fit(m, s, size, bold, maxWidth); s != "" {
+			els = append(els, element{x: x, y: y, text: s, size: size, bold: bold, anchor: a, colour: c})
+		}
+	}
+	contentWidth := Width - 2*margin
+
+	text(margin, 96, 26, true, anchorStart, accent, strings.ToUpper(card.Eyebrow), contentWidth)
+	text(margin, 158, 52, true, anchorStart, colourText, card.Title, contentWidth)
+	text(margin, 204, 28, false, anchorStart, colourSoft, card.Subtitle, contentWidth)
+
+	y := 250.0
+	if s := card.Score; s != nil {
+		// Short enough to leave room for a fixture's four rubbers below
+		els = append(els, element{x: margin, y: y, w: contentWidth, h: 110, radius: 14, colour: colourPanel})
+		text(Width/2-110, y+68, 34, true, anchorEnd, colourText, s.Home, Width/2-110-margin-24)
+		text(Width/2, y+82, 80, true, anchorMiddle, colourText, fmt.Sprintf("%d–%d", s.HomeScore, s.AwayScore), 200)
+		text(Width/2+110, y+68, 34, true, anchorStart, colourText, s.Away, Width/2-110-margin-24)
+		y += 140
+	}
+
+	if stats := card.Stats; len(stats) > 0 {
+		if len(stats) > 4 {
+			stats = stats[:4]
+		}
+		const gap = 24.0
+		tile := (contentWidth - gap*float64(len(stats)-1)) / float64(len(stats))
+		for i, st := range stats {
+			x := margin + float64(i)*(tile+gap)
+			els = append(els, element{x: x, y: y, w: tile, h: 150, radius: 14, colour: colourPanel})
+			text(x+tile/2, y+86, 64, true, anchorMiddle, colourText, st.Value, tile-24)
+			text(x+tile/2, y+126, 22, false, anchorMiddle, colourSoft, st.Label, tile-24)
+		}
+		y += 190
+	}
+
+	rows := card.Rows
+	if fits := int((rowsLimit - y) / rowHeight); len(rows) > fits && fits > 0 {
+		more := len(rows) - fits + 1
+		rows = append(rows[:fits-1:fits-1], Row{Middle: fmt.Sprintf("+ %d more", more)})
+	} else if fits <= 0 {
+		rows = nil
+	}
+	for _, row := range rows {
+		if row.Highlight {
+			els = append(els, element{x: margin - 12, y: y, w: contentWidth + 24, h: rowHeight - 6, radius: 8, colour: colourPanel})
+		}
+		baseline := y + 28
+		text(margin, baseline, 24, true, anchorStart, colourMuted, row.Left, 250)
+		text(margin+270, baseline, 26, row.Highlight, anchorStart, colourText, row.Middle, contentWidth-270-250)
+		text(Width-margin, baseline, 26, true, anchorEnd, colourText, row.Right, 230)
+		y += rowHeight
+	}
+
+	text(margin, 606, 22, false, anchorStart, colourMuted, card.Footer, contentWidth-200)
+	text(Width-margin, 606, 22, true, anchorEnd, colourMuted, brand, 200)
+	return els
+}
+
+// fit shortens text with an ellipsis until it is no wider than maxWidth
+func fit(m measurer, text string, size float64, bold bool, maxWidth float64) string {
+	if m.width(text, size, bold) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		shortened := strings.TrimRight(string(runes), " ") + "…"
+		if m.width(shortened, size, bold) <= maxWidth {
+			return shortened
+		}
+	}
+	return ""
+}
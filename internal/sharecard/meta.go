@@ -0,0 +1,27 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package sharecard
+
+import (
+	"os"
+	"strings"
+)
+
+// Meta is what a page tells link unfurlers through its OpenGraph tags.
+// URL and Image should be absolute, see AbsoluteURL.
+type Meta struct {
+	Title       string
+	Description string
+	URL         string
+	Image       string
+}
+
+// AbsoluteURL turns a site path into a full URL on APP_BASE_URL. Without
+// it the path is returned as is: the request's Host header is chosen by the
+// client, so building on it would let anyone poison a cached link preview.
+func AbsoluteURL(path string) string {
+	if base := os.Getenv("APP_BASE_URL"); base != "" {
+		return strings.TrimRight(base, "/") + path
+	}
+	return path
+}
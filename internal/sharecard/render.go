@@ -0,0 +1,204 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package sharecard
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Format is an image format a card can be rendered in
+type Format string
+
+const (
+	PNG Format = "png"
+	SVG Format = "svg"
+)
+
+// ParseFormat reads a format from a file extension such as ".png" or "svg"
+func ParseFormat(ext string) (Format, bool) {
+	switch ext {
+	case "png", ".png":
+		return PNG, true
+	case "svg", ".svg":
+		return SVG, true
+	}
+	return "", false
+}
+
+// ContentType is the MIME type to serve the format with
+func (f Format) ContentType() string {
+	if f == SVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Render draws the card in the given format
+func Render(w io.Writer, card Card, format Format) error {
+	faces, err := newFaceSet()
+	if err != nil {
+		return err
+	}
+	els := layout(card, faces)
+	if format == SVG {
+		return writeSVG(w, els)
+	}
+	return writePNG(w, els, faces)
+}
+
+// Serve writes the card as an HTTP response. Cards are rebuilt from live
+// data, so caches may only hold them briefly.
+func Serve(w http.ResponseWriter, card Card, format Format) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err := Render(w, card, format); err != nil {
+		http.Error(w, "Failed to render image", http.StatusInternalServerError)
+	}
+}
+
+// The Go fonts ship with x/image, so rendering needs nothing installed on
+// the host. SVG names them first and falls back to the viewer's sans-serif.
+const svgFontFamily = "Go, 'Helvetica Neue', Arial, sans-serif"
+
+var (
+	fontsOnce             sync.Once
+	regularFont, boldFont *opentype.Font
+	fontsErr              error
+)
+
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		if regularFont, fontsErr = opentype.Parse(goregular.TTF); fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+type faceKey struct {
+	size float64
+	bold bool
+}
+
+// faceSet holds the font faces for one render. Faces aren't safe for
+// concurrent use, so each render makes its own.
+type faceSet struct {
+	faces map[faceKey]font.Face
+}
+
+func newFaceSet() (*faceSet, error) {
+	if err := loadFonts(); err != nil {
+		return nil, fmt.Errorf("failed to load card fonts: %w", err)
+	}
+	return &faceSet{faces: make(map[faceKey]font.Face)}, nil
+}
+
+func (fs *faceSet) face(size float64, bold bool) font.Face {
+	key := faceKey{size, bold}
+	if f, ok := fs.faces[key]; ok {
+		return f
+	}
+	src := regularFont
+	if bold {
+		src = boldFont
+	}
+	f, err := opentype.NewFace(src, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		// Only fails for a bad size, which the layout never asks for
+		panic(err)
+	}
+	fs.faces[key] = f
+	return f
+}
+
+func (fs *faceSet) width(text string, size float64, bold bool) float64 {
+	return float64(font.MeasureString(fs.face(size, bold), text)) / 64
+}
+
+func writePNG(w io.Writer, els []element, faces *faceSet) error {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	for _, el := range els {
+		if el.text == "" {
+			fillRoundedRect(img, el)
+			continue
+		}
+		x := el.x
+		switch el.anchor {
+		case anchorMiddle:
+			x -= faces.width(el.text, el.size, el.bold) / 2
+		case anchorEnd:
+			x -= faces.width(el.text, el.size, el.bold)
+		}
+		d := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(el.colour),
+			Face: faces.face(el.size, el.bold),
+			Dot:  fixed.P(int(math.Round(x)), int(math.Round(el.y))),
+		}
+		d.DrawString(el.text)
+	}
+	return png.Encode(w, img)
+}
+
+// fillRoundedRect paints a rectangle, leaving its corners outside radius
+func fillRoundedRect(img *image.RGBA, el element) {
+	r := image.Rect(int(el.x), int(el.y), int(el.x+el.w), int(el.y+el.h))
+	src := image.NewUniform(el.colour)
+	if el.radius <= 0 {
+		draw.Draw(img, r, src, image.Point{}, draw.Src)
+		return
+	}
+	rad := el.radius
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			cx, cy := float64(px)+0.5, float64(py)+0.5
+			dx := math.Max(math.Max(el.x+rad-cx, cx-(el.x+el.w-rad)), 0)
+			dy := math.Max(math.Max(el.y+rad-cy, cy-(el.y+el.h-rad)), 0)
+			if dx*dx+dy*dy <= rad*rad {
+				img.SetRGBA(px, py, el.colour)
+			}
+		}
+	}
+}
+
+func writeSVG(w io.Writer, els []element) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", Width, Height, Width, Height)
+	for _, el := range els {
+		if el.text == "" {
+			fmt.Fprintf(bw, `<rect x="%g" y="%g" width="%g" height="%g" rx="%g" fill="%s"/>`+"\n",
+				el.x, el.y, el.w, el.h, el.radius, hexColour(el.colour))
+			continue
+		}
+		weight := "normal"
+		if el.bold {
+			weight = "bold"
+		}
+		textAnchor := map[anchor]string{anchorStart: "start", anchorMiddle: "middle", anchorEnd: "end"}[el.anchor]
+		fmt.Fprintf(bw, `<text x="%g" y="%g" font-family="%s" font-size="%g" font-weight="%s" text-anchor="%s" fill="%s">%s</text>`+"\n",
+			el.x, el.y, svgFontFamily, el.size, weight, textAnchor, hexColour(el.colour), html.EscapeString(el.text))
+	}
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+func hexColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package sharecard
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestCardsRenderAsPNGAndSVG(t *testing.T) {
+	card := Card{
+		Eyebrow: "Division 1 · Week 5",
+		Title:   "St Ann's A v Hove A",
+		Score:   &Score{Home: "St Ann's A", Away: "Hove A", HomeScore: 5, AwayScore: 3},
+		Rows: []Row{
+			{Left: "Mens", Middle: Pair("Ann Able", "Bob de Baker") + " v " + Pair("Hal Hove", "Hetty Hove"), Right: "6-4 6-3", Highlight: true},
+		},
+		Footer: "Parks League",
+		Tone:   ToneWin,
+	}
+
+	var out bytes.Buffer
+	if err := Render(&out, card, PNG); err != nil {
+		t.Fatalf("png: %v", err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("png is %dx%d; want %dx%d", b.Dx(), b.Dy(), Width, Height)
+	}
+	if r, g, b, _ := img.At(4, 300).RGBA(); r>>8 != 0x27 || g>>8 != 0xae || b>>8 != 0x60 {
+		t.Errorf("accent bar isn't the win colour")
+	}
+
+	out.Reset()
+	if err := Render(&out, card, SVG); err != nil {
+		t.Fatalf("svg: %v", err)
+	}
+	svg := out.String()
+	for _, want := range []string{`<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630"`,
+		"St Ann&#39;s A v Hove A", "5–3", "A.A. &amp; B.B. v H.H. &amp; H.H.", "DIVISION 1 · WEEK 5"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg is missing %s", want)
+		}
+	}
+}
+
+func TestCardLayoutTrimsWhatDoesNotFit(t *testing.T) {
+	faces, err := newFaceSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []Row
+	for i := 0; i < 20; i++ {
+		rows = append(rows, Row{Left: fmt.Sprintf("Row %d", i), Middle: "x"})
+	}
+	long := strings.Repeat("Brighton and Hove ", 20)
+	els := layout(Card{Title: long, Rows: rows}, faces)
+
+	var texts []string
+	for _, el := range els {
+		if el.text != "" {
+			texts = append(texts, el.text)
+			if el.anchor == anchorStart && el.x+faces.width(el.text, el.size, el.bold) > Width {
+				t.Errorf("%q runs off the card", el.text)
+			}
+		}
+	}
+	if !strings.HasSuffix(texts[0], "…") {
+		t.Errorf("long title = %q; want it cut short", texts[0])
+	}
+	// Rows start at 250 and stop above the footer: seven fit, the last
+	// giving way to a count of the rest
+	if !containsString(texts, "Row 5") || containsString(texts, "Row 6") || !containsString(texts, "+ 14 more") {
+		t.Errorf("rows = %v; want six rows and + 14 more", texts)
+	}
+}
+
+func TestInitials(t *testing.T) {
+	for name, want := range map[string]string{"Ann Able": "A.A.", "Bob de Baker": "B.B.", "Cher": "C.", "": ""} {
+		if got := Initials(name); got != want {
+			t.Errorf("Initials(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+// Package templates parses the HTML templates once at startup and serves the
+// parsed copies to handlers. Each area of the app (admin, players, auth and
+// the public pages) registers a Set describing which files it owns and how
+// they are parsed, since each uses its own template functions.
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ParseFunc parses one template, named by its path relative to the
+// templates directory
+type ParseFunc func(dir, name string) (*template.Template, error)
+
+// Set is a group of templates parsed the same way, such as the admin pages
+type Set struct {
+	name     string
+	patterns []string
+	parse    ParseFunc
+
+	mu    sync.RWMutex
+	cache map[cacheKey]*template.Template
+}
+
+// cacheKey identifies a parsed template. The directory is part of the key
+// because tests point handlers at the templates from their own package.
+type cacheKey struct {
+	dir  string
+	name string
+}
+
+var (
+	setsMu sync.Mutex
+	sets   []*Set
+)
+
+// NewSet registers a set of templates. Patterns are globs relative to the
+// templates directory naming the files the set owns; they are what LoadAll
+// parses up front.
+func NewSet(name string, parse ParseFunc, patterns ...string) *Set {
+	s := &Set{
+		name:     name,
+		patterns: patterns,
+		parse:    parse,
+		cache:    make(map[cacheKey]*template.Template),
+	}
+	setsMu.Lock()
+	sets = append(sets, s)
+	setsMu.Unlock()
+	return s
+}
+
+// Get returns a parsed template. Templates are normally parsed by LoadAll at
+// startup; one that is not cached yet is parsed now and cached if it parses.
+func (s *Set) Get(dir, name string) (*template.Template, error) {
+	key := cacheKey{dir: dir, name: name}
+	s.mu.RLock()
+	tmpl, ok := s.cache[key]
+	s.mu.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := s.parse(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cache[key] = tmpl
+	s.mu.Unlock()
+	return tmpl, nil
+}
+
+// Load parses every template the set owns in dir, replacing any cached
+// copies. The cache is only replaced if every template parses.
+func (s *Set) Load(dir string) (int, error) {
+	names, err := s.names(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed := make(map[cacheKey]*template.Template, len(names))
+	var errs []error
+	for _, name := range names {
+		tmpl, err := s.parse(dir, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		parsed[cacheKey{dir: dir, name: name}] = tmpl
+	}
+	if len(errs) > 0 {
+		return 0, fmt.Errorf("%s templates: %w", s.name, errors.Join(errs...))
+	}
+
+	s.mu.Lock()
+	s.dropLocked(dir)
+	for key, tmpl := range parsed {
+		s.cache[key] = tmpl
+	}
+	s.mu.Unlock()
+	return len(parsed), nil
+}
+
+// forget drops every cached template from dir, so the next Get re-parses
+func (s *Set) forget(dir string) {
+	s.mu.Lock()
+	s.dropLocked(dir)
+	s.mu.Unlock()
+}
+
+// dropLocked removes dir's templates from the cache; s.mu must be held
+func (s *Set) dropLocked(dir string) {
+	for key := range s.cache {
+		if key.dir == dir {
+			delete(s.cache, key)
+		}
+	}
+}
+
+// names lists the templates the set owns in dir, as slash-separated paths
+// relative to dir
+func (s *Set) names(dir string) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, pattern := range s.patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("%s templates: bad pattern %q: %w", s.name, pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s templates: nothing matches %q in %s", s.name, pattern, dir)
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, err
+			}
+			name := filepath.ToSlash(rel)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadAll parses every registered set's templates in dir, returning all the
+// errors found rather than stopping at the first
+func LoadAll(dir string) (int, error) {
+	setsMu.Lock()
+	registered := append([]*Set(nil), sets...)
+	setsMu.Unlock()
+
+	total := 0
+	var errs []error
+	for _, s := range registered {
+		n, err := s.Load(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total += n
+	}
+	return total, errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A set parses each template once, refuses to replace good templates with a
+// broken set, and picks up edits while being watched.
+func TestSetCachesAndReloads(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func(s *Set, name string) string {
+		t.Helper()
+		tmpl, err := s.Get(dir, name)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", name, err)
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, nil); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	write("fixtures/draw.html", "Sinner v Alcaraz")
+	write("fixtures/order.html", "Court 1")
+	var parses atomic.Int32
+	s := NewSet("fixtures", func(dir, name string) (*template.Template, error) {
+		parses.Add(1)
+		return template.ParseFiles(filepath.Join(dir, name))
+	}, "fixtures/*.html")
+
+	n, err := s.Load(dir)
+	if err != nil || n != 2 {
+		t.Fatalf("Load = %d, %v; want 2 templates", n, err)
+	}
+	for i := 0; i < 3; i++ {
+		render(s, "fixtures/draw.html")
+	}
+	if got := parses.Load(); got != 2 {
+		t.Errorf("parsed %d times, want 2 (once per template at load)", got)
+	}
+
+	// A template that no longer parses fails the load and leaves the cached
+	// copies in place
+	write("fixtures/order.html", "{{if}}")
+	if _, err := s.Load(dir); err == nil || !strings.Contains(err.Error(), "fixtures/order.html") {
+		t.Fatalf("Load with a broken template: %v", err)
+	}
+	if got := render(s, "fixtures/order.html"); got != "Court 1" {
+		t.Errorf("cached template replaced by a failed load: %q", got)
+	}
+	write("fixtures/order.html", "Court 1")
+
+	if _, err := s.Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Load of a directory with no templates succeeded")
+	}
+
+	// While watched, an edit is served without a restart
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := Watch(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	write("fixtures/draw.html", "Sinner v Alcaraz, final set tie-break")
+	deadline := time.Now().Add(5 * time.Second)
+	for render(s, "fixtures/draw.html") != "Sinner v Alcaraz, final set tie-break" {
+		if time.Now().After(deadline) {
+			t.Fatal("edited template was not reloaded")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
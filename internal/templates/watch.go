@@ -0,0 +1,106 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadSettle is how long the watcher waits after a change before
+// re-parsing, since editors often write a file in several steps
+const reloadSettle = 150 * time.Millisecond
+
+// ReloadEnabled reports whether DEV_TEMPLATES_RELOAD asks for templates to be
+// re-parsed when they change on disk
+func ReloadEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("DEV_TEMPLATES_RELOAD"))
+	return enabled
+}
+
+// Watch re-parses the templates whenever a file under dir changes, until ctx
+// is done. A template that no longer parses is dropped from the cache, so the
+// page using it reports the error instead of serving the old version.
+func Watch(ctx context.Context, dir string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watchTree(watcher, dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		settle := time.NewTimer(reloadSettle)
+		settle.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Directories created later, such as a new partials folder, are
+				// not watched until added
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						if err := watchTree(watcher, event.Name); err != nil {
+							slog.Warn("failed to watch new template directory", "dir", event.Name, "error", err)
+						}
+					}
+				}
+				settle.Reset(reloadSettle)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("template watcher error", "error", err)
+			case <-settle.C:
+				reload(dir)
+			}
+		}
+	}()
+	return nil
+}
+
+// watchTree adds dir and every directory below it to the watcher
+func watchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// reload re-parses every set after a change on disk
+func reload(dir string) {
+	setsMu.Lock()
+	registered := append([]*Set(nil), sets...)
+	setsMu.Unlock()
+
+	total := 0
+	for _, s := range registered {
+		n, err := s.Load(dir)
+		if err != nil {
+			s.forget(dir)
+			slog.Error("template reload failed", "set", s.name, "error", err)
+			continue
+		}
+		total += n
+	}
+	slog.Info("templates reloaded", "templates", total)
+}
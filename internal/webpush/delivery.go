@@ -0,0 +1,246 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package webpush
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"jim-dot-tennis/internal/observability"
+)
+
+// DeliveryOutcome is the result of a single attempt to deliver a push message
+type DeliveryOutcome string
+
+const (
+	DeliveryDelivered DeliveryOutcome = "Delivered" // Push service accepted the message
+	DeliveryRetrying  DeliveryOutcome = "Retrying"  // Transient failure; another attempt follows
+	DeliveryFailed    DeliveryOutcome = "Failed"    // Gave up: permanent error or retries exhausted
+	DeliveryGone      DeliveryOutcome = "Gone"      // Subscription no longer exists and was removed
+)
+
+// maxSendAttempts bounds how many times one message is tried against one
+// subscription before giving up
+const maxSendAttempts = 3
+
+// defaultRetryBackoff is the wait before the first retry; it doubles each time
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// Delivery is one recorded attempt to deliver a push message
+type Delivery struct {
+	ID               int64           `db:"id" json:"id"`
+	PlayerToken      *string         `db:"player_token" json:"playerToken,omitempty"`
+	Endpoint         string          `db:"endpoint" json:"endpoint"`
+	NotificationType string          `db:"notification_type" json:"notificationType"`
+	Attempt          int             `db:"attempt" json:"attempt"`
+	Outcome          DeliveryOutcome `db:"outcome" json:"outcome"`
+	StatusCode       *int            `db:"status_code" json:"statusCode,omitempty"`
+	LatencyMs        int64           `db:"latency_ms" json:"latencyMs"`
+	Error            *string         `db:"error" json:"error,omitempty"`
+	CreatedAt        time.Time       `db:"created_at" json:"createdAt"`
+}
+
+// deliveryColumns is the explicit column list for SELECTs into Delivery
+const deliveryColumns = "id, player_token, endpoint, notification_type, attempt, outcome, status_code, latency_ms, error, created_at"
+
+// Reachability summarises recent push deliveries for one player token
+type Reachability struct {
+	PlayerToken            string
+	Subscriptions          int
+	Attempts               int
+	Delivered              int
+	Failed                 int // Attempts that gave up (Failed or Gone); retries are not counted
+	LastError              string
+	LastAttemptAt          *time.Time
+	LastDeliveredAt        *time.Time
+	LastSelectionDelivered *time.Time // Last successful "You've been selected" notice
+}
+
+// errSubscriptionGone marks a delivery the push service rejected because the
+// subscription no longer exists or is no longer ours to use
+var errSubscriptionGone = errors.New("push subscription gone")
+
+// errDeliveryRetrying marks a delivery whose first attempt failed
+// transiently; the remaining attempts run in the background
+var errDeliveryRetrying = errors.New("push delivery queued for retry")
+
+// deliver makes the first attempt to send payload to one subscription. A
+// transient failure is handed to a background retry with exponential
+// backoff, so a slow push service never holds up the request that sent it.
+// Every attempt is recorded in push_deliveries, and a subscription the push
+// service reports as gone is deleted at once.
+func (s *Service) deliver(sub Subscription, payload []byte, notificationType string) error {
+	outcome, err := s.attemptDelivery(sub, payload, notificationType, 1)
+	if outcome != DeliveryRetrying {
+		return err
+	}
+	s.goBackground(func() {
+		s.retryDelivery(sub, payload, notificationType)
+	})
+	return fmt.Errorf("%w: %v", errDeliveryRetrying, err)
+}
+
+// retryDelivery makes the remaining attempts after a transient failure,
+// waiting longer before each one
+func (s *Service) retryDelivery(sub Subscription, payload []byte, notificationType string) {
+	backoff := s.retryBackoff
+	for attempt := 2; attempt <= maxSendAttempts; attempt++ {
+		if backoff > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		outcome, err := s.attemptDelivery(sub, payload, notificationType, attempt)
+		if outcome == DeliveryRetrying {
+			continue
+		}
+		if err != nil {
+			log.Printf("Gave up delivering %s notification to %s after %d attempts: %v", notificationType, sub.Endpoint, attempt, err)
+		}
+		return
+	}
+}
+
+// attemptDelivery makes and records one attempt, removing the subscription
+// when the push service says it's gone
+func (s *Service) attemptDelivery(sub Subscription, payload []byte, notificationType string, attempt int) (DeliveryOutcome, error) {
+	start := time.Now()
+	statusCode, err := s.sendOnce(sub, payload)
+	latency := time.Since(start)
+
+	outcome := DeliveryDelivered
+	switch {
+	case err == nil:
+	case isGonePushFailure(statusCode):
+		outcome = DeliveryGone
+	case isTransientPushFailure(statusCode) && attempt < maxSendAttempts:
+		outcome = DeliveryRetrying
+	default:
+		outcome = DeliveryFailed
+	}
+	s.recordDelivery(sub, notificationType, attempt, outcome, statusCode, latency, err)
+
+	if outcome == DeliveryGone {
+		log.Printf("Removing dead subscription (status %d): %s", statusCode, sub.Endpoint)
+		if delErr := s.DeleteSubscription(sub.Endpoint); delErr != nil {
+			log.Printf("Failed to remove dead subscription %s: %v", sub.Endpoint, delErr)
+		}
+		return outcome, fmt.Errorf("%w: %v", errSubscriptionGone, err)
+	}
+	return outcome, err
+}
+
+// isGonePushFailure reports whether the push service has disowned the
+// subscription: it no longer exists (404/410), or it was created under keys
+// we no longer hold (401), so no retry will ever reach it
+func isGonePushFailure(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusGone || statusCode == http.StatusUnauthorized
+}
+
+// isTransientPushFailure reports whether a failed send is worth retrying.
+// statusCode is 0 when the request never got a response (network error).
+func isTransientPushFailure(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// recordDelivery stores one delivery attempt. Failures to record are logged
+// but never stop the send.
+func (s *Service) recordDelivery(sub Subscription, notificationType string, attempt int, outcome DeliveryOutcome, statusCode int, latency time.Duration, sendErr error) {
+	observability.RecordPushSend(notificationType, string(outcome))
+
+	var code sql.NullInt64
+	if statusCode != 0 {
+		code = sql.NullInt64{Int64: int64(statusCode), Valid: true}
+	}
+	var errText sql.NullString
+	if sendErr != nil {
+		errText = sql.NullString{String: sendErr.Error(), Valid: true}
+	}
+
+	_, err := s.db.Exec(
+		`INSERT INTO push_deliveries (player_token, endpoint, notification_type, attempt, outcome, status_code, latency_ms, error)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		sub.PlayerToken, sub.Endpoint, notificationType, attempt, outcome, code, latency.Milliseconds(), errText,
+	)
+	if err != nil {
+		log.Printf("Failed to record push delivery to %s: %v", sub.Endpoint, err)
+	}
+}
+
+// CleanupOldDeliveries removes delivery records older than the given duration
+func (s *Service) CleanupOldDeliveries(maxAge time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-maxAge).UTC()
+	result, err := s.db.Exec("DELETE FROM push_deliveries WHERE created_at < $1", cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetDeliveriesByPlayerToken returns a player's most recent delivery attempts, newest first
+func (s *Service) GetDeliveriesByPlayerToken(playerToken string, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+	err := s.db.Select(&deliveries,
+		"SELECT "+deliveryColumns+" FROM push_deliveries WHERE player_token = $1 ORDER BY created_at DESC, id DESC LIMIT $2",
+		playerToken, limit)
+	return deliveries, err
+}
+
+// GetReachability summarises deliveries since the given time for every player
+// token that has a subscription or a recorded delivery
+func (s *Service) GetReachability(since time.Time) (map[string]*Reachability, error) {
+	subs, err := s.GetAllSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []Delivery
+	if err := s.db.Select(&deliveries,
+		"SELECT "+deliveryColumns+" FROM push_deliveries WHERE player_token IS NOT NULL AND created_at >= $1 ORDER BY created_at, id",
+		since.UTC()); err != nil {
+		return nil, err
+	}
+
+	byToken := make(map[string]*Reachability)
+	get := func(token string) *Reachability {
+		r, ok := byToken[token]
+		if !ok {
+			r = &Reachability{PlayerToken: token}
+			byToken[token] = r
+		}
+		return r
+	}
+	latest := func(current *time.Time, t time.Time) *time.Time {
+		if current == nil || t.After(*current) {
+			return &t
+		}
+		return current
+	}
+
+	for _, sub := range subs {
+		if sub.PlayerToken != nil {
+			get(*sub.PlayerToken).Subscriptions++
+		}
+	}
+	for _, d := range deliveries {
+		r := get(*d.PlayerToken)
+		r.Attempts++
+		r.LastAttemptAt = latest(r.LastAttemptAt, d.CreatedAt)
+		switch d.Outcome {
+		case DeliveryDelivered:
+			r.Delivered++
+			r.LastDeliveredAt = latest(r.LastDeliveredAt, d.CreatedAt)
+			if d.NotificationType == "selection" {
+				r.LastSelectionDelivered = latest(r.LastSelectionDelivered, d.CreatedAt)
+			}
+		case DeliveryFailed, DeliveryGone:
+			r.Failed++
+			if d.Error != nil {
+				r.LastError = *d.Error
+			}
+		}
+	}
+	return byToken, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// findMigrationsPath walks up from the test's working directory to locate the
+// repo-root migrations directory.
+func findMigrationsPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		candidate := filepath.Join(dir, "migrations")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		dir = filepath.Dir(dir)
+	}
+	t.Fatal("could not locate migrations directory")
+	return ""
+}
+
+// browserKeys returns a p256dh/auth pair like a browser would hand over on
+// subscribe, so the real payload encryption runs in the test
+func browserKeys(t *testing.T) (p256dh, auth string) {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate auth: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(secret)
+}
+
+// A transient 503 is retried in the background and then delivered, without
+// holding up the send; a 410 or 401 removes the dead subscription straight
+// away without retrying. Every attempt is recorded.
+func TestSendToPlayerRetriesAndPrunesDeadSubscriptions(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "webpush_delivery_test.db")
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	var mu sync.Mutex
+	hits := map[string]int{}
+	pushServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		n := hits[r.URL.Path]
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/flaky":
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/rekeyed":
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer pushServer.Close()
+
+	s := New(db)
+	s.retryBackoff = 300 * time.Millisecond
+	if _, _, err := s.GenerateVAPIDKeys(); err != nil {
+		t.Fatalf("vapid keys: %v", err)
+	}
+
+	token := "player-token"
+	for _, path := range []string{"/flaky", "/gone", "/rekeyed"} {
+		p256dh, auth := browserKeys(t)
+		if err := s.SaveSubscription(&Subscription{
+			Endpoint:    pushServer.URL + path,
+			P256dh:      p256dh,
+			Auth:        auth,
+			PlayerToken: &token,
+		}); err != nil {
+			t.Fatalf("save subscription: %v", err)
+		}
+	}
+
+	start := time.Now()
+	sent, err := s.SendToPlayer(token, map[string]interface{}{
+		"title": "You've been selected!",
+		"data":  map[string]string{"type": "selection"},
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= s.retryBackoff {
+		t.Errorf("send took %v; the retry backoff ran in the caller", elapsed)
+	}
+	if sent != 0 {
+		t.Errorf("sent = %d, want 0 (the flaky device is still retrying)", sent)
+	}
+	if err := s.Drain(context.Background()); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	mu.Lock()
+	if hits["/flaky"] != 2 {
+		t.Errorf("flaky endpoint hit %d times, want 2", hits["/flaky"])
+	}
+	if hits["/gone"] != 1 || hits["/rekeyed"] != 1 {
+		t.Errorf("gone endpoints hit %d and %d times, want 1 each (no retry on 410 or 401)", hits["/gone"], hits["/rekeyed"])
+	}
+	mu.Unlock()
+
+	subs, err := s.GetSubscriptionsByPlayerToken(token)
+	if err != nil {
+		t.Fatalf("load subscriptions: %v", err)
+	}
+	if len(subs) != 1 || subs[0].Endpoint != pushServer.URL+"/flaky" {
+		t.Errorf("expected only the flaky subscription to survive, got %+v", subs)
+	}
+
+	deliveries, err := s.GetDeliveriesByPlayerToken(token, 10)
+	if err != nil {
+		t.Fatalf("load deliveries: %v", err)
+	}
+	outcomes := map[DeliveryOutcome]int{}
+	for _, d := range deliveries {
+		outcomes[d.Outcome]++
+		if d.NotificationType != "selection" {
+			t.Errorf("delivery %d type = %q, want selection", d.ID, d.NotificationType)
+		}
+		if d.StatusCode == nil {
+			t.Errorf("delivery %d has no status code", d.ID)
+		}
+	}
+	want := map[DeliveryOutcome]int{DeliveryRetrying: 1, DeliveryDelivered: 1, DeliveryGone: 2}
+	for outcome, n := range want {
+		if outcomes[outcome] != n {
+			t.Errorf("%s deliveries = %d, want %d (all: %v)", outcome, outcomes[outcome], n, outcomes)
+		}
+	}
+
+	reach, err := s.GetReachability(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("reachability: %v", err)
+	}
+	r := reach[token]
+	if r == nil || r.Subscriptions != 1 || r.Delivered != 1 || r.Failed != 2 || r.LastSelectionDelivered == nil {
+		t.Errorf("unexpected reachability %+v", r)
+	}
+}
@@ -4,6 +4,7 @@ package webpush
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
@@ -20,6 +21,7 @@ func (s *Service) SetupHandlers(mux ...*http.ServeMux) {
 	}
 	register("/api/vapid-public-key", s.handleGetVAPIDPublicKey)
 	register("/api/push/subscribe", s.handleSubscribe)
+	register("/api/push/resubscribe", s.handleResubscribe)
 	register("/api/push/unsubscribe", s.handleUnsubscribe)
 	register("/api/push/test", s.handleTestPush)
 	register("/api/push/test-player", s.handleTestPlayerPush)
@@ -100,6 +102,40 @@ func (s *Service) handleSubscribe(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleResubscribe handles the service worker replacing a subscription the
+// browser has rotated. It is exempt from CSRF checks, so it can only move an
+// existing subscription, never create one for a player of the caller's choosing.
+func (s *Service) handleResubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ResubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OldEndpoint == "" || req.Endpoint == "" {
+		http.Error(w, "Invalid subscription data", http.StatusBadRequest)
+		return
+	}
+
+	subscription := &Subscription{
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: r.UserAgent(),
+	}
+	if err := s.ReplaceSubscription(r.Context(), req.OldEndpoint, subscription); err != nil {
+		if errors.Is(err, ErrUnknownSubscription) {
+			http.Error(w, "Subscription not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error replacing subscription: %v", err)
+		http.Error(w, "Failed to save subscription", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
 // handleUnsubscribe handles unsubscription requests
 func (s *Service) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -156,14 +192,14 @@ func (s *Service) handleTestPush(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Printf("Found %d active subscriptions to notify", len(subs))
 
-	// Send notifications in a goroutine
-	go func() {
+	// Send notifications in the background
+	s.goBackground(func() {
 		if err := s.SendToAll(reqData.Message); err != nil {
 			log.Printf("Error during test push broadcast: %v", err)
 		}
 		duration := time.Since(startTime)
 		log.Printf("Test push broadcast completed in %v", duration)
-	}()
+	})
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -0,0 +1,67 @@
+// Copyright (c) 2025-2026 James Hartt. Licensed under the MIT License.
+
+package webpush
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jim-dot-tennis/internal/database"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The resubscribe endpoint skips CSRF checks for the service worker, so it
+// may only move a subscription the caller proves they held, keeping its
+// player; it must never create one or choose the player.
+func TestResubscribeOnlyReplacesKnownSubscriptions(t *testing.T) {
+	db, err := database.New(database.Config{Driver: "sqlite3", FilePath: filepath.Join(t.TempDir(), "webpush_resubscribe_test.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	if err := db.ExecuteMigrations(findMigrationsPath(t)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	s := New(db)
+	p256dh, authSecret := browserKeys(t)
+	token := "ann-token"
+	if err := s.SaveSubscription(&Subscription{Endpoint: "https://push.example/old", P256dh: p256dh, Auth: authSecret, PlayerToken: &token}); err != nil {
+		t.Fatalf("save subscription: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	s.SetupHandlers(mux)
+	post := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/push/resubscribe", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	keys := `"keys": {"p256dh": "` + p256dh + `", "auth": "` + authSecret + `"}`
+
+	if code := post(`{"oldEndpoint": "https://push.example/guess", "endpoint": "https://push.example/attacker", "playerToken": "ann-token", ` + keys + `}`); code != http.StatusNotFound {
+		t.Errorf("unknown old endpoint = %d; want 404", code)
+	}
+	if code := post(`{"endpoint": "https://push.example/attacker", ` + keys + `}`); code != http.StatusBadRequest {
+		t.Errorf("missing old endpoint = %d; want 400", code)
+	}
+	if all, _ := s.GetAllSubscriptions(); len(all) != 1 {
+		t.Fatalf("subscriptions after refused requests = %d; want 1", len(all))
+	}
+
+	if code := post(`{"oldEndpoint": "https://push.example/old", "endpoint": "https://push.example/new", "playerToken": "someone-else", ` + keys + `}`); code != http.StatusCreated {
+		t.Fatalf("resubscribe = %d; want 201", code)
+	}
+	subs, err := s.GetSubscriptionsByPlayerToken(token)
+	if err != nil || len(subs) != 1 || subs[0].Endpoint != "https://push.example/new" {
+		t.Errorf("subscriptions after resubscribe = %+v, %v; want only the new endpoint for ann", subs, err)
+	}
+	if all, _ := s.GetAllSubscriptions(); len(all) != 1 {
+		t.Errorf("subscriptions after resubscribe = %d; want 1", len(all))
+	}
+}
@@ -3,6 +3,7 @@
 package webpush
 
 import (
+	"context"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
@@ -10,8 +11,8 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/SherClockHolmes/webpush-go"
@@ -47,14 +48,56 @@ type SubscriptionRequest struct {
 	} `json:"keys"`
 }
 
+// ResubscriptionRequest is sent by the service worker when the browser
+// replaces a subscription. It can't carry a CSRF token, so the old endpoint
+// is its proof: only the browser that held the subscription knows it.
+type ResubscriptionRequest struct {
+	OldEndpoint string `json:"oldEndpoint"`
+	Endpoint    string `json:"endpoint"`
+	Keys        struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// ErrUnknownSubscription is returned when replacing a subscription we don't hold
+var ErrUnknownSubscription = errors.New("unknown push subscription")
+
 // Service manages web push operations
 type Service struct {
-	db *database.DB
+	db           *database.DB
+	retryBackoff time.Duration  // Wait before the first retry of a transient failure
+	background   sync.WaitGroup // Sends still running after their request returned
 }
 
 // New creates a new WebPush service
 func New(db *database.DB) *Service {
-	return &Service{db: db}
+	return &Service{db: db, retryBackoff: defaultRetryBackoff}
+}
+
+// goBackground runs a send that outlives its request, tracked so shutdown
+// can wait for it
+func (s *Service) goBackground(fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn()
+	}()
+}
+
+// Drain waits for background sends to finish, giving up when ctx is done
+func (s *Service) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // GenerateVAPIDKeys generates a new pair of VAPID keys if none exist
@@ -177,6 +220,32 @@ func (s *Service) SaveSubscription(sub *Subscription) error {
 	return err
 }
 
+// ReplaceSubscription swaps the subscription at oldEndpoint for sub, which
+// keeps the old one's player. Returns ErrUnknownSubscription if there is no
+// subscription at oldEndpoint.
+func (s *Service) ReplaceSubscription(ctx context.Context, oldEndpoint string, sub *Subscription) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		var playerToken *string
+		err := s.db.GetContext(ctx, &playerToken, "SELECT player_token FROM push_subscriptions WHERE endpoint = $1", oldEndpoint)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnknownSubscription
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM push_subscriptions WHERE endpoint = $1", oldEndpoint); err != nil {
+			return err
+		}
+		_, err = s.db.ExecContext(ctx,
+			`INSERT INTO push_subscriptions (endpoint, p256dh, auth, platform, user_agent, player_token)
+			 VALUES ($1, $2, $3, $4, $5, $6)
+			 ON CONFLICT(endpoint) DO UPDATE SET player_token = $6, p256dh = $2, auth = $3`,
+			sub.Endpoint, sub.P256dh, sub.Auth, sub.Platform, sub.UserAgent, playerToken,
+		)
+		return err
+	})
+}
+
 // DeleteSubscription removes a subscription by endpoint
 func (s *Service) DeleteSubscription(endpoint string) error {
 	_, err := s.db.Exec(
@@ -217,8 +286,37 @@ func (s *Service) GetSubscriptionsByPlayerToken(playerToken string) ([]Subscript
 	return subs, err
 }
 
+// ReassignPlayerToken moves a player's push subscriptions and delivery
+// history from an old link token to their new one after the link is rotated.
+// It runs inside any unit of work ctx carries.
+func (s *Service) ReassignPlayerToken(ctx context.Context, oldToken, newToken string) (int64, error) {
+	if _, err := s.db.ExecContext(ctx, "UPDATE push_deliveries SET player_token = $1 WHERE player_token = $2", newToken, oldToken); err != nil {
+		return 0, err
+	}
+	result, err := s.db.ExecContext(ctx, "UPDATE push_subscriptions SET player_token = $1 WHERE player_token = $2", newToken, oldToken)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteSubscriptionsByPlayerToken removes every device subscribed under a
+// player token except keepEndpoint, if given. Used when a leaked link is
+// revoked, since a subscription made from a leaked link can't be told apart
+// from the player's own; only the device asking for the revocation is known
+// to be theirs. It runs inside any unit of work ctx carries.
+func (s *Service) DeleteSubscriptionsByPlayerToken(ctx context.Context, playerToken, keepEndpoint string) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM push_subscriptions WHERE player_token = $1 AND endpoint != $2", playerToken, keepEndpoint)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // SendToPlayer sends a push notification to all devices for a given player token.
-// Returns the number of successful sends and any error.
+// Returns the number of devices that accepted it first time and any error;
+// devices that failed transiently are retried in the background. Each
+// attempt is recorded in push_deliveries under the payload's data.type.
 func (s *Service) SendToPlayer(playerToken string, payload map[string]interface{}) (int, error) {
 	subs, err := s.GetSubscriptionsByPlayerToken(playerToken)
 	if err != nil {
@@ -236,7 +334,7 @@ func (s *Service) SendToPlayer(playerToken string, payload map[string]interface{
 
 	successCount := 0
 	for _, sub := range subs {
-		if err := s.sendRawNotification(sub, payloadBytes); err != nil {
+		if err := s.deliver(sub, payloadBytes, notificationType(payload)); err != nil {
 			log.Printf("Failed to send notification to player %s device %s: %v", playerToken, sub.Endpoint, err)
 		} else {
 			successCount++
@@ -246,6 +344,19 @@ func (s *Service) SendToPlayer(playerToken string, payload map[string]interface{
 	return successCount, nil
 }
 
+// notificationType pulls data.type out of a payload for delivery tracking
+func notificationType(payload map[string]interface{}) string {
+	switch data := payload["data"].(type) {
+	case map[string]string:
+		return data["type"]
+	case map[string]interface{}:
+		if t, ok := data["type"].(string); ok {
+			return t
+		}
+	}
+	return ""
+}
+
 // SendNotification sends a push notification to a subscription with a simple message string
 func (s *Service) SendNotification(sub Subscription, message string) error {
 	payload, err := json.Marshal(map[string]string{
@@ -254,14 +365,16 @@ func (s *Service) SendNotification(sub Subscription, message string) error {
 	if err != nil {
 		return err
 	}
-	return s.sendRawNotification(sub, payload)
+	return s.deliver(sub, payload, "broadcast")
 }
 
-// sendRawNotification sends a pre-encoded payload to a push subscription
-func (s *Service) sendRawNotification(sub Subscription, payload []byte) error {
+// sendOnce makes a single attempt to deliver a pre-encoded payload. It returns
+// the push service's status code (0 if no response was received) and an error
+// for anything other than a 2xx.
+func (s *Service) sendOnce(sub Subscription, payload []byte) (int, error) {
 	vapidPublic, vapidPrivate, err := s.GetVAPIDKeys()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	log.Printf("Sending notification to endpoint: %s", sub.Endpoint)
@@ -287,24 +400,19 @@ func (s *Service) sendRawNotification(sub Subscription, payload []byte) error {
 
 	if err != nil {
 		log.Printf("Error sending notification: %v", err)
-		return err
+		return 0, err
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Response body: %s", string(body))
 
 	if resp.StatusCode >= 400 {
 		log.Printf("Failed to send notification, status: %d", resp.StatusCode)
-		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusUnauthorized {
-			log.Printf("Removing stale subscription (status %d): %s", resp.StatusCode, sub.Endpoint)
-			s.DeleteSubscription(sub.Endpoint)
-		}
-		return fmt.Errorf("failed to send notification, status: %d, body: %s", resp.StatusCode, string(body))
+		return resp.StatusCode, fmt.Errorf("failed to send notification, status: %d, body: %s", resp.StatusCode, string(body))
 	}
 
 	log.Printf("Successfully sent notification")
-	return nil
+	return resp.StatusCode, nil
 }
 
 // SendToAll sends a push notification to all subscriptions
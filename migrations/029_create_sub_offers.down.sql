@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_sub_offer_recipients_player;
+DROP TABLE IF EXISTS sub_offer_recipients;
+
+DROP TRIGGER IF EXISTS chk_sub_offers_status_update;
+DROP TRIGGER IF EXISTS chk_sub_offers_status_insert;
+DROP INDEX IF EXISTS idx_sub_offers_status;
+DROP INDEX IF EXISTS idx_sub_offers_fixture;
+DROP TABLE IF EXISTS sub_offers;
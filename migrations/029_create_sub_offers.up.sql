@@ -0,0 +1,54 @@
+-- Substitute call-outs: when a team is short for a fixture, a captain can
+-- broadcast a 'find a sub' offer to eligible players from other home-club
+-- teams. The first recipient to accept is added to the fixture selection;
+-- everyone after that is told the spot has been filled.
+CREATE TABLE IF NOT EXISTS sub_offers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixture_id INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    gender TEXT,                       -- 'Men' / 'Women' when only one is short, NULL for either
+    status TEXT NOT NULL DEFAULT 'Open',
+    filled_by_player_id TEXT,
+    created_by_user_id INTEGER,
+    filled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (fixture_id) REFERENCES fixtures(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (filled_by_player_id) REFERENCES players(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sub_offers_fixture ON sub_offers(fixture_id, team_id);
+CREATE INDEX IF NOT EXISTS idx_sub_offers_status ON sub_offers(status);
+
+CREATE TRIGGER IF NOT EXISTS chk_sub_offers_status_insert
+BEFORE INSERT ON sub_offers
+FOR EACH ROW
+WHEN NEW.status NOT IN ('Open', 'Filled', 'Cancelled')
+BEGIN
+    SELECT RAISE(FAIL, 'Invalid sub offer status');
+END;
+
+CREATE TRIGGER IF NOT EXISTS chk_sub_offers_status_update
+BEFORE UPDATE ON sub_offers
+FOR EACH ROW
+WHEN NEW.status NOT IN ('Open', 'Filled', 'Cancelled')
+BEGIN
+    SELECT RAISE(FAIL, 'Invalid sub offer status');
+END;
+
+-- One row per player the offer was broadcast to, tracking their response.
+CREATE TABLE IF NOT EXISTS sub_offer_recipients (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sub_offer_id INTEGER NOT NULL,
+    player_id TEXT NOT NULL,
+    response TEXT NOT NULL DEFAULT 'Pending',
+    notified BOOLEAN NOT NULL DEFAULT 0,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(sub_offer_id, player_id),
+    FOREIGN KEY (sub_offer_id) REFERENCES sub_offers(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sub_offer_recipients_player ON sub_offer_recipients(player_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_selection_alerts_player;
+DROP INDEX IF EXISTS idx_selection_alerts_fixture;
+DROP TABLE IF EXISTS selection_alerts;
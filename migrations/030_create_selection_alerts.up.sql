@@ -0,0 +1,21 @@
+-- Selection alerts: raised when a player who is already in fixture_players
+-- changes their availability for that fixture (directly, via a date
+-- exception, or via their general day-of-week availability). The team and
+-- day captain get a push, and the team-selection screen flags the player
+-- until a captain acknowledges the alert.
+CREATE TABLE IF NOT EXISTS selection_alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixture_id INTEGER NOT NULL,
+    player_id TEXT NOT NULL,
+    team_id INTEGER,                   -- Team the player was selected for (managing team in derbies)
+    previous_status TEXT NOT NULL,
+    new_status TEXT NOT NULL,
+    acknowledged_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (fixture_id) REFERENCES fixtures(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_alerts_fixture ON selection_alerts(fixture_id, acknowledged_at);
+CREATE INDEX IF NOT EXISTS idx_selection_alerts_player ON selection_alerts(player_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS chk_fixture_changes_type_insert;
+DROP INDEX IF EXISTS idx_fixture_changes_fixture;
+DROP TABLE IF EXISTS fixture_changes;
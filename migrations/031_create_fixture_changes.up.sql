@@ -0,0 +1,24 @@
+-- Fixture change log: one row per player-visible change to a fixture (date,
+-- Postponed/Cancelled status, or resolved venue). Drives the 'your fixture has
+-- changed' notifications and the iCal SEQUENCE so re-downloaded calendar
+-- entries replace the old event rather than being ignored.
+CREATE TABLE IF NOT EXISTS fixture_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixture_id INTEGER NOT NULL,
+    change_type TEXT NOT NULL,         -- 'Date', 'Status' or 'Venue'
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+    notified_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (fixture_id) REFERENCES fixtures(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_fixture_changes_fixture ON fixture_changes(fixture_id);
+
+CREATE TRIGGER IF NOT EXISTS chk_fixture_changes_type_insert
+BEFORE INSERT ON fixture_changes
+FOR EACH ROW
+WHEN NEW.change_type NOT IN ('Date', 'Status', 'Venue')
+BEGIN
+    SELECT RAISE(FAIL, 'Invalid fixture change type');
+END;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_push_deliveries_created_at;
+DROP INDEX IF EXISTS idx_push_deliveries_player_token;
+DROP TABLE IF EXISTS push_deliveries;
@@ -0,0 +1,19 @@
+-- Push deliveries: one row per attempt to deliver a web push message to a
+-- subscription, so admins can see who is actually reachable. Endpoint and
+-- player token are copied rather than referenced because dead subscriptions
+-- (404/410 from the push service) are deleted as soon as they are seen.
+CREATE TABLE IF NOT EXISTS push_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_token TEXT,
+    endpoint TEXT NOT NULL,
+    notification_type TEXT NOT NULL DEFAULT '',  -- payload data.type, e.g. 'selection'
+    attempt INTEGER NOT NULL DEFAULT 1,
+    outcome TEXT NOT NULL,                       -- 'Delivered', 'Retrying', 'Failed' or 'Gone'
+    status_code INTEGER,                         -- NULL when the request never got a response
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_deliveries_player_token ON push_deliveries(player_token, created_at);
+CREATE INDEX IF NOT EXISTS idx_push_deliveries_created_at ON push_deliveries(created_at);
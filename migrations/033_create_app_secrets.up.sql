@@ -0,0 +1,8 @@
+-- App secrets: server-side keys generated on first use and kept across
+-- restarts, e.g. the HMAC key that signs push action links. An environment
+-- variable can override each one; see internal/auth/signed_links.go.
+CREATE TABLE IF NOT EXISTS app_secrets (
+    name TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
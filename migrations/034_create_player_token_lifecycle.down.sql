@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_player_token_accesses_player;
+DROP TABLE IF EXISTS player_token_accesses;
+DROP INDEX IF EXISTS idx_retired_player_tokens_player;
+DROP TABLE IF EXISTS retired_player_tokens;
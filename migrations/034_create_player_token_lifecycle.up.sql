@@ -0,0 +1,34 @@
+-- Player link lifecycle. A player's private link is the auth_token of their
+-- fantasy_mixed_doubles pairing; rotating the link assigns a fresh pairing and
+-- records the old token here so it can keep redirecting to the new link until
+-- grace_until, after which it is refused as replaced.
+CREATE TABLE IF NOT EXISTS retired_player_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id TEXT NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    replaced_by_token TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    retired_by TEXT NOT NULL DEFAULT '',  -- admin username, or 'player' for self-service
+    grace_until TIMESTAMP NOT NULL,       -- redirect to the new link until this time
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_retired_player_tokens_player ON retired_player_tokens(player_id);
+
+-- One row per (token, device) that has opened a player's link, so admins and
+-- players can see where a link is being used and spot one that has leaked.
+CREATE TABLE IF NOT EXISTS player_token_accesses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id TEXT NOT NULL,
+    token TEXT NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',          -- most recent address seen for this device
+    access_count INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (token, user_agent),
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_token_accesses_player ON player_token_accesses(player_id);
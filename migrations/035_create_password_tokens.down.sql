@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS chk_password_tokens_purpose_insert;
+DROP INDEX IF EXISTS idx_password_tokens_user;
+DROP TABLE IF EXISTS password_tokens;
+ALTER TABLE users DROP COLUMN email;
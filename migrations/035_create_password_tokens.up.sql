@@ -0,0 +1,26 @@
+-- Password tokens back the invitation and forgot-password flows. Only the
+-- SHA-256 of each token is stored; the raw token exists only in the link sent
+-- to the user. A token is single use (used_at) and time limited (expires_at).
+ALTER TABLE users ADD COLUMN email TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS password_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    purpose TEXT NOT NULL,             -- 'invite' or 'reset'
+    token_hash TEXT NOT NULL UNIQUE,   -- hex SHA-256 of the raw token
+    created_by TEXT NOT NULL DEFAULT '', -- admin username, or 'self' for forgot-password
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_tokens_user ON password_tokens(user_id);
+
+CREATE TRIGGER IF NOT EXISTS chk_password_tokens_purpose_insert
+BEFORE INSERT ON password_tokens
+FOR EACH ROW
+WHEN NEW.purpose NOT IN ('invite', 'reset')
+BEGIN
+    SELECT RAISE(FAIL, 'Invalid password token purpose');
+END;
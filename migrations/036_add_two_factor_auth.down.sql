@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_trusted_devices_session;
+DROP INDEX IF EXISTS idx_trusted_devices_user;
+DROP TABLE IF EXISTS trusted_devices;
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+ALTER TABLE sessions DROP COLUMN mfa_pending;
+ALTER TABLE users DROP COLUMN totp_last_step;
+ALTER TABLE users DROP COLUMN totp_enabled_at;
+ALTER TABLE users DROP COLUMN totp_secret;
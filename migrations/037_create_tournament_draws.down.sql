@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tournament_match_players_player;
+DROP INDEX IF EXISTS idx_tournament_match_players_match;
+DROP TABLE IF EXISTS tournament_match_players;
+DROP INDEX IF EXISTS idx_tournament_matches_draw;
+DROP TABLE IF EXISTS tournament_matches;
+DROP TABLE IF EXISTS tournament_draws;
@@ -0,0 +1,51 @@
+-- Cup draws synced from CourtHive for each tournament. A draw belongs to one
+-- of the tournament's events (e.g. Mixed Doubles), so the event is kept on it.
+CREATE TABLE IF NOT EXISTS tournament_draws (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_id INTEGER NOT NULL,
+    courthive_draw_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    draw_type TEXT NOT NULL DEFAULT '',
+    event_name TEXT NOT NULL DEFAULT '',
+    event_type TEXT NOT NULL DEFAULT '',  -- SINGLES or DOUBLES
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    UNIQUE (tournament_id, courthive_draw_id)
+);
+
+-- One row per draw position: round_number counts from the first round and
+-- round_position from the top of the bracket. winning_side is 1 or 2 once
+-- the match is decided.
+CREATE TABLE IF NOT EXISTS tournament_matches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    draw_id INTEGER NOT NULL,
+    courthive_match_up_id TEXT NOT NULL,
+    round_number INTEGER NOT NULL DEFAULT 0,
+    round_position INTEGER NOT NULL DEFAULT 0,
+    round_name TEXT NOT NULL DEFAULT '',
+    side1_name TEXT NOT NULL DEFAULT '',
+    side2_name TEXT NOT NULL DEFAULT '',
+    winning_side INTEGER,
+    score TEXT NOT NULL DEFAULT '',  -- from side 1's point of view
+    status TEXT NOT NULL DEFAULT '',
+    played_date TIMESTAMP,
+    FOREIGN KEY (draw_id) REFERENCES tournament_draws(id) ON DELETE CASCADE,
+    UNIQUE (draw_id, courthive_match_up_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_matches_draw ON tournament_matches(draw_id);
+
+-- The individuals on each side of a match. player_id is set when the
+-- participant's name matched one of our players.
+CREATE TABLE IF NOT EXISTS tournament_match_players (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id INTEGER NOT NULL,
+    side INTEGER NOT NULL,
+    participant_name TEXT NOT NULL,
+    player_id TEXT,
+    FOREIGN KEY (match_id) REFERENCES tournament_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_match_players_match ON tournament_match_players(match_id);
+CREATE INDEX IF NOT EXISTS idx_tournament_match_players_player ON tournament_match_players(player_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tournament_entries_partner;
+DROP INDEX IF EXISTS idx_tournament_entries_player;
+DROP INDEX IF EXISTS idx_tournament_entries_event;
+DROP TABLE IF EXISTS tournament_entries;
+DROP INDEX IF EXISTS idx_tournament_events_tournament;
+DROP TABLE IF EXISTS tournament_events;
@@ -0,0 +1,39 @@
+-- Events members can enter for a tournament, e.g. the Parks League Cup
+-- Mixed Doubles. courthive_event_id is the CourtHive event approved entries
+-- are exported to.
+CREATE TABLE IF NOT EXISTS tournament_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('Singles', 'Doubles')),
+    gender TEXT NOT NULL CHECK (gender IN ('Men', 'Women', 'Mixed', 'Open')),
+    entry_deadline TIMESTAMP NOT NULL,
+    courthive_event_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_events_tournament ON tournament_events(tournament_id);
+
+-- A player's entry into an event, with their partner for doubles. Admins
+-- approve entries; sync_status tracks whether an approved entry has reached
+-- CourtHive.
+CREATE TABLE IF NOT EXISTS tournament_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id INTEGER NOT NULL,
+    player_id TEXT NOT NULL,
+    partner_id TEXT,
+    status TEXT NOT NULL DEFAULT 'Pending' CHECK (status IN ('Pending', 'Approved', 'Rejected', 'Withdrawn')),
+    sync_status TEXT NOT NULL DEFAULT 'NotSynced' CHECK (sync_status IN ('NotSynced', 'Synced', 'Failed')),
+    sync_error TEXT NOT NULL DEFAULT '',
+    synced_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (event_id) REFERENCES tournament_events(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    FOREIGN KEY (partner_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_entries_event ON tournament_entries(event_id);
+CREATE INDEX IF NOT EXISTS idx_tournament_entries_player ON tournament_entries(player_id);
+CREATE INDEX IF NOT EXISTS idx_tournament_entries_partner ON tournament_entries(partner_id);
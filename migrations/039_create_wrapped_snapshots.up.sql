@@ -0,0 +1,12 @@
+-- Frozen copies of a finished season's Wrapped, so corrections to old
+-- results don't rewrite what members already saw. player_id is '' for the
+-- club edition and the player's ID for a personal edition; data is the
+-- edition as JSON.
+CREATE TABLE IF NOT EXISTS wrapped_snapshots (
+    season_id INTEGER NOT NULL,
+    player_id TEXT NOT NULL DEFAULT '',
+    data TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (season_id, player_id),
+    FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE
+);
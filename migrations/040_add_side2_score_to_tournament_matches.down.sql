@@ -0,0 +1 @@
+ALTER TABLE tournament_matches DROP COLUMN score_side2;
@@ -0,0 +1,4 @@
+-- CourtHive gives each match's score from both sides. score stays side 1's
+-- view for the brackets; score_side2 lets a side 2 player's history show the
+-- score their way round. Rows synced before this are empty until the next sync.
+ALTER TABLE tournament_matches ADD COLUMN score_side2 TEXT NOT NULL DEFAULT '';
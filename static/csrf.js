@@ -0,0 +1,74 @@
+// Adds the CSRF token to every state-changing request the page makes.
+// The server keeps the token in the csrf_token cookie; this copies it into
+// form posts, HTMX requests and same-origin fetch calls.
+(function () {
+  const COOKIE = 'csrf_token';
+  const FIELD = 'csrf_token';
+  const HEADER = 'X-CSRF-Token';
+  const SAFE_METHODS = ['GET', 'HEAD', 'OPTIONS', 'TRACE'];
+
+  function csrfToken() {
+    const match = document.cookie.match(new RegExp('(?:^|; )' + COOKIE + '=([^;]*)'));
+    return match ? decodeURIComponent(match[1]) : '';
+  }
+
+  function isUnsafe(method) {
+    return SAFE_METHODS.indexOf((method || 'GET').toUpperCase()) === -1;
+  }
+
+  function isSameOrigin(url) {
+    try {
+      return new URL(url, window.location.href).origin === window.location.origin;
+    } catch (e) {
+      return false;
+    }
+  }
+
+  // Read the token at submit time rather than page load, so a page left open
+  // across a login or logout still sends the current one
+  function addTokenToForm(form) {
+    if (!isUnsafe(form.getAttribute('method')) || !isSameOrigin(form.action)) {
+      return;
+    }
+    let input = form.querySelector('input[name="' + FIELD + '"]');
+    if (!input) {
+      input = document.createElement('input');
+      input.type = 'hidden';
+      input.name = FIELD;
+      form.appendChild(input);
+    }
+    input.value = csrfToken();
+  }
+
+  document.addEventListener('submit', function (event) {
+    addTokenToForm(event.target);
+  }, true);
+
+  // form.submit() doesn't fire a submit event
+  const nativeSubmit = HTMLFormElement.prototype.submit;
+  HTMLFormElement.prototype.submit = function () {
+    addTokenToForm(this);
+    return nativeSubmit.call(this);
+  };
+
+  document.addEventListener('htmx:configRequest', function (event) {
+    if (isUnsafe(event.detail.verb)) {
+      event.detail.headers[HEADER] = csrfToken();
+    }
+  });
+
+  const nativeFetch = window.fetch;
+  if (nativeFetch) {
+    window.fetch = function (input, init) {
+      const method = (init && init.method) || (input instanceof Request ? input.method : 'GET');
+      const url = input instanceof Request ? input.url : String(input);
+      if (isUnsafe(method) && isSameOrigin(url)) {
+        init = Object.assign({}, init);
+        const headers = new Headers(init.headers || (input instanceof Request ? input.headers : undefined));
+        headers.set(HEADER, csrfToken());
+        init.headers = headers;
+      }
+      return nativeFetch.call(window, input, init);
+    };
+  }
+})();
@@ -76,6 +76,9 @@ self.addEventListener('push', function(event) {
       if (payload.tag) {
         options.tag = payload.tag;
       }
+      if (payload.actions) {
+        options.actions = payload.actions;
+      }
     } catch (e) {
       console.log('Service Worker: JSON parse failed, using text:', e);
       options.body = event.data.text() || options.body;
@@ -87,7 +90,7 @@ self.addEventListener('push', function(event) {
   options.renotify = true;
   options.tag = options.tag || 'jim-tennis-' + Date.now();
   options.vibrate = [100, 50, 100];
-  options.actions = [
+  options.actions = options.actions || [
     { action: 'open', title: 'Open' },
     { action: 'close', title: 'Close' }
   ];
@@ -126,6 +129,29 @@ self.addEventListener('notificationclick', function(event) {
   }
 
   var url = (event.notification.data && event.notification.data.url) || '/';
+
+  // Action buttons that carry their own signed endpoint (e.g. Available /
+  // If needed / Unavailable) are answered in the background; the server
+  // sends a confirmation push. If the request fails, open the page instead.
+  var actionUrl = event.action && event.notification.data && event.notification.data['action_' + event.action];
+  if (actionUrl) {
+    event.waitUntil(
+      fetch(actionUrl, { method: 'POST', credentials: 'same-origin' })
+        .then(function(response) {
+          if (!response.ok) {
+            throw new Error('Action failed with status ' + response.status);
+          }
+        })
+        .catch(function(error) {
+          console.error('Service Worker: Notification action failed:', error);
+          if (clients.openWindow) {
+            return clients.openWindow(url);
+          }
+        })
+    );
+    return;
+  }
+
   console.log('Service Worker: Opening URL:', url);
 
   event.waitUntil(
@@ -149,19 +175,28 @@ self.addEventListener('notificationclick', function(event) {
   );
 });
 
-// Handle push subscription change (browser rotates keys)
+// Handle push subscription change (browser rotates keys). The worker can't
+// read the CSRF cookie, so it sends the old endpoint as proof instead; if the
+// browser doesn't say what the old one was, the player re-enables
+// notifications from their availability page.
 self.addEventListener('pushsubscriptionchange', function(event) {
   console.log('Service Worker: Push subscription changed');
+  if (!event.oldSubscription) {
+    return;
+  }
+  var oldEndpoint = event.oldSubscription.endpoint;
   event.waitUntil(
     self.registration.pushManager.subscribe({
       userVisibleOnly: true,
-      applicationServerKey: event.oldSubscription ? event.oldSubscription.options.applicationServerKey : null
+      applicationServerKey: event.oldSubscription.options.applicationServerKey
     }).then(function(subscription) {
       console.log('Service Worker: Re-subscribed:', subscription);
-      return fetch('/api/push/subscribe', {
+      var payload = subscription.toJSON();
+      payload.oldEndpoint = oldEndpoint;
+      return fetch('/api/push/resubscribe', {
         method: 'POST',
         headers: { 'Content-Type': 'application/json' },
-        body: JSON.stringify(subscription)
+        body: JSON.stringify(payload)
       });
     })
   );
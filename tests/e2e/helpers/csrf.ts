@@ -0,0 +1,20 @@
+import { Page } from "@playwright/test";
+
+/**
+ * Headers carrying the CSRF token for direct page.request posts.
+ * The server sets the csrf_token cookie on the first visit, so one GET is
+ * made first if the browser context doesn't have it yet.
+ */
+export async function csrfHeaders(
+  page: Page,
+): Promise<Record<string, string>> {
+  const findToken = async () =>
+    (await page.context().cookies()).find((c) => c.name === "csrf_token");
+
+  let token = await findToken();
+  if (!token) {
+    await page.request.get("/");
+    token = await findToken();
+  }
+  return { "X-CSRF-Token": token?.value ?? "" };
+}
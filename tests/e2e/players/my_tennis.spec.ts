@@ -1,5 +1,6 @@
 import { test, expect } from "../fixtures/test-fixtures";
 import { expectNoErrorBanner } from "../helpers/assertions";
+import { csrfHeaders } from "../helpers/csrf";
 
 const VALID_TOKEN = "Sabalenka_Djokovic_Gauff_Sinner";
 const PLAYER_ID = "p-alice";
@@ -30,6 +31,7 @@ test.describe("My Tennis — write-only GET contract (tier-aware)", () => {
     const unique = `ritual-${Date.now()}`;
     // POST a single tier-6 field via the token URL (pre_match_ritual lives there).
     const res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "6",
         intent: "finish",
@@ -68,6 +70,7 @@ test.describe("My Tennis — confirmation page", () => {
   }) => {
     const unique = `signature-${Date.now()}`;
     const res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "3",
         intent: "finish",
@@ -104,6 +107,7 @@ test.describe("My Tennis — merge semantics (round-trip via admin)", () => {
 
     // 1) Write field A (walkout_song), tier 6.
     let res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "6",
         intent: "finish",
@@ -115,6 +119,7 @@ test.describe("My Tennis — merge semantics (round-trip via admin)", () => {
     // 2) Write field B (tennis_spirit_animal) in a separate POST, same tier.
     //    If merge semantics are correct, field A is preserved.
     res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "6",
         intent: "finish",
@@ -148,6 +153,7 @@ test.describe("My Tennis — merge semantics (round-trip via admin)", () => {
     // Seeded improvement_focus = ["serve","volleys"].
     // POST the explicit clear — repo should set the column to `[]`.
     const res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "5",
         intent: "finish",
@@ -1,4 +1,5 @@
 import { test, expect } from "../fixtures/test-fixtures";
+import { csrfHeaders } from "../helpers/csrf";
 
 // Sprint 018 WI-111: wizard contract tests for /my-profile/{token}.
 //
@@ -111,6 +112,7 @@ test.describe("My Tennis wizard — monotonic progress", () => {
 
     // Submit a tier-2 POST with intent=continue.
     const res = await page.request.post(`/my-profile/${VALID_TOKEN}`, {
+      headers: await csrfHeaders(page),
       form: {
         tier: "2",
         intent: "continue",